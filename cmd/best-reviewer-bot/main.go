@@ -2,6 +2,7 @@
 package main
 
 import (
+	"cmp"
 	"context"
 	"encoding/json"
 	"flag"
@@ -9,14 +10,24 @@ import (
 	"log/slog"
 	"net/http"
 	"os"
+	"strings"
 	"sync"
 	"sync/atomic"
 	"time"
 
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/availability"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/events"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/reviewer"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
 	"github.com/codeGROOVE-dev/prx/pkg/prx"
+	"github.com/codeGROOVE-dev/retry"
+)
+
+const (
+	eventMaxRetries = 3                // Max retries for processing a PR event
+	eventMaxDelay   = 10 * time.Second // Max delay between retries
 )
 
 var (
@@ -24,15 +35,32 @@ var (
 	appID      = flag.String("app-id", "", "GitHub App ID for authentication")
 	appKeyPath = flag.String("app-key-path", "", "Path to GitHub App private key file")
 
+	// GitHub Enterprise Server flags.
+	apiURL       = flag.String("api-url", "", "GitHub REST API URL for GitHub Enterprise Server, e.g. https://github.example.com/api/v3 (default $GITHUB_API_URL or https://api.github.com)")
+	graphQLURL   = flag.String("graphql-url", "", "GitHub GraphQL URL (default $GITHUB_GRAPHQL_URL or derived from -api-url)")
+	sprinklerURL = flag.String("sprinkler-url", "", "Sprinkler WebSocket URL for PR events (default $SPRINKLER_URL or the public sprinkler server)")
+
+	// Event source flags.
+	useSprinkler  = flag.Bool("sprinkler", true, "Receive PR events from the sprinkler WebSocket service (on GitHub Enterprise Server, only with -sprinkler-url)")
+	webhookSecret = flag.String("webhook-secret", "", "Secret for verifying webhooks delivered to /webhook (default $GITHUB_WEBHOOK_SECRET; unset disables the endpoint)")
+	eventPoll     = flag.Duration("event-poll", 0, "Interval for polling each org for PRs without reviewers, as a fallback event source (0 disables)")
+	eventWorkers  = flag.Int("event-workers", 4, "Number of PR events processed concurrently")
+
 	// Behavior flags.
 	loopDelay   = flag.Duration("loop-delay", 5*time.Minute, "Loop delay between polling cycles (default: 5m)")
 	dryRun      = flag.Bool("dry-run", false, "Run in dry-run mode (no actual reviewer assignments)")
 	minOpenTime = flag.Duration("min-age", 0, "Minimum time since last activity for PR assignment")
 	maxOpenTime = flag.Duration("max-age", 10*365*24*time.Hour, "Maximum time since last activity for PR assignment")
+	dailyCap    = flag.Int("daily-cap", 0, "Maximum review requests per reviewer in 24 hours (0 for no cap)")
+	oooCalendar = flag.String("ooo-calendar", "", "Out-of-office calendar file (.ics or YAML date ranges)")
+	inactive    = flag.Duration("inactive-after", availability.DefaultInactiveAfter, "Inactivity in the repository after which reviewers are penalized (0 disables)")
 
 	prCountCache = flag.Duration("pr-count-cache", 6*time.Hour, "Cache duration for PR count queries")
 )
 
+// weights holds the scoring weights; set via -weights, repository config files may override them.
+var weights = reviewer.DefaultWeights()
+
 // prxClientWrapper wraps prx.Client to satisfy the interface expected by github.Client.
 type prxClientWrapper struct {
 	client *prx.Client
@@ -65,8 +93,13 @@ func main() {
 		fmt.Fprint(os.Stderr, "  GITHUB_APP_ID               - GitHub App ID\n")
 		fmt.Fprint(os.Stderr, "  GITHUB_APP_KEY              - Secret name in Google Secret Manager for private key\n")
 		fmt.Fprint(os.Stderr, "  GITHUB_APP_KEY_PATH         - Path to GitHub App private key file\n")
+		fmt.Fprint(os.Stderr, "  GITHUB_API_URL              - REST API URL for GitHub Enterprise Server\n")
+		fmt.Fprint(os.Stderr, "  GITHUB_GRAPHQL_URL          - GraphQL URL for GitHub Enterprise Server\n")
+		fmt.Fprint(os.Stderr, "  SPRINKLER_URL               - Sprinkler WebSocket URL\n")
+		fmt.Fprint(os.Stderr, "  GITHUB_WEBHOOK_SECRET       - Secret for verifying webhooks delivered to /webhook\n")
 		fmt.Fprint(os.Stderr, "  PORT                        - HTTP server port (default: 8080)\n")
 	}
+	flag.Var(&weights, "weights", "Scoring weight overrides as name=value pairs (e.g. assignee=100,file=8)")
 	flag.Parse()
 
 	// Set up structured logging
@@ -105,6 +138,8 @@ func main() {
 		AppKeyPath:  effectiveAppKey,
 		HTTPTimeout: 30 * time.Second,
 		CacheTTL:    24 * time.Hour,
+		APIURL:      cmp.Or(*apiURL, os.Getenv("GITHUB_API_URL")),
+		GraphQLURL:  cmp.Or(*graphQLURL, os.Getenv("GITHUB_GRAPHQL_URL")),
 	}
 	client, err := github.New(ctx, cfg)
 	if err != nil {
@@ -112,32 +147,88 @@ func main() {
 		os.Exit(1)
 	}
 
-	// Get token for prx client
-	token, err := client.Token(ctx)
-	if err != nil {
-		slog.Error("Failed to get GitHub token for prx client", "error", err)
-		os.Exit(1)
+	// prx only talks to GitHub.com; on GitHub Enterprise Server PRs are fetched through the REST API
+	if client.Host() == "github.com" {
+		// Get token for prx client
+		token, err := client.Token(ctx)
+		if err != nil {
+			slog.Error("Failed to get GitHub token for prx client", "error", err)
+			os.Exit(1)
+		}
+
+		// Create prx client for enhanced PR data (includes CI status)
+		prxClient := prx.NewClient(token, prx.WithLogger(logger))
+
+		// Wrap prx client to satisfy interface
+		client.SetPrxClient(&prxClientWrapper{client: prxClient})
+	} else {
+		slog.Info("Not using prx on GitHub Enterprise Server, fetching PR data from the REST API", "host", client.Host())
 	}
 
-	// Create prx client for enhanced PR data (includes CI status)
-	prxClient := prx.NewClient(token, prx.WithLogger(logger))
+	availCfg := availability.Config{InactiveAfter: *inactive}
+	if *oooCalendar != "" {
+		availCfg.Calendar, err = availability.LoadCalendar(*oooCalendar)
+		if err != nil {
+			slog.Error("Failed to load out-of-office calendar", "path", *oooCalendar, "error", err)
+			os.Exit(1)
+		}
+	}
 
-	// Wrap prx client to satisfy interface
-	client.SetPrxClient(&prxClientWrapper{client: prxClient})
+	// Track review requests so later PRs see them before cached open PR counts do
+	load := reviewer.NewLoadTracker(*prCountCache, *dailyCap)
 
 	// Create reviewer finder
 	finderCfg := reviewer.Config{
+		ConfigLoader: config.NewLoader(client, client.Cache()),
+		Weights:      weights,
+		Load:         load,
+		Availability: availability.New(client, availCfg),
 		PRCountCache: *prCountCache,
 	}
-	finder := reviewer.New(client, finderCfg)
+	finder, err := reviewer.New(client, finderCfg)
+	if err != nil {
+		slog.Error("Failed to create reviewer finder", "error", err)
+		os.Exit(1)
+	}
+
+	explicitSprinkler := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "sprinkler" {
+			explicitSprinkler = true
+		}
+	})
+	serverURL, sprinklerOn, err := resolveSprinkler(client.Host(), cmp.Or(*sprinklerURL, os.Getenv("SPRINKLER_URL")), *useSprinkler, explicitSprinkler)
+	if err != nil {
+		slog.Error("Invalid sprinkler configuration", "error", err)
+		os.Exit(1)
+	}
+	if *useSprinkler && !sprinklerOn {
+		slog.Warn("Sprinkler disabled: the public sprinkler server only serves GitHub.com; set -sprinkler-url to use a sprinkler server for this host",
+			"host", client.Host())
+	}
 
 	bot := &Bot{
-		client:            client,
-		finder:            finder,
-		sprinklerMonitors: make(map[string]*sprinklerMonitor),
-		dryRun:            *dryRun,
-		minOpenTime:       *minOpenTime,
-		maxOpenTime:       *maxOpenTime,
+		client:       client,
+		finder:       finder,
+		load:         load,
+		useSprinkler: sprinklerOn,
+		sprinklerURL: serverURL,
+		eventPoll:    *eventPoll,
+		dryRun:       *dryRun,
+		minOpenTime:  *minOpenTime,
+		maxOpenTime:  *maxOpenTime,
+	}
+	bot.dispatcher = events.NewDispatcher(bot.processEvent, *eventWorkers)
+	if secret := cmp.Or(*webhookSecret, os.Getenv("GITHUB_WEBHOOK_SECRET")); secret != "" {
+		bot.webhook = events.NewWebhookSource(events.WebhookConfig{
+			Secret:         []byte(secret),
+			PRsForCommit:   bot.openPullRequestsForCommit,
+			OnInstallation: bot.updateEventSources,
+		})
+	}
+
+	if !bot.useSprinkler && bot.webhook == nil && bot.eventPoll == 0 {
+		slog.Warn("No event sources are enabled, PRs are only picked up by the main loop", "loop_delay", *loopDelay)
 	}
 
 	slog.Info("Starting in server mode", "loop_delay", *loopDelay)
@@ -146,13 +237,18 @@ func main() {
 
 // Bot manages reviewer assignment across all installed organizations.
 type Bot struct {
-	client            *github.Client
-	finder            *reviewer.Finder
-	metrics           *MetricsCollector
-	sprinklerMonitors map[string]*sprinklerMonitor // One monitor per org
-	dryRun            bool
-	minOpenTime       time.Duration
-	maxOpenTime       time.Duration
+	client       *github.Client
+	finder       *reviewer.Finder
+	load         *reviewer.LoadTracker // Shared with finder
+	metrics      *MetricsCollector
+	dispatcher   *events.Dispatcher    // Processes PR events from all event sources
+	webhook      *events.WebhookSource // Set when a webhook secret is configured
+	sprinklerURL string
+	eventPoll    time.Duration // Polling source interval; 0 disables it
+	useSprinkler bool          // Add a sprinkler event source per org
+	dryRun       bool
+	minOpenTime  time.Duration
+	maxOpenTime  time.Duration
 }
 
 // processAllOrgs processes all organizations where the GitHub app is installed.
@@ -186,10 +282,8 @@ func (b *Bot) processAllOrgs(ctx context.Context) error {
 
 			slog.Info("Processing organization", "org", orgName, "progress", fmt.Sprintf("%d/%d", i+1, len(orgs)))
 
-			b.client.SetCurrentOrg(orgName)
-			defer b.client.SetCurrentOrg("")
-
-			processed, assigned, skipped := b.processOrg(orgCtx, orgName)
+			// Event workers share the client, so the org travels with the context
+			processed, assigned, skipped := b.processOrg(github.WithOrg(orgCtx, orgName), orgName)
 			totalProcessed += processed
 			totalAssigned += assigned
 			totalSkipped += skipped
@@ -209,7 +303,65 @@ func (b *Bot) processAllOrgs(ctx context.Context) error {
 	return nil
 }
 
-// processSinglePR processes a single PR by owner, repo, and number (used by sprinkler).
+// processEvent processes the PR from an event source, retrying transient failures.
+func (b *Bot) processEvent(ctx context.Context, e events.Event) error {
+	startTime := time.Now()
+	slog.Info("Processing PR event", "component", "events", "source", e.Source, "owner", e.Owner, "repo", e.Repo, "pr", e.Number)
+
+	// Workers run concurrently on a shared client, so the org travels with the context
+	ctx = github.WithOrg(ctx, e.Owner)
+
+	err := retry.Do(func() error {
+		return b.processSinglePR(ctx, e.Owner, e.Repo, e.Number)
+	},
+		retry.Attempts(eventMaxRetries),
+		retry.DelayType(retry.CombineDelay(retry.BackOffDelay, retry.RandomDelay)),
+		retry.MaxDelay(eventMaxDelay),
+		retry.OnRetry(func(n uint, err error) {
+			slog.Info("Retrying PR processing", "component", "events", "attempt", n+1, "owner", e.Owner, "repo", e.Repo, "pr", e.Number, "error", err)
+		}),
+		retry.Context(ctx),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to process PR after retries: %w", err)
+	}
+
+	slog.Info("Successfully processed PR",
+		"component", "events",
+		"source", e.Source,
+		"owner", e.Owner,
+		"repo", e.Repo,
+		"pr", e.Number,
+		"elapsed", time.Since(startTime).Round(time.Millisecond))
+	return nil
+}
+
+// openPullRequestsForCommit returns the open PRs containing a commit, for webhook status events.
+func (b *Bot) openPullRequestsForCommit(ctx context.Context, owner, repo, sha string) ([]int, error) {
+	return b.client.OpenPullRequestsForCommit(github.WithOrg(ctx, owner), owner, repo, sha)
+}
+
+// listPRsWithoutReviewers returns the open PRs without reviewers in every installed org,
+// for the polling event source.
+func (b *Bot) listPRsWithoutReviewers(ctx context.Context) ([]github.PRRef, error) {
+	orgs, err := b.client.ListAppInstallations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list app installations: %w", err)
+	}
+
+	var refs []github.PRRef
+	for _, org := range orgs {
+		orgRefs, err := b.client.SearchPullRequests(github.WithOrg(ctx, org), fmt.Sprintf("is:pr is:open org:%s review:none", org), 0)
+		if err != nil {
+			slog.Warn("Failed to search for PRs without reviewers", "component", "events", "org", org, "error", err)
+			continue
+		}
+		refs = append(refs, orgRefs...)
+	}
+	return refs, nil
+}
+
+// processSinglePR processes a single PR by owner, repo, and number (used for PR events).
 func (b *Bot) processSinglePR(ctx context.Context, owner, repo string, prNumber int) (err error) {
 	// Add panic recovery
 	defer func() {
@@ -274,20 +426,31 @@ func (b *Bot) processOrg(ctx context.Context, org string) (processed, assigned,
 
 // processPR processes a single PR and assigns reviewers if appropriate.
 func (b *Bot) processPR(ctx context.Context, pr *types.PullRequest) bool {
-	// Skip draft PRs
-	if pr.Draft {
-		slog.Debug("Skipping draft PR", "pr", pr.Number, "repo", pr.Repository)
+	// Skip drafts and PRs that already have reviewers or team review requests
+	if reason := reviewer.SkipReason(pr); reason != "" {
+		slog.Debug("Skipping PR", "pr", pr.Number, "repo", pr.Repository, "reason", reason)
 		return false
 	}
 
-	// Skip if PR already has reviewers
-	if len(pr.Reviewers) > 0 {
-		slog.Debug("Skipping PR with existing reviewers", "pr", pr.Number, "repo", pr.Repository)
+	// Load repository config; an invalid config file is reported rather than silently ignored
+	cfg, err := b.finder.RepoConfig(ctx, pr.Owner, pr.Repository)
+	if err != nil {
+		slog.Error("Skipping PR due to invalid reviewer config",
+			"pr", pr.Number,
+			"repo", pr.Repository,
+			"error", err)
+		return false
+	}
+
+	// Apply the repository's skip and route rules before any analysis
+	match := reviewer.MatchRule(pr, cfg.Rules)
+	if match != nil && match.Rule.Action == config.RuleSkip {
+		slog.Info("Skipping PR matched by rule", "pr", pr.Number, "repo", pr.Repository, "reason", match.Reason)
 		return false
 	}
 
 	// Check CI/test status and apply delays
-	if !b.isPRReadyForReview(pr) {
+	if !b.isPRReadyForReview(pr, cfg.Wait) {
 		return false
 	}
 
@@ -302,98 +465,139 @@ func (b *Bot) processPR(ctx context.Context, pr *types.PullRequest) bool {
 		return false
 	}
 
-	// Find reviewers
-	candidates, err := b.finder.Find(ctx, pr)
-	if err != nil {
-		slog.Warn("Failed to find reviewers", "pr", pr.Number, "repo", pr.Repository, "error", err)
-		return false
+	var reviewers, teams []string
+	explanation := &reviewer.Explanation{Org: pr.Owner}
+	if match != nil {
+		// Route rules name the reviewers, so there is nothing to search for
+		reviewers, teams = reviewer.RoutedReviewers(pr, cfg, &match.Rule), match.Rule.Teams
+		slog.Info("Routing PR by rule", "pr", pr.Number, "repo", pr.Repository, "reason", match.Reason,
+			"reviewers", reviewers, "teams", teams)
+		explanation.Rule = match.Reason
+		for _, u := range reviewers {
+			explanation.Chosen = append(explanation.Chosen, types.ReviewerCandidate{Username: u})
+		}
+	} else {
+		candidates, found, ok := b.findReviewers(ctx, pr, cfg)
+		if !ok {
+			return false
+		}
+		teams = found
+		// Request the configured number of top reviewers only
+		chosen := min(cfg.Reviewers, len(candidates))
+		for _, c := range candidates[:chosen] {
+			reviewers = append(reviewers, c.Username)
+		}
+		explanation.Chosen, explanation.RunnersUp = candidates[:chosen], candidates[chosen:]
 	}
+	explanation.Teams = teams
 
-	if len(candidates) == 0 {
+	if len(reviewers) == 0 && len(teams) == 0 {
 		slog.Debug("No suitable reviewers found", "pr", pr.Number, "repo", pr.Repository)
 		return false
 	}
 
-	// Assign top 2 reviewers only
-	maxReviewers := 2
-	if len(candidates) < maxReviewers {
-		maxReviewers = len(candidates)
-	}
-	reviewers := make([]string, 0, maxReviewers)
-	for i := range maxReviewers {
-		reviewers = append(reviewers, candidates[i].Username)
-	}
-
 	if b.dryRun {
 		slog.Info("Would assign reviewers (dry-run)",
 			"pr", pr.Number,
 			"repo", pr.Repository,
-			"reviewers", reviewers)
+			"reviewers", reviewers,
+			"teams", teams,
+			"comment", cfg.Comment)
+		// Record anyway so dry runs show how load would be spread
+		b.load.Record(reviewers...)
 		return true
 	}
 
-	if err := b.client.AddReviewers(ctx, pr.Owner, pr.Repository, pr.Number, reviewers); err != nil {
+	if err := b.client.AddReviewRequests(ctx, pr.Owner, pr.Repository, pr.Number, reviewers, teams); err != nil {
 		slog.Error("Failed to assign reviewers",
 			"pr", pr.Number,
 			"repo", pr.Repository,
 			"error", err)
 		return false
 	}
+	b.load.Record(reviewers...)
 
 	slog.Info("Assigned reviewers",
 		"pr", pr.Number,
 		"repo", pr.Repository,
-		"reviewers", reviewers)
+		"reviewers", reviewers,
+		"teams", teams)
+
+	// The review requests are what matter, so a failed comment is only logged
+	if cfg.Comment {
+		if err := b.client.UpsertComment(ctx, pr.Owner, pr.Repository, pr.Number, reviewer.CommentMarker, explanation.Markdown()); err != nil {
+			slog.Warn("Failed to post reviewer explanation", "pr", pr.Number, "repo", pr.Repository, "error", err)
+		}
+	}
 	return true
 }
 
+// findReviewers returns the reviewer candidates for a PR, best first, and the owning
+// teams to request. Returns false if the search failed.
+func (b *Bot) findReviewers(ctx context.Context, pr *types.PullRequest, cfg *config.Config) (candidates []types.ReviewerCandidate, teams []string, ok bool) {
+	candidates, err := b.finder.Find(ctx, pr)
+	if err != nil {
+		slog.Warn("Failed to find reviewers", "pr", pr.Number, "repo", pr.Repository, "error", err)
+		return nil, nil, false
+	}
+
+	// Find owning teams if the repository wants team review requests
+	if cfg.TeamReviewers > 0 {
+		teams, err = b.finder.FindTeams(ctx, pr)
+		if err != nil {
+			slog.Warn("Failed to find teams, requesting individuals only", "pr", pr.Number, "repo", pr.Repository, "error", err)
+		}
+		teams = teams[:min(cfg.TeamReviewers, len(teams))]
+	}
+	return candidates, teams, true
+}
+
 // isPRReadyForReview checks if a PR is ready for reviewer assignment based on CI/test status.
-// Returns false if tests are pending (wait 20 min) or failing (wait 90 min).
-// Also enforces a minimum 2 minute wait since last update.
-func (*Bot) isPRReadyForReview(pr *types.PullRequest) bool {
+// Returns false if tests are pending or failing and their grace period hasn't elapsed
+// (20 and 90 minutes by default). Also enforces a minimum wait since last update.
+func (*Bot) isPRReadyForReview(pr *types.PullRequest, wait config.Wait) bool {
 	timeSinceUpdate := time.Since(pr.UpdatedAt)
 
-	// Always wait at least 2 minutes since last update before assigning reviewers
-	const minWaitTime = 2 * time.Minute
-	if timeSinceUpdate < minWaitTime {
+	// Always wait a minimum time since last update before assigning reviewers
+	if timeSinceUpdate < wait.Min {
 		slog.Debug("Skipping PR - waiting for minimum time since last update",
 			"pr", pr.Number,
 			"repo", pr.Repository,
 			"time_since_update", timeSinceUpdate.Round(time.Second),
-			"wait_remaining", (minWaitTime - timeSinceUpdate).Round(time.Second))
+			"wait_remaining", (wait.Min - timeSinceUpdate).Round(time.Second))
 		return false
 	}
 
 	switch pr.TestState {
 	case "failing":
-		// Wait 90 minutes after last update if tests are failing
-		if timeSinceUpdate < 90*time.Minute {
+		// Wait after last update if tests are failing
+		if timeSinceUpdate < wait.Failing {
 			slog.Debug("Skipping PR with failing tests - waiting for fixes",
 				"pr", pr.Number,
 				"repo", pr.Repository,
 				"test_state", pr.TestState,
 				"time_since_update", timeSinceUpdate.Round(time.Minute),
-				"wait_remaining", (90*time.Minute - timeSinceUpdate).Round(time.Minute))
+				"wait_remaining", (wait.Failing - timeSinceUpdate).Round(time.Minute))
 			return false
 		}
-		slog.Info("Assigning reviewers to PR with failing tests after 90 minute grace period",
+		slog.Info("Assigning reviewers to PR with failing tests after grace period",
 			"pr", pr.Number,
 			"repo", pr.Repository,
 			"test_state", pr.TestState,
 			"time_since_update", timeSinceUpdate.Round(time.Minute))
 
 	case "pending", "queued", "running":
-		// Wait 20 minutes after last update if tests are pending
-		if timeSinceUpdate < 20*time.Minute {
+		// Wait after last update if tests are pending
+		if timeSinceUpdate < wait.Pending {
 			slog.Debug("Skipping PR with pending tests - waiting for completion",
 				"pr", pr.Number,
 				"repo", pr.Repository,
 				"test_state", pr.TestState,
 				"time_since_update", timeSinceUpdate.Round(time.Minute),
-				"wait_remaining", (20*time.Minute - timeSinceUpdate).Round(time.Minute))
+				"wait_remaining", (wait.Pending - timeSinceUpdate).Round(time.Minute))
 			return false
 		}
-		slog.Info("Assigning reviewers to PR with pending tests after 20 minute grace period",
+		slog.Info("Assigning reviewers to PR with pending tests after grace period",
 			"pr", pr.Number,
 			"repo", pr.Repository,
 			"test_state", pr.TestState,
@@ -426,30 +630,20 @@ func (b *Bot) runServeMode(ctx context.Context, loopDelay time.Duration) {
 	time.Sleep(100 * time.Millisecond)
 	slog.Info("Service started in server mode", "loop_delay", loopDelay)
 
-	// Initialize and start one sprinkler monitor per org
-	orgs, err := b.client.ListAppInstallations(ctx)
-	if err != nil {
-		slog.Warn("Failed to list organizations for sprinkler", "error", err)
-	} else {
-		for _, org := range orgs {
-			// Create and start sprinkler for this org
-			monitor := newSprinklerMonitor(b, org)
-			if err := monitor.start(ctx); err != nil {
-				slog.Error("Failed to start sprinkler for org", "org", org, "error", err)
-				continue
-			}
-			b.sprinklerMonitors[org] = monitor
-			slog.Info("Started sprinkler monitor", "org", org)
+	// Start the event workers and the event sources feeding them
+	b.dispatcher.Start(ctx)
+	defer b.dispatcher.Stop()
+	if b.webhook != nil {
+		if err := b.dispatcher.Add(ctx, b.webhook); err != nil {
+			slog.Error("Failed to add webhook event source", "error", err)
+		}
+	}
+	if b.eventPoll > 0 {
+		if err := b.dispatcher.Add(ctx, events.NewPollingSource("poll", b.eventPoll, b.listPRsWithoutReviewers)); err != nil {
+			slog.Error("Failed to add polling event source", "error", err)
 		}
-
-		// Stop all monitors on shutdown
-		defer func() {
-			for org, monitor := range b.sprinklerMonitors {
-				slog.Info("Stopping sprinkler monitor", "org", org)
-				monitor.stop()
-			}
-		}()
 	}
+	b.updateEventSources(ctx)
 
 	// Start heartbeat logger
 	go b.heartbeat(ctx)
@@ -481,8 +675,8 @@ func (b *Bot) runServeMode(ctx context.Context, loopDelay time.Duration) {
 					slog.Error("Failed to process app installations", "error", err)
 				}
 
-				// Check for new/removed orgs and update sprinkler monitors
-				b.updateSprinklerMonitors(ctx)
+				// Check for new/removed orgs and update event sources
+				b.updateEventSources(ctx)
 
 				b.metrics.RecordRunComplete()
 				duration := time.Since(startTime)
@@ -520,65 +714,79 @@ func (b *Bot) heartbeat(ctx context.Context) {
 		case <-ticker.C:
 			stats := b.metrics.Stats()
 
-			// Count connected sprinklers
-			connectedSprinklers := 0
-			totalSprinklers := len(b.sprinklerMonitors)
-			for _, monitor := range b.sprinklerMonitors {
-				status := monitor.healthStatus()
-				isConnected, ok := status["is_connected"].(bool)
-				if ok && isConnected {
-					connectedSprinklers++
+			// Count connected event sources; sources without a connection count as connected
+			connectedSources := 0
+			sources := b.dispatcher.Health()
+			for _, status := range sources {
+				if isConnected, ok := status["is_connected"].(bool); !ok || isConnected {
+					connectedSources++
+				}
+			}
+			eventStats := b.dispatcher.Stats()
+
+			// Report the budget closest to running out
+			var lowest github.RateLimit
+			lowestBudget := "unknown"
+			for i, l := range b.client.RateLimits() {
+				if i == 0 || l.Remaining < lowest.Remaining {
+					lowest = l
+					lowestBudget = fmt.Sprintf("%s %s %d/%d", l.Key, l.Resource, l.Remaining, l.Limit)
 				}
 			}
 
+			httpCache := b.client.HTTPCacheStats()
 			slog.Info("Heartbeat - service is alive",
 				"uptime_runs", stats.TotalRuns,
 				"last_run_ago", time.Since(stats.LastRun).Round(time.Second),
-				"sprinklers_connected", fmt.Sprintf("%d/%d", connectedSprinklers, totalSprinklers),
+				"event_sources_connected", fmt.Sprintf("%d/%d", connectedSources, len(sources)),
+				"events_processed", eventStats.Processed,
+				"events_failed", eventStats.Failed,
 				"total_prs_seen", stats.PRsSeen,
-				"total_prs_modified", stats.PRsModified)
+				"total_prs_modified", stats.PRsModified,
+				"lowest_rate_limit", lowestBudget,
+				"http_cache_hits", httpCache.Hits,
+				"http_cache_misses", httpCache.Misses)
 		}
 	}
 }
 
-// updateSprinklerMonitors checks for new/removed orgs and updates sprinkler monitors accordingly.
-func (b *Bot) updateSprinklerMonitors(ctx context.Context) {
+// updateEventSources adds sprinkler event sources for newly installed orgs and removes those
+// for orgs the app was uninstalled from.
+func (b *Bot) updateEventSources(ctx context.Context) {
+	if !b.useSprinkler {
+		return
+	}
+
 	orgs, err := b.client.ListAppInstallations(ctx)
 	if err != nil {
-		slog.Warn("Failed to list organizations for sprinkler update", "error", err)
+		slog.Warn("Failed to list organizations for event source update", "error", err)
 		return
 	}
 
-	// Build set of current orgs
-	currentOrgs := make(map[string]bool)
+	// Build set of current sprinkler source names
+	current := make(map[string]bool)
 	for _, org := range orgs {
-		currentOrgs[org] = true
+		current[sprinklerSourceName(org)] = true
 	}
 
-	// Stop monitors for removed orgs
-	for org, monitor := range b.sprinklerMonitors {
-		if !currentOrgs[org] {
-			slog.Info("Stopping sprinkler for removed org", "org", org)
-			monitor.stop()
-			delete(b.sprinklerMonitors, org)
+	// Remove sources for removed orgs
+	existing := make(map[string]bool)
+	for _, name := range b.dispatcher.Names() {
+		existing[name] = true
+		if strings.HasPrefix(name, sprinklerSourceName("")) && !current[name] {
+			slog.Info("Removing sprinkler source for removed org", "source", name)
+			b.dispatcher.Remove(name)
 		}
 	}
 
-	// Start monitors for new orgs
+	// Add sources for new orgs
 	for _, org := range orgs {
-		if _, exists := b.sprinklerMonitors[org]; exists {
+		if existing[sprinklerSourceName(org)] {
 			continue // Already monitoring
 		}
-
-		// Create and start sprinkler for this org
-		monitor := newSprinklerMonitor(b, org)
-		if err := monitor.start(ctx); err != nil {
-			slog.Error("Failed to start sprinkler for new org", "org", org, "error", err)
-			continue
+		if err := b.dispatcher.Add(ctx, newSprinklerSource(b.client, org, b.sprinklerURL)); err != nil {
+			slog.Error("Failed to add sprinkler source for org", "org", org, "error", err)
 		}
-
-		b.sprinklerMonitors[org] = monitor
-		slog.Info("Started sprinkler monitor for new org", "org", org)
 	}
 }
 
@@ -604,31 +812,35 @@ func (b *Bot) startHealthServer(ctx context.Context) {
 			warnings = append(warnings, fmt.Sprintf("main loop stale (last run: %s ago)", time.Since(stats.LastRun).Round(time.Second)))
 		}
 
-		// Check sprinkler monitor health
-		sprinklerStatuses := make([]map[string]any, 0, len(b.sprinklerMonitors))
-		allSprinklersHealthy := true
-		for _, monitor := range b.sprinklerMonitors {
-			monitorStatus := monitor.healthStatus()
-			sprinklerStatuses = append(sprinklerStatuses, monitorStatus)
-
-			// Check if monitor is unhealthy
-			isRunning, runningOK := monitorStatus["is_running"].(bool)
-			isConnected, connectedOK := monitorStatus["is_connected"].(bool)
-			orgName, orgOK := monitorStatus["org"].(string)
-			if !orgOK {
-				orgName = "unknown"
-			}
+		// Check event source health
+		sources := b.dispatcher.Health()
+		allSourcesHealthy := true
+		for _, sourceStatus := range sources {
+			isRunning, runningOK := sourceStatus["is_running"].(bool)
+			isConnected, connectedOK := sourceStatus["is_connected"].(bool)
+			name := fmt.Sprint(sourceStatus["source"])
 
 			if runningOK && !isRunning {
-				allSprinklersHealthy = false
-				warnings = append(warnings, fmt.Sprintf("sprinkler for %s not running", orgName))
+				allSourcesHealthy = false
+				warnings = append(warnings, fmt.Sprintf("event source %s not running", name))
 			} else if connectedOK && !isConnected {
-				allSprinklersHealthy = false
-				warnings = append(warnings, fmt.Sprintf("sprinkler for %s disconnected", orgName))
+				allSourcesHealthy = false
+				warnings = append(warnings, fmt.Sprintf("event source %s disconnected", name))
+			}
+		}
+
+		// Check for spent GitHub rate limit budgets; requests wait until they reset
+		rateLimits := b.client.RateLimits()
+		rateLimited := false
+		for _, l := range rateLimits {
+			if l.Remaining <= 0 && time.Now().Before(l.Reset) {
+				rateLimited = true
+				warnings = append(warnings, fmt.Sprintf("%s rate limit for %s exhausted (resets in %s)",
+					l.Resource, l.Key, time.Until(l.Reset).Round(time.Second)))
 			}
 		}
 
-		if !allSprinklersHealthy && status == "healthy" {
+		if (!allSourcesHealthy || rateLimited) && status == "healthy" {
 			status = "degraded"
 			statusCode = http.StatusOK // Still OK but degraded
 		}
@@ -646,7 +858,10 @@ func (b *Bot) startHealthServer(ctx context.Context) {
 				"prs_seen":     stats.PRsSeen,
 				"prs_modified": stats.PRsModified,
 			},
-			"sprinklers": sprinklerStatuses,
+			"event_sources": sources,
+			"events":        b.dispatcher.Stats(),
+			"rate_limits":   rateLimits,
+			"http_cache":    b.client.HTTPCacheStats(),
 		}
 
 		if len(warnings) > 0 {
@@ -695,6 +910,11 @@ func (b *Bot) startHealthServer(ctx context.Context) {
 		}
 	})
 
+	if b.webhook != nil {
+		http.Handle("/webhook", b.webhook)
+		slog.Info("Accepting GitHub webhooks", "path", "/webhook")
+	}
+
 	http.HandleFunc("/", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
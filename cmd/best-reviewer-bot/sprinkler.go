@@ -6,73 +6,103 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
-	"strings"
 	"sync"
 	"time"
 
-	"github.com/codeGROOVE-dev/retry"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/events"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
 	"github.com/codeGROOVE-dev/sprinkler/pkg/client"
 )
 
 const (
 	eventChannelSize       = 100              // Buffer size for event channel
-	eventDedupWindow       = 5 * time.Second  // Time window for deduplicating events
-	eventMapMaxSize        = 1000             // Maximum entries in event dedup map
-	eventMapCleanupAge     = 1 * time.Hour    // Age threshold for cleaning up old entries
-	sprinklerMaxRetries    = 3                // Max retries for PR processing
-	sprinklerMaxDelay      = 10 * time.Second // Max delay between retries
 	connectionHealthCheck  = 2 * time.Minute  // Check connection health every 2 minutes
 	connectionStaleTimeout = 5 * time.Minute  // Reconnect if no connection for 5 minutes
 	maxReconnectAttempts   = 100              // Max reconnection attempts (high limit for production reliability)
 	reconnectBackoff       = 30 * time.Second // Initial backoff between reconnection attempts
 )
 
-// sprinklerMonitor manages WebSocket event subscriptions for a single org.
-type sprinklerMonitor struct {
+// sprinklerSource is an event source backed by a sprinkler WebSocket subscription for a single org.
+type sprinklerSource struct {
 	mu                sync.RWMutex
 	lastConnectedAt   time.Time // Last successful connection time
 	lastEventAt       time.Time // Last event received time (for health monitoring)
-	bot               *Bot
-	client            *client.Client
-	eventChan         chan string          // Channel for PR URLs that need processing
-	lastEventMap      map[string]time.Time // Track last event per URL to dedupe
-	stopChan          chan struct{}        // Channel to signal monitor should stop
-	org               string               // Organization this monitor is for
-	reconnectAttempts int                  // Current reconnection attempt count
+	client            *github.Client
+	wsClient          *client.Client
+	events            chan events.Event // PRs that need processing
+	stopChan          chan struct{}     // Channel to signal the source should stop
+	org               string            // Organization this source is for
+	serverURL         string            // Sprinkler WebSocket URL
+	reconnectAttempts int               // Current reconnection attempt count
 	isRunning         bool
 	isConnected       bool // Track WebSocket connection status
-	isStopped         bool // Track if monitor was explicitly stopped
+	isStopped         bool // Track if source was explicitly stopped
 }
 
-// newSprinklerMonitor creates a new sprinkler monitor for a specific org.
-func newSprinklerMonitor(bot *Bot, org string) *sprinklerMonitor {
-	return &sprinklerMonitor{
-		bot:          bot,
-		org:          org,
-		eventChan:    make(chan string, eventChannelSize),
-		lastEventMap: make(map[string]time.Time),
-		stopChan:     make(chan struct{}),
+// newSprinklerSource creates a sprinkler event source for a specific org.
+func newSprinklerSource(ghClient *github.Client, org, serverURL string) *sprinklerSource {
+	return &sprinklerSource{
+		client:    ghClient,
+		org:       org,
+		serverURL: serverURL,
+		events:    make(chan events.Event, eventChannelSize),
+		stopChan:  make(chan struct{}),
 	}
 }
 
-// start begins monitoring for PR events for this org.
-//
-//nolint:unparam // Error return kept for interface consistency with other lifecycle methods
-func (sm *sprinklerMonitor) start(ctx context.Context) error {
+// defaultSprinklerURL returns the public sprinkler server's WebSocket URL.
+func defaultSprinklerURL() string {
+	return "wss://" + client.DefaultServerAddress + "/ws"
+}
+
+// resolveSprinkler decides whether to use sprinkler and at which URL. explicitURL is the
+// -sprinkler-url flag or SPRINKLER_URL, and explicitEnable reports whether -sprinkler was
+// given. The public server only sees GitHub.com, so on GitHub Enterprise Server sprinkler
+// stays off unless a server is configured: installation tokens must not be sent elsewhere.
+func resolveSprinkler(host, explicitURL string, enable, explicitEnable bool) (serverURL string, use bool, err error) {
+	if !enable {
+		return "", false, nil
+	}
+	if explicitURL != "" {
+		return explicitURL, true, nil
+	}
+	if host == "github.com" {
+		return defaultSprinklerURL(), true, nil
+	}
+	if explicitEnable {
+		return "", false, fmt.Errorf("-sprinkler on GitHub Enterprise Server (%s) requires -sprinkler-url or SPRINKLER_URL", host)
+	}
+	return "", false, nil
+}
+
+// sprinklerSourceName returns the event source name for an org's sprinkler subscription.
+func sprinklerSourceName(org string) string {
+	return "sprinkler/" + org
+}
+
+// Name returns the source name, "sprinkler/<org>".
+func (sm *sprinklerSource) Name() string {
+	return sprinklerSourceName(sm.org)
+}
+
+// Events returns the channel PR events are delivered on.
+func (sm *sprinklerSource) Events() <-chan events.Event {
+	return sm.events
+}
+
+// Start connects to sprinkler for this org in the background.
+func (sm *sprinklerSource) Start(ctx context.Context) error {
 	sm.mu.Lock()
 	if sm.isRunning {
 		sm.mu.Unlock()
-		slog.Info("Monitor already running", "component", "sprinkler", "org", sm.org)
+		slog.Info("Sprinkler source already running", "component", "sprinkler", "org", sm.org)
 		return nil
 	}
 	sm.isRunning = true
 	sm.isStopped = false
 	sm.mu.Unlock()
 
-	slog.Info("Starting event monitor for org", "component", "sprinkler", "org", sm.org)
-
-	// Start event processor
-	go sm.processEvents(ctx)
+	slog.Info("Starting sprinkler source for org", "component", "sprinkler", "org", sm.org)
 
 	// Start connection manager with auto-reconnect
 	go sm.manageConnection(ctx)
@@ -80,14 +110,13 @@ func (sm *sprinklerMonitor) start(ctx context.Context) error {
 	// Start health monitor
 	go sm.monitorHealth(ctx)
 
-	slog.Info("Event monitor started successfully", "component", "sprinkler", "org", sm.org)
 	return nil
 }
 
 // manageConnection manages the WebSocket connection with automatic reconnection.
 // The sprinkler client has its own internal reconnection logic with exponential backoff.
 // This function handles restarting the client only when it gives up or encounters fatal errors.
-func (sm *sprinklerMonitor) manageConnection(ctx context.Context) {
+func (sm *sprinklerSource) manageConnection(ctx context.Context) {
 	defer func() {
 		if r := recover(); r != nil {
 			slog.Error("Connection manager panic", "component", "sprinkler", "org", sm.org, "panic", r)
@@ -174,15 +203,13 @@ func (sm *sprinklerMonitor) manageConnection(ctx context.Context) {
 }
 
 // connectWebSocket establishes a WebSocket connection.
-func (sm *sprinklerMonitor) connectWebSocket(ctx context.Context) error {
+func (sm *sprinklerSource) connectWebSocket(ctx context.Context) error {
 	config := client.Config{
-		ServerURL:    "wss://" + client.DefaultServerAddress + "/ws",
+		ServerURL:    sm.serverURL,
 		Organization: sm.org,
 		// Use TokenProvider for dynamic token refresh instead of static Token
 		TokenProvider: func() (string, error) {
-			sm.bot.client.SetCurrentOrg(sm.org)
-			token, err := sm.bot.client.Token(ctx)
-			sm.bot.client.SetCurrentOrg("")
+			token, err := sm.client.Token(github.WithOrg(ctx, sm.org))
 			if err != nil {
 				return "", fmt.Errorf("failed to get token: %w", err)
 			}
@@ -219,7 +246,7 @@ func (sm *sprinklerMonitor) connectWebSocket(ctx context.Context) error {
 	}
 
 	sm.mu.Lock()
-	sm.client = wsClient
+	sm.wsClient = wsClient
 	sm.mu.Unlock()
 
 	slog.Info("Starting WebSocket client", "component", "sprinkler", "org", sm.org)
@@ -240,7 +267,7 @@ func (sm *sprinklerMonitor) connectWebSocket(ctx context.Context) error {
 }
 
 // monitorHealth monitors connection health and triggers reconnection if needed.
-func (sm *sprinklerMonitor) monitorHealth(ctx context.Context) {
+func (sm *sprinklerSource) monitorHealth(ctx context.Context) {
 	defer func() {
 		if r := recover(); r != nil {
 			slog.Error("Health monitor panic", "component", "sprinkler", "org", sm.org, "panic", r)
@@ -301,8 +328,8 @@ func (sm *sprinklerMonitor) monitorHealth(ctx context.Context) {
 	}
 }
 
-// handleEvent processes incoming PR events.
-func (sm *sprinklerMonitor) handleEvent(event client.Event) {
+// handleEvent delivers pull request events for this org.
+func (sm *sprinklerSource) handleEvent(event client.Event) {
 	// Filter by event type
 	if event.Type != "pull_request" {
 		return
@@ -313,128 +340,41 @@ func (sm *sprinklerMonitor) handleEvent(event client.Event) {
 		return
 	}
 
-	// Extract org from URL (format: https://github.com/org/repo/pull/123)
-	parts := strings.Split(event.URL, "/")
-	const minParts = 5
-	if len(parts) < minParts || parts[2] != "github.com" {
-		slog.Warn("Failed to extract org from URL", "component", "sprinkler", "url", event.URL, "org", sm.org)
+	ref, err := github.ParsePRURL(event.URL, sm.client.Host())
+	if err != nil {
+		slog.Warn("Failed to parse PR URL", "component", "sprinkler", "url", event.URL, "org", sm.org, "error", err)
 		return
 	}
-	org := parts[3]
 
 	// Verify this event is for our org (should always match due to sprinkler config)
-	if org != sm.org {
-		slog.Debug("Ignoring event for different org", "component", "sprinkler", "event_org", org, "monitor_org", sm.org)
+	if ref.Owner != sm.org {
+		slog.Debug("Ignoring event for different org", "component", "sprinkler", "event_org", ref.Owner, "monitor_org", sm.org)
 		return
 	}
 
-	// Dedupe events - only process if we haven't seen this URL recently
 	sm.mu.Lock()
-	lastSeen, exists := sm.lastEventMap[event.URL]
-	now := time.Now()
-	if exists && now.Sub(lastSeen) < eventDedupWindow {
-		sm.mu.Unlock()
-		return
-	}
-	sm.lastEventMap[event.URL] = now
-	sm.lastEventAt = now // Track last event time for health monitoring
-
-	// Clean up old entries to prevent memory leak
-	if len(sm.lastEventMap) > eventMapMaxSize {
-		cutoff := now.Add(-eventMapCleanupAge)
-		for url, timestamp := range sm.lastEventMap {
-			if timestamp.Before(cutoff) {
-				delete(sm.lastEventMap, url)
-			}
-		}
-	}
+	sm.lastEventAt = time.Now() // Track last event time for health monitoring
 	sm.mu.Unlock()
 
 	slog.Info("PR event received", "component", "sprinkler", "url", event.URL, "org", sm.org)
 
-	// Send to event channel for processing (non-blocking)
+	// Deliver without blocking the WebSocket client
 	select {
-	case sm.eventChan <- event.URL:
+	case sm.events <- events.Event{Source: sm.Name(), PRRef: ref}:
 	default:
 		slog.Warn("Event channel full, dropping event", "component", "sprinkler", "url", event.URL)
 	}
 }
 
-// processEvents handles PR events by checking and processing them.
-func (sm *sprinklerMonitor) processEvents(ctx context.Context) {
-	defer func() {
-		if r := recover(); r != nil {
-			slog.Error("Event processor panic", "component", "sprinkler", "panic", r)
-		}
-	}()
-
-	for {
-		select {
-		case <-ctx.Done():
-			return
-		case prURL := <-sm.eventChan:
-			sm.processEvent(ctx, prURL)
-		}
-	}
-}
-
-// processEvent processes a single PR event.
-func (sm *sprinklerMonitor) processEvent(ctx context.Context, prURL string) {
-	startTime := time.Now()
-
-	// Parse PR URL to extract owner, repo, and number
-	ref, err := parsePRURL(prURL)
-	if err != nil {
-		slog.Warn("Failed to parse PR URL", "component", "sprinkler", "url", prURL, "error", err)
-		return
-	}
-
-	slog.Info("Processing PR event", "component", "sprinkler", "owner", ref.owner, "repo", ref.repo, "pr", ref.number)
-
-	// Set the current org for the GitHub client
-	sm.bot.client.SetCurrentOrg(ref.owner)
-	defer sm.bot.client.SetCurrentOrg("")
-
-	// Process the PR with retry logic
-	err = retry.Do(func() error {
-		return sm.bot.processSinglePR(ctx, ref.owner, ref.repo, ref.number)
-	},
-		retry.Attempts(sprinklerMaxRetries),
-		retry.DelayType(retry.CombineDelay(retry.BackOffDelay, retry.RandomDelay)),
-		retry.MaxDelay(sprinklerMaxDelay),
-		retry.OnRetry(func(n uint, err error) {
-			slog.Info("Retrying PR processing", "component", "sprinkler", "attempt", n+1, "owner", ref.owner, "repo", ref.repo, "pr", ref.number, "error", err)
-		}),
-		retry.Context(ctx),
-	)
-	if err != nil {
-		slog.Error("Failed to process PR after retries",
-			"component", "sprinkler",
-			"owner", ref.owner,
-			"repo", ref.repo,
-			"pr", ref.number,
-			"elapsed", time.Since(startTime).Round(time.Millisecond),
-			"error", err)
-		return
-	}
-
-	slog.Info("Successfully processed PR",
-		"component", "sprinkler",
-		"owner", ref.owner,
-		"repo", ref.repo,
-		"pr", ref.number,
-		"elapsed", time.Since(startTime).Round(time.Millisecond))
-}
-
-// stop stops the sprinkler monitor.
-func (sm *sprinklerMonitor) stop() {
+// Stop closes the WebSocket connection and stops reconnecting.
+func (sm *sprinklerSource) Stop() {
 	sm.mu.Lock()
 	if !sm.isRunning {
 		sm.mu.Unlock()
 		return
 	}
 
-	slog.Info("Stopping event monitor", "component", "sprinkler", "org", sm.org)
+	slog.Info("Stopping sprinkler source", "component", "sprinkler", "org", sm.org)
 	sm.isRunning = false
 	sm.isStopped = true
 	sm.mu.Unlock()
@@ -444,18 +384,18 @@ func (sm *sprinklerMonitor) stop() {
 
 	// Close the client to stop the WebSocket connection
 	sm.mu.RLock()
-	wsClient := sm.client
+	wsClient := sm.wsClient
 	sm.mu.RUnlock()
 
 	if wsClient != nil {
 		wsClient.Stop()
 	}
 
-	slog.Info("Event monitor stopped", "component", "sprinkler", "org", sm.org)
+	slog.Info("Sprinkler source stopped", "component", "sprinkler", "org", sm.org)
 }
 
-// healthStatus returns the current health status of the monitor.
-func (sm *sprinklerMonitor) healthStatus() map[string]any {
+// Health returns the current connection status.
+func (sm *sprinklerSource) Health() map[string]any {
 	sm.mu.RLock()
 	defer sm.mu.RUnlock()
 
@@ -482,31 +422,3 @@ func (sm *sprinklerMonitor) healthStatus() map[string]any {
 
 	return status
 }
-
-// prRef holds a parsed PR reference.
-type prRef struct {
-	owner  string
-	repo   string
-	number int
-}
-
-// parsePRURL extracts owner, repo, and PR number from URL.
-// URL format: https://github.com/owner/repo/pull/123
-func parsePRURL(url string) (*prRef, error) {
-	const minParts = 7
-	parts := strings.Split(url, "/")
-	if len(parts) < minParts || parts[2] != "github.com" {
-		return nil, fmt.Errorf("invalid GitHub PR URL format: %s", url)
-	}
-
-	owner := parts[3]
-	repo := parts[4]
-
-	var number int
-	_, scanErr := fmt.Sscanf(parts[6], "%d", &number)
-	if scanErr != nil {
-		return nil, fmt.Errorf("invalid PR number in URL: %s", url)
-	}
-
-	return &prRef{owner: owner, repo: repo, number: number}, nil
-}
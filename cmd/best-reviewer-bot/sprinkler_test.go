@@ -0,0 +1,37 @@
+package main
+
+import "testing"
+
+func TestResolveSprinkler(t *testing.T) {
+	tests := []struct {
+		name           string
+		host           string
+		explicitURL    string
+		enable         bool
+		explicitEnable bool
+		wantURL        string
+		wantUse        bool
+		wantErr        bool
+	}{
+		{name: "github.com default", host: "github.com", enable: true, wantURL: defaultSprinklerURL(), wantUse: true},
+		{name: "github.com custom server", host: "github.com", explicitURL: "wss://sprinkler.example.com/ws", enable: true,
+			wantURL: "wss://sprinkler.example.com/ws", wantUse: true},
+		{name: "disabled", host: "github.com", explicitEnable: true},
+		{name: "GHES default is off", host: "github.example.com", enable: true},
+		{name: "GHES with a server", host: "github.example.com", explicitURL: "wss://sprinkler.example.com/ws", enable: true,
+			wantURL: "wss://sprinkler.example.com/ws", wantUse: true},
+		{name: "GHES explicitly enabled without a server", host: "github.example.com", enable: true, explicitEnable: true, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gotURL, gotUse, err := resolveSprinkler(tt.host, tt.explicitURL, tt.enable, tt.explicitEnable)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveSprinkler() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if gotURL != tt.wantURL || gotUse != tt.wantUse {
+				t.Errorf("resolveSprinkler() = %q, %v, want %q, %v", gotURL, gotUse, tt.wantURL, tt.wantUse)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"strings"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/reviewer"
+)
+
+// reviewRequester requests reviews on a PR; *github.Client implements it.
+type reviewRequester interface {
+	AddReviewRequests(ctx context.Context, owner, repo string, prNumber int, users, teams []string) error
+}
+
+// assigner requests reviews for analyzed PRs, asking for confirmation first unless yes is set.
+type assigner struct {
+	client reviewRequester
+	in     *bufio.Reader // Confirmation answers
+	out    io.Writer     // Confirmation prompts
+	count  int           // Number of top candidates to request
+	yes    bool          // Skip confirmation
+}
+
+// assign requests review from the top candidates and owning teams of each report, as the
+// bot would. PRs the bot would skip (drafts, PRs with existing reviewers, PRs matching a
+// skip rule) are left alone, and PRs matching a route rule get the rule's reviewers and teams.
+// Returns false if any review request failed.
+func (a *assigner) assign(ctx context.Context, reports []*report) bool {
+	ok := true
+	for _, r := range reports {
+		ref := github.PRRef{Owner: r.PullRequest.Owner, Repo: r.PullRequest.Repo, Number: r.PullRequest.Number}
+
+		if reason := reviewer.SkipReason(r.pr); reason != "" {
+			slog.Info("Not requesting reviewers", "pr", ref.String(), "reason", reason)
+			continue
+		}
+		if r.Rule != nil && r.Rule.Action == config.RuleSkip {
+			slog.Info("Not requesting reviewers", "pr", ref.String(), "reason", "rule: "+r.Rule.Reason)
+			continue
+		}
+
+		var reviewers, teams []string
+		if r.Rule != nil {
+			reviewers, teams = r.Rule.Reviewers, r.Rule.Teams
+		} else {
+			for _, c := range r.Candidates[:min(a.count, len(r.Candidates))] {
+				reviewers = append(reviewers, c.Username)
+			}
+			teams = r.requestTeams
+		}
+		if len(reviewers) == 0 && len(teams) == 0 {
+			continue
+		}
+
+		if !a.yes && !a.confirm(ref, reviewers, teams) {
+			slog.Info("Review request cancelled", "pr", ref.String())
+			continue
+		}
+
+		if err := a.client.AddReviewRequests(ctx, ref.Owner, ref.Repo, ref.Number, reviewers, teams); err != nil {
+			slog.Error("Failed to request reviewers", "pr", ref.String(), "reviewers", reviewers, "teams", teams, "error", err)
+			ok = false
+			continue
+		}
+		slog.Info("Requested reviewers", "pr", ref.String(), "reviewers", reviewers, "teams", teams)
+	}
+	return ok
+}
+
+// confirm asks whether to request review from reviewers and teams. Anything but y/yes, including EOF, declines.
+func (a *assigner) confirm(ref github.PRRef, reviewers, teams []string) bool {
+	who := make([]string, 0, len(reviewers)+len(teams))
+	for _, u := range reviewers {
+		who = append(who, "@"+u)
+	}
+	for _, team := range teams {
+		who = append(who, "@"+ref.Owner+"/"+team)
+	}
+	fmt.Fprintf(a.out, "Request review from %s on %s? [y/N] ", strings.Join(who, ", "), ref)
+	answer, err := a.in.ReadString('\n')
+	if err != nil && answer == "" {
+		fmt.Fprintln(a.out)
+		return false
+	}
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
@@ -0,0 +1,153 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+// fakeRequester records review requests; requests for PR numbers in fail return an error.
+type fakeRequester struct {
+	fail      map[int]bool
+	requested []string
+}
+
+func (f *fakeRequester) AddReviewRequests(_ context.Context, owner, repo string, prNumber int, users, teams []string) error {
+	if f.fail[prNumber] {
+		return errors.New("validation failed")
+	}
+	f.requested = append(f.requested, fmt.Sprintf("%s/%s#%d %v %v", owner, repo, prNumber, users, teams))
+	return nil
+}
+
+// assignReport returns a report for PR number with the given candidates.
+func assignReport(number int, pr *types.PullRequest, candidates ...string) *report {
+	cs := make([]types.ReviewerCandidate, len(candidates))
+	for i, c := range candidates {
+		cs[i] = types.ReviewerCandidate{Username: c}
+	}
+	return newReport(github.PRRef{Owner: "o", Repo: "r", Number: number}, "", pr, "", nil, nil, cs)
+}
+
+func TestAssign(t *testing.T) {
+	open := &types.PullRequest{State: "open"}
+	routed := assignReport(4, open, "alice")
+	routed.Rule = &ruleSummary{Action: config.RuleRoute, Reviewers: []string{"carol"}, Teams: []string{"core"}}
+	owned := assignReport(6, open, "alice")
+	owned.requestTeams = []string{"core"}
+	skipped := assignReport(5, open, "alice")
+	skipped.Rule = &ruleSummary{Action: config.RuleSkip, Reason: `label "wip"`}
+
+	tests := []struct {
+		name    string
+		reports []*report
+		input   string
+		yes     bool
+		fail    map[int]bool
+		want    []string
+		wantOK  bool
+		prompts int
+	}{
+		{
+			name:    "top candidates up to count",
+			reports: []*report{assignReport(1, open, "alice", "bob", "carol")},
+			yes:     true,
+			want:    []string{"o/r#1 [alice bob] []"},
+			wantOK:  true,
+		},
+		{
+			name: "drafts, PRs with reviewers and skip rules are left alone",
+			reports: []*report{
+				assignReport(1, &types.PullRequest{Draft: true}, "alice"),
+				assignReport(2, &types.PullRequest{Reviewers: []string{"dave"}}, "alice"),
+				assignReport(3, &types.PullRequest{ReviewerTeams: []string{"core"}}, "alice"),
+				skipped,
+				assignReport(6, open),
+			},
+			yes:    true,
+			wantOK: true,
+		},
+		{
+			name:    "route rule replaces candidates",
+			reports: []*report{routed},
+			yes:     true,
+			want:    []string{"o/r#4 [carol] [core]"},
+			wantOK:  true,
+		},
+		{
+			name:    "owning teams alongside candidates",
+			reports: []*report{owned, assignReport(7, open)},
+			yes:     true,
+			want:    []string{"o/r#6 [alice] [core]"},
+			wantOK:  true,
+		},
+		{
+			name:    "confirmed and declined",
+			reports: []*report{assignReport(1, open, "alice"), assignReport(2, open, "bob"), assignReport(3, open, "carol")},
+			input:   "y\nn\nYES\n",
+			want:    []string{"o/r#1 [alice] []", "o/r#3 [carol] []"},
+			wantOK:  true,
+			prompts: 3,
+		},
+		{
+			name:    "end of input declines",
+			reports: []*report{assignReport(1, open, "alice"), assignReport(2, open, "bob")},
+			input:   "y",
+			want:    []string{"o/r#1 [alice] []"},
+			wantOK:  true,
+			prompts: 2,
+		},
+		{
+			name:    "failed request is reported but others continue",
+			reports: []*report{assignReport(1, open, "alice"), assignReport(2, open, "bob")},
+			yes:     true,
+			fail:    map[int]bool{1: true},
+			want:    []string{"o/r#2 [bob] []"},
+			wantOK:  false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requester := &fakeRequester{fail: tt.fail}
+			var out strings.Builder
+			a := &assigner{
+				client: requester,
+				in:     bufio.NewReader(strings.NewReader(tt.input)),
+				out:    &out,
+				count:  2,
+				yes:    tt.yes,
+			}
+
+			if got := a.assign(context.Background(), tt.reports); got != tt.wantOK {
+				t.Errorf("assign() = %v, want %v", got, tt.wantOK)
+			}
+			if !slices.Equal(requester.requested, tt.want) {
+				t.Errorf("requested %v, want %v", requester.requested, tt.want)
+			}
+			if got := strings.Count(out.String(), "[y/N]"); got != tt.prompts {
+				t.Errorf("prompted %d times, want %d:\n%s", got, tt.prompts, out.String())
+			}
+		})
+	}
+}
+
+func TestConfirmPrompt(t *testing.T) {
+	var out strings.Builder
+	a := &assigner{in: bufio.NewReader(strings.NewReader(" Y \n")), out: &out}
+
+	if !a.confirm(github.PRRef{Owner: "o", Repo: "r", Number: 1}, []string{"alice"}, []string{"core"}) {
+		t.Error("confirm() = false for \" Y \"")
+	}
+	if want := "Request review from @alice, @o/core on o/r#1? [y/N] "; out.String() != want {
+		t.Errorf("prompt = %q, want %q", out.String(), want)
+	}
+}
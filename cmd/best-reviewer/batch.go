@@ -0,0 +1,189 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/reviewer"
+)
+
+// batchResult is the outcome of analyzing one PR in batch mode.
+type batchResult struct {
+	Report      *report `json:"report,omitempty"`
+	PullRequest string  `json:"pull_request"` // owner/repo#number
+	Error       string  `json:"error,omitempty"`
+}
+
+// batchReport is the -output=json schema in batch mode.
+type batchReport struct {
+	Results       []batchResult `json:"results"` // In input order
+	SchemaVersion int           `json:"schema_version"`
+}
+
+// collectRefs parses PR references from command-line arguments and, if path is set,
+// from a file with one reference per line ("-" reads stdin). Blank lines and lines
+// starting with # are ignored. PR URLs must be on host. Duplicates are removed, keeping the first occurrence.
+func collectRefs(args []string, path, host string) ([]github.PRRef, error) {
+	inputs := append([]string{}, args...)
+
+	if path != "" {
+		var r io.Reader = os.Stdin
+		if path != "-" {
+			f, err := os.Open(path)
+			if err != nil {
+				return nil, fmt.Errorf("failed to open PR list: %w", err)
+			}
+			defer f.Close() //nolint:errcheck // read-only file
+			r = f
+		}
+		scanner := bufio.NewScanner(r)
+		for scanner.Scan() {
+			line := strings.TrimSpace(scanner.Text())
+			if line == "" || strings.HasPrefix(line, "#") {
+				continue
+			}
+			inputs = append(inputs, line)
+		}
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("failed to read PR list: %w", err)
+		}
+	}
+
+	refs := make([]github.PRRef, 0, len(inputs))
+	for _, input := range inputs {
+		ref, err := parsePRURL(input, host)
+		if err != nil {
+			return nil, fmt.Errorf("%q: %w", input, err)
+		}
+		refs = append(refs, ref)
+	}
+	return dedupeRefs(refs), nil
+}
+
+// dedupeRefs removes duplicate references, keeping the first occurrence.
+func dedupeRefs(refs []github.PRRef) []github.PRRef {
+	seen := make(map[github.PRRef]bool, len(refs))
+	unique := refs[:0]
+	for _, ref := range refs {
+		if !seen[ref] {
+			seen[ref] = true
+			unique = append(unique, ref)
+		}
+	}
+	return unique
+}
+
+// batchMode reports whether the CLI should produce an aggregated report, given the
+// -file and -query flags. A single PR argument keeps the single-PR output format.
+func batchMode(refs []github.PRRef, file, query string) bool {
+	return len(refs) != 1 || file != "" || query != ""
+}
+
+// analyzeAll analyzes PRs with at most concurrency in flight. The client and finder
+// caches are shared, so repository data is only fetched once per run.
+func analyzeAll(ctx context.Context, client *github.Client, finder *reviewer.Finder, refs []github.PRRef, concurrency int) []batchResult {
+	slog.Info("Analyzing pull requests in batch", "count", len(refs), "concurrency", concurrency)
+
+	results := make([]batchResult, len(refs))
+	sem := make(chan struct{}, concurrency)
+	var wg sync.WaitGroup
+	for i, ref := range refs {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func() {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			results[i].PullRequest = ref.String()
+			rep, err := analyze(ctx, client, finder, ref)
+			if err != nil {
+				slog.Error("Failed to analyze PR", "pr", ref.String(), "error", err)
+				results[i].Error = err.Error()
+				return
+			}
+			results[i].Report = rep
+		}()
+	}
+	wg.Wait()
+	return results
+}
+
+// exitCode returns exitError if any PR failed or, with requestsOK false, any review
+// request failed; exitNoReviewers if any PR has no candidates; and exitOK otherwise.
+func exitCode(results []batchResult, requestsOK bool) int {
+	if !requestsOK {
+		return exitError
+	}
+	code := exitOK
+	for _, r := range results {
+		if r.Report == nil {
+			return exitError
+		}
+		if len(r.Report.Candidates) == 0 {
+			code = exitNoReviewers
+		}
+	}
+	return code
+}
+
+// renderBatch writes the results of a batch in the given format.
+func renderBatch(w io.Writer, format string, results []batchResult, verbose bool) error {
+	if format == formatJSON {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(batchReport{SchemaVersion: schemaVersion, Results: nonNil(results)})
+	}
+
+	for i, r := range results {
+		if format == formatMarkdown && i > 0 {
+			if _, err := io.WriteString(w, "\n---\n\n"); err != nil {
+				return err
+			}
+		}
+		if r.Report == nil {
+			line := fmt.Sprintf("\n❌ %s: %s\n", r.PullRequest, r.Error)
+			if format == formatMarkdown {
+				line = fmt.Sprintf("### %s\n\nFailed: %s\n", r.PullRequest, r.Error)
+			}
+			if _, err := io.WriteString(w, line); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := render(w, format, r.Report, verbose); err != nil {
+			return err
+		}
+	}
+
+	_, err := io.WriteString(w, batchSummary(format, results))
+	return err
+}
+
+// batchSummary returns a one-line count of PRs with reviewers, without reviewers, and failed.
+func batchSummary(format string, results []batchResult) string {
+	var found, none, failed int
+	for _, r := range results {
+		switch {
+		case r.Report == nil:
+			failed++
+		case len(r.Report.Candidates) == 0:
+			none++
+		default:
+			found++
+		}
+	}
+	summary := fmt.Sprintf("Analyzed %d pull requests: %d with reviewers, %d without, %d failed\n",
+		len(results), found, none, failed)
+	if format == formatMarkdown {
+		return "\n---\n\n**" + strings.TrimSuffix(summary, "\n") + "**\n"
+	}
+	return "\n📊 " + summary
+}
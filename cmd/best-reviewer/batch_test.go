@@ -0,0 +1,190 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+// testReport returns a report with one candidate and no collaborators or teams.
+func testReport() *report {
+	created := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	pr := &types.PullRequest{
+		Title:        "Fix the parser",
+		Author:       "bob",
+		State:        "open",
+		CreatedAt:    created,
+		UpdatedAt:    created.Add(time.Hour),
+		ChangedFiles: []types.ChangedFile{{Filename: "parser.go"}},
+	}
+	candidates := []types.ReviewerCandidate{{
+		Username:        "alice",
+		SelectionMethod: "blame-author:+12",
+		ContextScore:    12,
+		Breakdown: &types.ScoreBreakdown{
+			Sources:   map[string]int{"blame-author": 12},
+			Expertise: 12,
+			Evidence:  []types.Evidence{{Source: "blame-author", Path: "parser.go", Lines: 4, Score: 12}},
+		},
+	}}
+	ref := github.PRRef{Owner: "owner", Repo: "repo", Number: 2}
+	return newReport(ref, "https://github.com/owner/repo/pull/2", pr, "", nil, nil, candidates)
+}
+
+func TestCollectRefs(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "prs.txt")
+	list := "# release blockers\n\nowner/repo#2\n  https://github.com/owner/repo/pull/3  \nowner/repo#1\n"
+	if err := os.WriteFile(path, []byte(list), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		args    []string
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{name: "arguments", args: []string{"owner/repo#1", "owner/other#1"}, want: []string{"owner/repo#1", "owner/other#1"}},
+		{name: "arguments then file, first occurrence kept", args: []string{"owner/repo#1"}, path: path, want: []string{"owner/repo#1", "owner/repo#2", "owner/repo#3"}},
+		{name: "url and shorthand are the same PR", args: []string{"https://github.com/owner/repo/pull/1", "owner/repo#1"}, want: []string{"owner/repo#1"}},
+		{name: "invalid reference", args: []string{"owner/repo#1", "nonsense"}, wantErr: true},
+		{name: "missing file", path: filepath.Join(t.TempDir(), "missing.txt"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refs, err := collectRefs(tt.args, tt.path, "github.com")
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("collectRefs() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			got := make([]string, len(refs))
+			for i, ref := range refs {
+				got[i] = ref.String()
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("collectRefs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDedupeRefs(t *testing.T) {
+	a := github.PRRef{Owner: "o", Repo: "r", Number: 1}
+	b := github.PRRef{Owner: "o", Repo: "r", Number: 2}
+	c := github.PRRef{Owner: "o", Repo: "other", Number: 1}
+
+	got := dedupeRefs([]github.PRRef{b, a, b, c, a})
+	if want := []github.PRRef{b, a, c}; !slices.Equal(got, want) {
+		t.Errorf("dedupeRefs() = %v, want %v", got, want)
+	}
+	if got := dedupeRefs(nil); len(got) != 0 {
+		t.Errorf("dedupeRefs(nil) = %v", got)
+	}
+}
+
+func TestBatchMode(t *testing.T) {
+	one := []github.PRRef{{Owner: "o", Repo: "r", Number: 1}}
+	two := append(slices.Clone(one), github.PRRef{Owner: "o", Repo: "r", Number: 2})
+
+	tests := []struct {
+		name  string
+		refs  []github.PRRef
+		file  string
+		query string
+		want  bool
+	}{
+		{name: "single PR", refs: one, want: false},
+		{name: "several PRs", refs: two, want: true},
+		{name: "single PR from a file", refs: one, file: "prs.txt", want: true},
+		{name: "single PR from a query", refs: one, query: "is:open", want: true},
+		{name: "query without results", query: "is:open", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := batchMode(tt.refs, tt.file, tt.query); got != tt.want {
+				t.Errorf("batchMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	found := batchResult{PullRequest: "o/r#1", Report: &report{Candidates: []types.ReviewerCandidate{{Username: "alice"}}}}
+	none := batchResult{PullRequest: "o/r#2", Report: &report{Candidates: []types.ReviewerCandidate{}}}
+	failed := batchResult{PullRequest: "o/r#3", Error: "not found"}
+
+	tests := []struct {
+		name       string
+		results    []batchResult
+		requestsOK bool
+		want       int
+	}{
+		{name: "reviewers found", results: []batchResult{found}, requestsOK: true, want: exitOK},
+		{name: "no reviewers", results: []batchResult{none}, requestsOK: true, want: exitNoReviewers},
+		{name: "some without reviewers", results: []batchResult{found, none}, requestsOK: true, want: exitNoReviewers},
+		{name: "failure outranks no reviewers", results: []batchResult{none, failed, found}, requestsOK: true, want: exitError},
+		{name: "review request failed", results: []batchResult{found}, requestsOK: false, want: exitError},
+		{name: "empty batch", requestsOK: true, want: exitOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := exitCode(tt.results, tt.requestsOK); got != tt.want {
+				t.Errorf("exitCode() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderBatchJSON(t *testing.T) {
+	results := []batchResult{
+		{PullRequest: "owner/repo#2", Report: testReport()},
+		{PullRequest: "owner/repo#1", Error: "failed to fetch PR: not found"},
+	}
+
+	var buf bytes.Buffer
+	if err := renderBatch(&buf, formatJSON, results, false); err != nil {
+		t.Fatalf("renderBatch() error = %v", err)
+	}
+
+	var got struct {
+		Results []struct {
+			Report      map[string]any `json:"report"`
+			PullRequest string         `json:"pull_request"`
+			Error       string         `json:"error"`
+		} `json:"results"`
+		SchemaVersion int `json:"schema_version"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if got.SchemaVersion != schemaVersion {
+		t.Errorf("schema_version = %d, want %d", got.SchemaVersion, schemaVersion)
+	}
+	if len(got.Results) != 2 || got.Results[0].PullRequest != "owner/repo#2" || got.Results[1].PullRequest != "owner/repo#1" {
+		t.Fatalf("results not in input order: %+v", got.Results)
+	}
+	if got.Results[0].Report == nil || got.Results[0].Error != "" {
+		t.Errorf("successful result = %+v", got.Results[0])
+	}
+	if got.Results[1].Report != nil || got.Results[1].Error == "" {
+		t.Errorf("failed result = %+v", got.Results[1])
+	}
+
+	// An empty batch is still a results array, not null
+	buf.Reset()
+	if err := renderBatch(&buf, formatJSON, nil, false); err != nil {
+		t.Fatalf("renderBatch(nil) error = %v", err)
+	}
+	if !strings.Contains(buf.String(), `"results": []`) {
+		t.Errorf("empty batch = %s, want an empty results array", buf.String())
+	}
+}
@@ -2,6 +2,8 @@
 package main
 
 import (
+	"bufio"
+	"cmp"
 	"context"
 	"errors"
 	"flag"
@@ -12,11 +14,38 @@ import (
 	"strings"
 	"time"
 
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/availability"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/reviewer"
 )
 
-var verbose = flag.Bool("v", false, "Verbose output with detailed diagnostics")
+var (
+	verbose     = flag.Bool("v", false, "Verbose output with detailed diagnostics")
+	outputFlag  = flag.String("output", formatText, "Output format: text, json, or markdown")
+	jsonOutput  = flag.Bool("json", false, "Shorthand for -output=json")
+	fileFlag    = flag.String("file", "", "Read PR references from a file, one per line (- for stdin)")
+	queryFlag   = flag.String("query", "", "Analyze PRs matching a GitHub search query (e.g. \"repo:owner/name is:open review:none\")")
+	limitFlag   = flag.Int("limit", 50, "Maximum number of PRs to analyze from -query (0 for no limit)")
+	concurrency = flag.Int("concurrency", 4, "Number of PRs to analyze in parallel in batch mode")
+	assignFlag  = flag.Int("assign", 0, "Request review from the top N candidates (drafts and PRs with reviewers are skipped)")
+	yesFlag     = flag.Bool("yes", false, "Do not ask for confirmation before requesting reviews with -assign")
+	oooCalendar = flag.String("ooo-calendar", "", "Out-of-office calendar file (.ics or YAML date ranges)")
+	inactive    = flag.Duration("inactive-after", availability.DefaultInactiveAfter, "Inactivity in the repository after which reviewers are penalized (0 disables)")
+	apiURLFlag  = flag.String("api-url", "", "GitHub REST API URL for GitHub Enterprise Server, e.g. https://github.example.com/api/v3 (default $GITHUB_API_URL or https://api.github.com)")
+	graphQLFlag = flag.String("graphql-url", "", "GitHub GraphQL URL (default $GITHUB_GRAPHQL_URL or derived from -api-url)")
+)
+
+// Exit codes. exitUsage matches the flag package's exit code for bad flags.
+const (
+	exitOK          = 0
+	exitError       = 1
+	exitUsage       = 2
+	exitNoReviewers = 3
+)
+
+// weights holds the scoring weights; set via -weights, repository config files may override them.
+var weights = reviewer.DefaultWeights()
 
 const prCountCache = 6 * time.Hour
 
@@ -30,51 +59,108 @@ func defaultCacheDir() string {
 
 func main() {
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "Usage: %s <PR_URL> [options]\n\n", os.Args[0])
-		fmt.Fprint(os.Stderr, "Analyzes a GitHub pull request and recommends the top 5 reviewers.\n\n")
+		fmt.Fprintf(os.Stderr, "Usage: %s [options] <PR_URL>...\n\n", os.Args[0])
+		fmt.Fprint(os.Stderr, "Analyzes GitHub pull requests and recommends the top 5 reviewers for each.\n\n")
 		fmt.Fprint(os.Stderr, "Arguments:\n")
-		fmt.Fprint(os.Stderr, "  PR_URL    Pull request URL (e.g., https://github.com/owner/repo/pull/123 or owner/repo#123)\n\n")
+		fmt.Fprint(os.Stderr, "  PR_URL    Pull request URL (e.g., https://github.com/owner/repo/pull/123 or owner/repo#123)\n")
+		fmt.Fprint(os.Stderr, "            Several PRs, -file, or -query analyze PRs in batch with a combined report\n\n")
 		fmt.Fprint(os.Stderr, "Options:\n")
 		flag.PrintDefaults()
 		fmt.Fprint(os.Stderr, "\nExamples:\n")
 		fmt.Fprintf(os.Stderr, "  %s https://github.com/owner/repo/pull/123\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s owner/repo#123\n", os.Args[0])
 		fmt.Fprintf(os.Stderr, "  %s owner/repo#123 -v\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -output markdown owner/repo#123\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s owner/repo#123 owner/repo#124\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -file prs.txt\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -query \"repo:owner/name is:open review:none\"\n", os.Args[0])
+		fmt.Fprintf(os.Stderr, "  %s -assign 2 owner/repo#123\n", os.Args[0])
+		fmt.Fprint(os.Stderr, "\nEnvironment Variables:\n")
+		fmt.Fprint(os.Stderr, "  GITHUB_API_URL       - REST API URL, as set by GitHub Actions on GitHub Enterprise Server\n")
+		fmt.Fprint(os.Stderr, "  GITHUB_GRAPHQL_URL   - GraphQL URL\n")
+		fmt.Fprint(os.Stderr, "\nExit Codes:\n")
+		fmt.Fprintf(os.Stderr, "  %d  Reviewers found\n", exitOK)
+		fmt.Fprintf(os.Stderr, "  %d  Error (in batch mode: any PR failed; with -assign: any review request failed)\n", exitError)
+		fmt.Fprintf(os.Stderr, "  %d  Invalid arguments\n", exitUsage)
+		fmt.Fprintf(os.Stderr, "  %d  No suitable reviewers found (in batch mode: for any PR)\n", exitNoReviewers)
 	}
+	flag.Var(&weights, "weights", "Scoring weight overrides as name=value pairs (e.g. assignee=100,file=8)")
 	flag.Parse()
 
-	if flag.NArg() < 1 {
+	if flag.NArg() < 1 && *fileFlag == "" && *queryFlag == "" {
 		flag.Usage()
-		os.Exit(1)
+		os.Exit(exitUsage)
 	}
 
-	prURL := flag.Arg(0)
+	format := *outputFlag
+	if *jsonOutput {
+		format = formatJSON
+	}
+	if !validFormat(format) {
+		fmt.Fprintf(os.Stderr, "invalid -output %q (expected text, json, or markdown)\n", format)
+		os.Exit(exitUsage)
+	}
+	if *concurrency < 1 {
+		fmt.Fprintf(os.Stderr, "invalid -concurrency %d (must be at least 1)\n", *concurrency)
+		os.Exit(exitUsage)
+	}
+	if *assignFlag < 0 {
+		fmt.Fprintf(os.Stderr, "invalid -assign %d (must not be negative)\n", *assignFlag)
+		os.Exit(exitUsage)
+	}
+	if *assignFlag > 0 && !*yesFlag && *fileFlag == "-" {
+		fmt.Fprint(os.Stderr, "-assign with -file - reads PRs from stdin, so confirmation is impossible; use -yes\n")
+		os.Exit(exitUsage)
+	}
 
 	// Set up structured logging
 	logLevel := slog.LevelInfo
 	if *verbose {
 		logLevel = slog.LevelDebug
 	}
-	logger := slog.New(slog.NewTextHandler(os.Stdout, &slog.HandlerOptions{
+	// Keep stdout clean for machine-readable formats
+	logOutput := os.Stdout
+	if format != formatText {
+		logOutput = os.Stderr
+	}
+	logger := slog.New(slog.NewTextHandler(logOutput, &slog.HandlerOptions{
 		Level: logLevel,
 	}))
 	slog.SetDefault(logger)
 
 	ctx := context.Background()
 
-	// Parse PR URL
-	ref, err := parsePRURL(prURL)
+	// Resolve the GitHub instance first so PR URLs on GitHub Enterprise Server are recognized
+	apiURL := cmp.Or(*apiURLFlag, os.Getenv("GITHUB_API_URL"))
+	graphQLURL := cmp.Or(*graphQLFlag, os.Getenv("GITHUB_GRAPHQL_URL"))
+	host, err := github.WebHost(apiURL)
 	if err != nil {
-		slog.Error("Invalid PR URL", "error", err)
-		os.Exit(1)
+		slog.Error("Invalid GitHub API URL", "error", err)
+		os.Exit(exitUsage)
+	}
+
+	// Parse PR references from arguments and -file
+	refs, err := collectRefs(flag.Args(), *fileFlag, host)
+	if err != nil {
+		slog.Error("Invalid PR reference", "error", err)
+		os.Exit(exitUsage)
+	}
+
+	availCfg := availability.Config{InactiveAfter: *inactive}
+	if *oooCalendar != "" {
+		availCfg.Calendar, err = availability.LoadCalendar(*oooCalendar)
+		if err != nil {
+			slog.Error("Failed to load out-of-office calendar", "path", *oooCalendar, "error", err)
+			os.Exit(exitUsage)
+		}
 	}
 
 	// Get GitHub token from gh CLI
-	token, err := getGitHubToken(ctx)
+	token, err := getGitHubToken(ctx, host)
 	if err != nil {
 		slog.Error("Failed to get GitHub token", "error", err)
 		slog.Info("Make sure you have the gh CLI installed and authenticated (run: gh auth login)")
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
 	// Create GitHub client
@@ -84,150 +170,161 @@ func main() {
 		HTTPTimeout: 30 * time.Second,
 		CacheTTL:    24 * time.Hour,
 		CacheDir:    defaultCacheDir(),
+		APIURL:      apiURL,
+		GraphQLURL:  graphQLURL,
 	}
 	client, err := github.New(ctx, cfg)
 	if err != nil {
 		slog.Error("Failed to create GitHub client", "error", err)
-		os.Exit(1)
+		os.Exit(exitError)
 	}
 
 	// Create reviewer finder
 	finderCfg := reviewer.Config{
+		ConfigLoader: config.NewLoader(client, client.Cache()),
+		Weights:      weights,
+		Availability: availability.New(client, availCfg),
 		PRCountCache: prCountCache,
 	}
-	finder := reviewer.New(client, finderCfg)
-
-	// Fetch PR details
-	slog.Info("Fetching PR details", "owner", ref.owner, "repo", ref.repo, "number", ref.prNumber)
-	pr, err := client.PullRequest(ctx, ref.owner, ref.repo, ref.prNumber)
+	finder, err := reviewer.New(client, finderCfg)
 	if err != nil {
-		slog.Error("Failed to fetch PR", "error", err)
-		os.Exit(1)
+		slog.Error("Failed to create reviewer finder", "error", err)
+		os.Exit(exitError)
 	}
 
-	// Print PR information
-	fmt.Printf("\n📋 Pull Request: %s/%s#%d\n", ref.owner, ref.repo, ref.prNumber)
-	fmt.Printf("   Title: %s\n", pr.Title)
-	fmt.Printf("   Author: %s\n", pr.Author)
-	fmt.Printf("   State: %s\n", pr.State)
-	if pr.Draft {
-		fmt.Println("   Draft: yes")
+	requester := &assigner{
+		client: client,
+		in:     bufio.NewReader(os.Stdin),
+		out:    os.Stderr,
+		count:  *assignFlag,
+		yes:    *yesFlag,
 	}
-	fmt.Printf("   Changed files: %d\n", len(pr.ChangedFiles))
-	if len(pr.Reviewers) > 0 {
-		fmt.Printf("   Current reviewers: %s\n", strings.Join(pr.Reviewers, ", "))
-	}
-	fmt.Println()
 
-	// Get all project collaborators for context
-	collaborators, err := client.Collaborators(ctx, ref.owner, ref.repo)
-	if err != nil {
-		slog.Warn("Failed to fetch collaborators", "error", err)
-		// Continue without collaborator list
+	if *queryFlag != "" {
+		found, err := client.SearchPullRequests(ctx, *queryFlag, *limitFlag)
+		if err != nil {
+			slog.Error("Failed to search pull requests", "error", err)
+			os.Exit(exitError)
+		}
+		refs = dedupeRefs(append(refs, found...))
 	}
 
-	// Find reviewers
-	slog.Info("Finding best reviewers")
-	candidates, err := finder.Find(ctx, pr)
-	if err != nil {
-		slog.Error("Failed to find reviewers", "error", err)
-		os.Exit(1)
+	if !batchMode(refs, *fileFlag, *queryFlag) {
+		rep, err := analyze(ctx, client, finder, refs[0])
+		if err != nil {
+			slog.Error("Failed to analyze PR", "error", err)
+			os.Exit(exitError)
+		}
+		if err := render(os.Stdout, format, rep, *verbose); err != nil {
+			slog.Error("Failed to write output", "error", err)
+			os.Exit(exitError)
+		}
+		requestsOK := *assignFlag == 0 || requester.assign(ctx, []*report{rep})
+		os.Exit(exitCode([]batchResult{{PullRequest: refs[0].String(), Report: rep}}, requestsOK))
 	}
 
-	// Display collaborators if available
-	if len(collaborators) > 0 {
-		// Filter out bots from display
-		validCollaborators := make([]string, 0, len(collaborators))
-		for _, collab := range collaborators {
-			if !client.IsUserBot(ctx, collab) {
-				validCollaborators = append(validCollaborators, collab)
+	results := analyzeAll(ctx, client, finder, refs, *concurrency)
+	if err := renderBatch(os.Stdout, format, results, *verbose); err != nil {
+		slog.Error("Failed to write output", "error", err)
+		os.Exit(exitError)
+	}
+	requestsOK := true
+	if *assignFlag > 0 {
+		var reports []*report
+		for _, r := range results {
+			if r.Report != nil {
+				reports = append(reports, r.Report)
 			}
 		}
+		requestsOK = requester.assign(ctx, reports)
+	}
+	os.Exit(exitCode(results, requestsOK))
+}
 
-		if len(validCollaborators) > 0 {
-			fmt.Printf("👥 Project Collaborators (%d):\n   ", len(validCollaborators))
-			fmt.Println(strings.Join(validCollaborators, ", "))
-			fmt.Println()
-		}
+// analyze fetches a PR and finds its reviewers and owning teams.
+func analyze(ctx context.Context, client *github.Client, finder *reviewer.Finder, ref github.PRRef) (*report, error) {
+	slog.Info("Fetching PR details", "owner", ref.Owner, "repo", ref.Repo, "number", ref.Number)
+	pr, err := client.PullRequest(ctx, ref.Owner, ref.Repo, ref.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch PR: %w", err)
 	}
 
-	// Display results
-	if len(candidates) == 0 {
-		fmt.Println("❌ No suitable reviewers found")
-		os.Exit(0)
+	// Load repository config so problems are reported before any analysis
+	repoCfg, err := finder.RepoConfig(ctx, ref.Owner, ref.Repo)
+	if err != nil {
+		return nil, fmt.Errorf("invalid reviewer config: %w", err)
 	}
 
-	topCount := min(5, len(candidates))
-	fmt.Printf("🏆 Top %d Reviewer Recommendations (in descending order):\n\n", topCount)
-	for i, candidate := range candidates[:topCount] {
-		fmt.Printf("%d. @%s\n", i+1, candidate.Username)
-		fmt.Printf("   Selection Method: %s\n", candidate.SelectionMethod)
-		fmt.Printf("   Context Score: %d\n", candidate.ContextScore)
-		if candidate.ActivityScore > 0 {
-			fmt.Printf("   Activity Score: %d\n", candidate.ActivityScore)
-		}
-		if !candidate.LastActivity.IsZero() {
-			fmt.Printf("   Last Activity: %s\n", candidate.LastActivity.Format(time.RFC3339))
-		}
-		if *verbose && candidate.AuthorAssociation != "" {
-			fmt.Printf("   Association: %s\n", candidate.AuthorAssociation)
+	// Get all project collaborators for context
+	collaborators, err := client.Collaborators(ctx, ref.Owner, ref.Repo)
+	if err != nil {
+		slog.Warn("Failed to fetch collaborators", "error", err)
+		// Continue without collaborator list
+	}
+	validCollaborators := make([]string, 0, len(collaborators))
+	for _, collab := range collaborators {
+		if !client.IsUserBot(ctx, collab) {
+			validCollaborators = append(validCollaborators, collab)
 		}
-		fmt.Println()
 	}
 
-	fmt.Printf("✅ Found %d total candidates\n", len(candidates))
-}
+	// Find reviewers
+	slog.Info("Finding best reviewers", "pr", ref.String())
+	result, err := finder.Analyze(ctx, pr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find reviewers: %w", err)
+	}
+
+	teams, err := finder.FindTeams(ctx, pr)
+	if err != nil {
+		slog.Warn("Failed to find teams", "error", err)
+	}
 
-// prRef holds a parsed PR reference.
-type prRef struct {
-	owner    string
-	repo     string
-	prNumber int
+	r := newReport(ref, client.PRURL(ref), pr, repoCfg.Source, validCollaborators, teams, result.Candidates)
+	r.requestTeams = teams[:min(repoCfg.TeamReviewers, len(teams))]
+	r.Coverage = result.Coverage
+	r.Selection = result.Selection
+	if match := reviewer.MatchRule(pr, repoCfg.Rules); match != nil {
+		r.Rule = &ruleSummary{Action: match.Rule.Action, Reason: match.Reason}
+		if match.Rule.Action == config.RuleRoute {
+			r.Rule.Reviewers = reviewer.RoutedReviewers(pr, repoCfg, &match.Rule)
+			r.Rule.Teams = match.Rule.Teams
+		}
+	}
+	return r, nil
 }
 
-// parsePRURL parses a PR URL or shorthand into a PR reference.
-func parsePRURL(url string) (*prRef, error) {
+// parsePRURL parses a PR URL on host or shorthand into a PR reference.
+func parsePRURL(url, host string) (github.PRRef, error) {
 	// Handle shorthand: owner/repo#123
 	if strings.Contains(url, "#") && !strings.Contains(url, "://") {
 		parts := strings.Split(url, "#")
 		if len(parts) != 2 {
-			return nil, errors.New("invalid PR shorthand format (expected owner/repo#number)")
+			return github.PRRef{}, errors.New("invalid PR shorthand format (expected owner/repo#number)")
 		}
 		repoPath := strings.Split(parts[0], "/")
 		if len(repoPath) != 2 {
-			return nil, errors.New("invalid repository path (expected owner/repo)")
+			return github.PRRef{}, errors.New("invalid repository path (expected owner/repo)")
 		}
 		var prNumber int
 		_, err := fmt.Sscanf(parts[1], "%d", &prNumber)
 		if err != nil {
-			return nil, fmt.Errorf("invalid PR number: %w", err)
+			return github.PRRef{}, fmt.Errorf("invalid PR number: %w", err)
 		}
-		return &prRef{owner: repoPath[0], repo: repoPath[1], prNumber: prNumber}, nil
+		return github.PRRef{Owner: repoPath[0], Repo: repoPath[1], Number: prNumber}, nil
 	}
 
 	// Handle full URL: https://github.com/owner/repo/pull/123
-	//nolint:revive // http fallback intentional for backward compatibility with user input
-	if strings.HasPrefix(url, "https://github.com/") || strings.HasPrefix(url, "http://github.com/") {
-		url = strings.TrimPrefix(url, "https://github.com/")
-		url = strings.TrimPrefix(url, "http://github.com/")
-		parts := strings.Split(url, "/")
-		if len(parts) < 4 || parts[2] != "pull" {
-			return nil, errors.New("invalid GitHub PR URL format")
-		}
-		var prNumber int
-		_, err := fmt.Sscanf(parts[3], "%d", &prNumber)
-		if err != nil {
-			return nil, fmt.Errorf("invalid PR number: %w", err)
-		}
-		return &prRef{owner: parts[0], repo: parts[1], prNumber: prNumber}, nil
+	if strings.Contains(url, "://") {
+		return github.ParsePRURL(url, host)
 	}
 
-	return nil, errors.New("invalid PR URL format (use: https://github.com/owner/repo/pull/123 or owner/repo#123)")
+	return github.PRRef{}, errors.New("invalid PR URL format (use: https://github.com/owner/repo/pull/123 or owner/repo#123)")
 }
 
-// getGitHubToken retrieves the GitHub token from gh CLI.
-func getGitHubToken(ctx context.Context) (string, error) {
-	cmd := exec.CommandContext(ctx, "gh", "auth", "token")
+// getGitHubToken retrieves the GitHub token for host from gh CLI.
+func getGitHubToken(ctx context.Context, host string) (string, error) {
+	cmd := exec.CommandContext(ctx, "gh", "auth", "token", "--hostname", host)
 	output, err := cmd.Output()
 	if err != nil {
 		return "", fmt.Errorf("failed to get GitHub token: %w", err)
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+)
+
+func TestParsePRURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		host    string
+		want    github.PRRef
+		wantErr bool
+	}{
+		{name: "shorthand", input: "owner/repo#123", host: "github.com", want: github.PRRef{Owner: "owner", Repo: "repo", Number: 123}},
+		{name: "url", input: "https://github.com/owner/repo/pull/7", host: "github.com", want: github.PRRef{Owner: "owner", Repo: "repo", Number: 7}},
+		{
+			name:  "enterprise url",
+			input: "https://github.example.com/owner/repo/pull/7",
+			host:  "github.example.com",
+			want:  github.PRRef{Owner: "owner", Repo: "repo", Number: 7},
+		},
+		{name: "url on another host", input: "https://github.com/owner/repo/pull/7", host: "github.example.com", wantErr: true},
+		{name: "issue url", input: "https://github.com/owner/repo/issues/7", host: "github.com", wantErr: true},
+		{name: "shorthand without repo", input: "owner#1", host: "github.com", wantErr: true},
+		{name: "shorthand with extra hash", input: "owner/repo#1#2", host: "github.com", wantErr: true},
+		{name: "shorthand without number", input: "owner/repo#abc", host: "github.com", wantErr: true},
+		{name: "bare repo", input: "owner/repo", host: "github.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parsePRURL(tt.input, tt.host)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parsePRURL(%q) error = %v, wantErr %v", tt.input, err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("parsePRURL(%q) = %+v, want %+v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
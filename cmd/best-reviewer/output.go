@@ -0,0 +1,376 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/reviewer"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+// Output formats.
+const (
+	formatText     = "text"
+	formatJSON     = "json"
+	formatMarkdown = "markdown"
+)
+
+// schemaVersion is the version of the JSON output schema. Bump it when fields are
+// removed or change meaning; adding fields is backwards compatible.
+const schemaVersion = 1
+
+// evidenceToShow is how many evidence entries are printed per candidate without -v.
+const evidenceToShow = 3
+
+// report is everything the CLI knows about a PR's recommended reviewers.
+// Its JSON encoding is the -output=json schema.
+type report struct {
+	pr            *types.PullRequest        // Analyzed PR, used by -assign
+	requestTeams  []string                  // Owning team slugs -assign requests, up to the repository's team_reviewers
+	ConfigSource  string                    `json:"config_source,omitempty"` // Config file used ("" for defaults)
+	Collaborators []string                  `json:"collaborators"`           // Non-bot collaborators of the repository
+	Teams         []string                  `json:"teams"`                   // Owning teams as @org/team
+	Candidates    []types.ReviewerCandidate `json:"candidates"`              // Best first
+	Coverage      *types.Coverage           `json:"coverage,omitempty"`      // How much of the change history analysis covered
+	Selection     []reviewer.Assignment     `json:"selection,omitempty"`     // Reviewers picked for file coverage (selection: coverage)
+	Rule          *ruleSummary              `json:"rule,omitempty"`          // Skip or route rule matching the PR
+	PullRequest   prSummary                 `json:"pull_request"`
+	SchemaVersion int                       `json:"schema_version"`
+}
+
+// ruleSummary describes the repository rule that matched a PR.
+type ruleSummary struct {
+	Action    string   `json:"action"` // "skip" or "route"
+	Reason    string   `json:"reason"` // Conditions that matched
+	Reviewers []string `json:"reviewers,omitempty"`
+	Teams     []string `json:"teams,omitempty"`
+}
+
+// describe summarizes the rule for text and Markdown output.
+func (r *ruleSummary) describe() string {
+	if r.Action == config.RuleSkip {
+		return "skipped (" + r.Reason + ")"
+	}
+	var to []string
+	for _, u := range r.Reviewers {
+		to = append(to, "@"+u)
+	}
+	for _, team := range r.Teams {
+		to = append(to, "team "+team)
+	}
+	if len(to) == 0 {
+		to = append(to, "nobody, all its reviewers are excluded")
+	}
+	return fmt.Sprintf("routed to %s (%s)", strings.Join(to, ", "), r.Reason)
+}
+
+// prSummary describes the analyzed pull request.
+type prSummary struct {
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+	Owner         string    `json:"owner"`
+	Repo          string    `json:"repo"`
+	URL           string    `json:"url"`
+	Title         string    `json:"title"`
+	Author        string    `json:"author"`
+	State         string    `json:"state"`
+	TestState     string    `json:"test_state,omitempty"`
+	Reviewers     []string  `json:"requested_reviewers"`
+	ReviewerTeams []string  `json:"requested_teams"`
+	Number        int       `json:"number"`
+	ChangedFiles  int       `json:"changed_files"`
+	Draft         bool      `json:"draft"`
+}
+
+// newReport assembles a report. Nil slices become empty so JSON consumers always see arrays.
+func newReport(ref github.PRRef, prURL string, pr *types.PullRequest, configSource string, collaborators, teams []string, candidates []types.ReviewerCandidate) *report {
+	orgTeams := make([]string, len(teams))
+	for i, team := range teams {
+		orgTeams[i] = "@" + ref.Owner + "/" + team
+	}
+	return &report{
+		pr:            pr,
+		SchemaVersion: schemaVersion,
+		PullRequest: prSummary{
+			Owner:         ref.Owner,
+			Repo:          ref.Repo,
+			Number:        ref.Number,
+			URL:           prURL,
+			Title:         pr.Title,
+			Author:        pr.Author,
+			State:         pr.State,
+			TestState:     pr.TestState,
+			Draft:         pr.Draft,
+			CreatedAt:     pr.CreatedAt,
+			UpdatedAt:     pr.UpdatedAt,
+			ChangedFiles:  len(pr.ChangedFiles),
+			Reviewers:     nonNil(pr.Reviewers),
+			ReviewerTeams: nonNil(pr.ReviewerTeams),
+		},
+		ConfigSource:  configSource,
+		Collaborators: nonNil(collaborators),
+		Teams:         orgTeams,
+		Candidates:    nonNil(candidates),
+	}
+}
+
+// nonNil returns s, or an empty slice if s is nil.
+func nonNil[T any](s []T) []T {
+	if s == nil {
+		return []T{}
+	}
+	return s
+}
+
+// validFormat reports whether format is a supported output format.
+func validFormat(format string) bool {
+	return format == formatText || format == formatJSON || format == formatMarkdown
+}
+
+// render writes the report in the given format. verbose shows all evidence in text output.
+func render(w io.Writer, format string, r *report, verbose bool) error {
+	switch format {
+	case formatJSON:
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(r)
+	case formatMarkdown:
+		return writeMarkdown(w, r)
+	default:
+		return writeText(w, r, verbose)
+	}
+}
+
+// writeText writes the human-readable report.
+func writeText(w io.Writer, r *report, verbose bool) error {
+	var b strings.Builder
+	pr := r.PullRequest
+
+	fmt.Fprintf(&b, "\n📋 Pull Request: %s/%s#%d\n", pr.Owner, pr.Repo, pr.Number)
+	fmt.Fprintf(&b, "   Title: %s\n", pr.Title)
+	fmt.Fprintf(&b, "   Author: %s\n", pr.Author)
+	fmt.Fprintf(&b, "   State: %s\n", pr.State)
+	if pr.Draft {
+		b.WriteString("   Draft: yes\n")
+	}
+	fmt.Fprintf(&b, "   Changed files: %d\n", pr.ChangedFiles)
+	if r.ConfigSource != "" {
+		fmt.Fprintf(&b, "   Config: %s\n", r.ConfigSource)
+	}
+	if len(pr.Reviewers) > 0 {
+		fmt.Fprintf(&b, "   Current reviewers: %s\n", strings.Join(pr.Reviewers, ", "))
+	}
+	if r.Coverage != nil {
+		fmt.Fprintf(&b, "   Coverage: %s\n", describeCoverage(r.Coverage))
+	}
+	if r.Rule != nil {
+		fmt.Fprintf(&b, "   Rule: %s\n", r.Rule.describe())
+	}
+	b.WriteString("\n")
+
+	if len(r.Collaborators) > 0 {
+		fmt.Fprintf(&b, "👥 Project Collaborators (%d):\n   %s\n\n", len(r.Collaborators), strings.Join(r.Collaborators, ", "))
+	}
+	if len(r.Teams) > 0 {
+		fmt.Fprintf(&b, "🏢 Owning Teams (%d):\n   %s\n\n", len(r.Teams), strings.Join(r.Teams, ", "))
+	}
+
+	if len(r.Candidates) == 0 {
+		b.WriteString("❌ No suitable reviewers found\n")
+		_, err := io.WriteString(w, b.String())
+		return err
+	}
+
+	topCount := min(5, len(r.Candidates))
+	fmt.Fprintf(&b, "🏆 Top %d Reviewer Recommendations (in descending order):\n\n", topCount)
+	for i, candidate := range r.Candidates[:topCount] {
+		fmt.Fprintf(&b, "%d. @%s\n", i+1, candidate.Username)
+		fmt.Fprintf(&b, "   Selection Method: %s\n", candidate.SelectionMethod)
+		fmt.Fprintf(&b, "   Context Score: %d\n", candidate.ContextScore)
+		if candidate.ActivityScore > 0 {
+			fmt.Fprintf(&b, "   Activity Score: %d\n", candidate.ActivityScore)
+		}
+		if !candidate.LastActivity.IsZero() {
+			fmt.Fprintf(&b, "   Last Activity: %s\n", candidate.LastActivity.Format(time.RFC3339))
+		}
+		if verbose && candidate.AuthorAssociation != "" {
+			fmt.Fprintf(&b, "   Association: %s\n", candidate.AuthorAssociation)
+		}
+		if files := r.coveredFiles(candidate.Username); len(files) > 0 {
+			fmt.Fprintf(&b, "   Selected to cover: %s\n", strings.Join(files, ", "))
+		}
+		writeBreakdown(&b, candidate.Breakdown, verbose)
+		b.WriteString("\n")
+	}
+
+	fmt.Fprintf(&b, "✅ Found %d total candidates\n", len(r.Candidates))
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeBreakdown writes why a candidate was recommended.
+func writeBreakdown(b *strings.Builder, bd *types.ScoreBreakdown, verbose bool) {
+	if bd == nil {
+		return
+	}
+
+	b.WriteString("   Score Breakdown:\n")
+	for _, source := range sortedSources(bd) {
+		fmt.Fprintf(b, "     %-16s +%d\n", source, bd.Sources[source])
+	}
+	if bd.AvailabilityPenalty > 0 {
+		fmt.Fprintf(b, "     %-16s -%d (%s)\n", "availability", bd.AvailabilityPenalty, bd.Availability)
+	}
+	if bd.WorkloadPenalty > 0 {
+		fmt.Fprintf(b, "     %-16s -%d (%d open PRs)\n", "workload", bd.WorkloadPenalty, bd.OpenPRs)
+	}
+	if bd.Responsiveness != 0 {
+		fmt.Fprintf(b, "     %-16s %+d (median first review %.1fh)\n", "responsiveness", bd.Responsiveness, bd.MedianResponseHours)
+	}
+
+	if len(bd.Evidence) == 0 {
+		return
+	}
+	b.WriteString("   Evidence:\n")
+	for i, e := range bd.Evidence {
+		if i >= evidenceToShow && !verbose {
+			fmt.Fprintf(b, "     ... %d more (use -v to show all)\n", len(bd.Evidence)-i)
+			break
+		}
+		fmt.Fprintf(b, "     %s: %s\n", e.Source, describeEvidence(e, "PR #%d"))
+	}
+}
+
+// writeMarkdown writes the report as Markdown suitable for a PR comment.
+func writeMarkdown(w io.Writer, r *report) error {
+	var b strings.Builder
+	pr := r.PullRequest
+
+	fmt.Fprintf(&b, "### Suggested reviewers for [%s/%s#%d](%s)\n\n", pr.Owner, pr.Repo, pr.Number, pr.URL)
+
+	if len(r.Candidates) == 0 {
+		b.WriteString("No suitable reviewers found.\n")
+	} else {
+		b.WriteString("| # | Reviewer | Score | Why |\n")
+		b.WriteString("|---|----------|------:|-----|\n")
+		for i, c := range r.Candidates {
+			fmt.Fprintf(&b, "| %d | @%s | %d | %s |\n", i+1, c.Username, c.ContextScore, markdownReason(c))
+		}
+	}
+
+	if len(r.Teams) > 0 {
+		fmt.Fprintf(&b, "\n**Owning teams:** %s\n", strings.Join(r.Teams, ", "))
+	}
+	if r.Coverage != nil {
+		fmt.Fprintf(&b, "\n**Coverage:** %s\n", describeCoverage(r.Coverage))
+	}
+	if r.Rule != nil {
+		fmt.Fprintf(&b, "\n**Rule:** %s\n", r.Rule.describe())
+	}
+	if len(r.Selection) > 0 {
+		picks := make([]string, len(r.Selection))
+		for i, a := range r.Selection {
+			picks[i] = "@" + a.Username
+			if len(a.Files) > 0 {
+				picks[i] += fmt.Sprintf(" (%s)", strings.Join(a.Files, ", "))
+			}
+		}
+		fmt.Fprintf(&b, "\n**Selected for coverage:** %s\n", strings.Join(picks, "; "))
+	}
+
+	var evidence strings.Builder
+	for _, c := range r.Candidates {
+		if c.Breakdown == nil {
+			continue
+		}
+		for _, e := range c.Breakdown.Evidence {
+			fmt.Fprintf(&evidence, "- @%s — %s: %s\n", c.Username, e.Source, describeEvidence(e, "#%d"))
+		}
+	}
+	if evidence.Len() > 0 {
+		b.WriteString("\n<details>\n<summary>Evidence</summary>\n\n")
+		b.WriteString(evidence.String())
+		b.WriteString("\n</details>\n")
+	}
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// coveredFiles returns the files username was selected to cover, if any.
+func (r *report) coveredFiles(username string) []string {
+	for _, a := range r.Selection {
+		if a.Username == username {
+			return a.Files
+		}
+	}
+	return nil
+}
+
+// describeCoverage summarizes how much of the PR's changes history analysis covered.
+func describeCoverage(c *types.Coverage) string {
+	s := fmt.Sprintf("%.0f%% of changed lines have blame evidence (%d/%d) across %d files",
+		c.Ratio()*100, c.BlamedLines, c.ChangedLines, c.FilesAnalyzed)
+	if c.FilesSkipped > 0 {
+		s += fmt.Sprintf(", %d files not blamed", c.FilesSkipped)
+	}
+	return s + fmt.Sprintf("; %d/%d API requests", c.APIRequests, c.APIBudget)
+}
+
+// markdownReason summarizes a candidate's score breakdown in one table cell.
+func markdownReason(c types.ReviewerCandidate) string {
+	if c.Breakdown == nil {
+		return c.SelectionMethod
+	}
+	sources := sortedSources(c.Breakdown)
+	parts := make([]string, 0, len(sources)+3)
+	for _, source := range sources {
+		parts = append(parts, fmt.Sprintf("%s +%d", source, c.Breakdown.Sources[source]))
+	}
+	if c.Breakdown.AvailabilityPenalty > 0 {
+		parts = append(parts, fmt.Sprintf("availability -%d (%s)", c.Breakdown.AvailabilityPenalty, c.Breakdown.Availability))
+	}
+	if c.Breakdown.WorkloadPenalty > 0 {
+		parts = append(parts, fmt.Sprintf("workload -%d (%d open PRs)", c.Breakdown.WorkloadPenalty, c.Breakdown.OpenPRs))
+	}
+	if c.Breakdown.Responsiveness != 0 {
+		parts = append(parts, fmt.Sprintf("responsiveness %+d (median first review %.1fh)", c.Breakdown.Responsiveness, c.Breakdown.MedianResponseHours))
+	}
+	return strings.Join(parts, ", ")
+}
+
+// describeEvidence formats an evidence entry's path and details. prFormat formats the PR number.
+func describeEvidence(e types.Evidence, prFormat string) string {
+	var details []string
+	if e.Lines > 0 {
+		details = append(details, fmt.Sprintf("%d lines", e.Lines))
+	}
+	if e.PR > 0 {
+		details = append(details, fmt.Sprintf(prFormat, e.PR))
+	}
+	if len(details) == 0 {
+		return e.Path
+	}
+	return e.Path + " (" + strings.Join(details, ", ") + ")"
+}
+
+// sortedSources returns the breakdown's sources by descending score, then name.
+func sortedSources(bd *types.ScoreBreakdown) []string {
+	sources := make([]string, 0, len(bd.Sources))
+	for source := range bd.Sources {
+		sources = append(sources, source)
+	}
+	sort.Slice(sources, func(i, j int) bool {
+		if bd.Sources[sources[i]] != bd.Sources[sources[j]] {
+			return bd.Sources[sources[i]] > bd.Sources[sources[j]]
+		}
+		return sources[i] < sources[j]
+	})
+	return sources
+}
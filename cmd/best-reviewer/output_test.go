@@ -0,0 +1,94 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+// TestRenderJSON pins the -output=json schema: consumers rely on these field names and on
+// arrays never being null. Adding fields means updating this test; renaming or removing
+// them also means bumping schemaVersion.
+func TestRenderJSON(t *testing.T) {
+	const want = `{
+  "collaborators": [],
+  "teams": [],
+  "candidates": [
+    {
+      "breakdown": {
+        "sources": {
+          "blame-author": 12
+        },
+        "evidence": [
+          {
+            "source": "blame-author",
+            "path": "parser.go",
+            "lines": 4,
+            "score": 12
+          }
+        ],
+        "expertise": 12,
+        "workload_penalty": 0,
+        "open_prs": 0
+      },
+      "username": "alice",
+      "selection_method": "blame-author:+12",
+      "context_score": 12
+    }
+  ],
+  "pull_request": {
+    "created_at": "2025-03-01T12:00:00Z",
+    "updated_at": "2025-03-01T13:00:00Z",
+    "owner": "owner",
+    "repo": "repo",
+    "url": "https://github.com/owner/repo/pull/2",
+    "title": "Fix the parser",
+    "author": "bob",
+    "state": "open",
+    "requested_reviewers": [],
+    "requested_teams": [],
+    "number": 2,
+    "changed_files": 1,
+    "draft": false
+  },
+  "schema_version": 1
+}
+`
+
+	var first, second bytes.Buffer
+	if err := render(&first, formatJSON, testReport(), false); err != nil {
+		t.Fatalf("render() error = %v", err)
+	}
+	if got := first.String(); got != want {
+		t.Errorf("render() JSON =\n%s\nwant\n%s", got, want)
+	}
+
+	// Output is stable across runs, so it can be diffed
+	if err := render(&second, formatJSON, testReport(), false); err != nil {
+		t.Fatalf("render() error = %v", err)
+	}
+	if first.String() != second.String() {
+		t.Error("render() output differs between runs")
+	}
+}
+
+func TestRenderTextVerbose(t *testing.T) {
+	r := testReport()
+	bd := r.Candidates[0].Breakdown
+	for range evidenceToShow {
+		bd.Evidence = append(bd.Evidence, types.Evidence{Source: "file-author", Path: "lexer.go", PR: 9})
+	}
+
+	for _, verbose := range []bool{false, true} {
+		var buf bytes.Buffer
+		if err := render(&buf, formatText, r, verbose); err != nil {
+			t.Fatalf("render() error = %v", err)
+		}
+		truncated := strings.Contains(buf.String(), "1 more (use -v to show all)")
+		if truncated == verbose {
+			t.Errorf("verbose=%v: evidence truncated = %v\n%s", verbose, truncated, buf.String())
+		}
+	}
+}
@@ -9,6 +9,7 @@ require (
 	github.com/codeGROOVE-dev/prx v0.0.0-20251109164430-90488144076d
 	github.com/codeGROOVE-dev/retry v1.3.0
 	github.com/codeGROOVE-dev/sprinkler v0.0.0-20251105232821-c5aeed50a046
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require golang.org/x/net v0.46.0 // indirect
@@ -0,0 +1,280 @@
+// Package availability determines whether reviewers can take on a review, based on
+// their GitHub user status, an optional out-of-office calendar, and how recently they
+// were active in the repository.
+package availability
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/cache"
+)
+
+// DefaultInactiveAfter is how long a reviewer can go without activity while the
+// repository stays active before they are considered to have limited availability.
+const DefaultInactiveAfter = 30 * 24 * time.Hour
+
+// statusCacheTTL is how long GitHub user statuses are cached.
+const statusCacheTTL = 30 * time.Minute
+
+// maxStatusBatch is the number of users whose status is fetched per GraphQL request.
+const maxStatusBatch = 25
+
+// Status messages and emoji that mean a user is away rather than merely busy.
+var awayKeywords = []string{
+	"out of office", "out-of-office", "ooo", "vacation", "holiday", "on leave", "parental leave", "sabbatical", "pto",
+}
+
+var awayEmoji = []string{":palm_tree:", ":desert_island:", ":airplane:", ":beach_umbrella:", "🌴", "🏝", "✈", "🏖"}
+
+// Level is how available a reviewer is.
+type Level int
+
+// Availability levels, from most to least available.
+const (
+	Available   Level = iota
+	Limited           // Penalized
+	Unavailable       // Excluded
+)
+
+// String returns the level's name.
+func (l Level) String() string {
+	switch l {
+	case Limited:
+		return "limited"
+	case Unavailable:
+		return "unavailable"
+	default:
+		return "available"
+	}
+}
+
+// Status is a reviewer's availability and why.
+type Status struct {
+	Reason string // e.g. "GitHub status: busy" or "out of office until 2024-07-14 (vacation)"
+	Level  Level
+}
+
+// GraphQLClient makes GitHub GraphQL requests.
+type GraphQLClient interface {
+	MakeGraphQLRequest(ctx context.Context, query string, variables map[string]any) (map[string]any, error)
+}
+
+// Config configures a Checker.
+type Config struct {
+	Calendar      *Calendar     // Out-of-office calendar (optional)
+	InactiveAfter time.Duration // Inactivity that counts as limited availability (0 disables the check)
+}
+
+// Checker determines reviewer availability.
+type Checker struct {
+	client        GraphQLClient
+	calendar      *Calendar
+	statuses      *cache.Cache
+	now           func() time.Time
+	inactiveAfter time.Duration
+}
+
+// New creates a Checker.
+func New(client GraphQLClient, cfg Config) *Checker {
+	return &Checker{
+		client:        client,
+		calendar:      cfg.Calendar,
+		statuses:      cache.New(statusCacheTTL),
+		now:           time.Now,
+		inactiveAfter: cfg.InactiveAfter,
+	}
+}
+
+// Check returns the availability of each user that is not fully available.
+// lastActive holds each user's latest activity in the repository; users without an
+// entry are not checked for inactivity. Inactivity is measured against the most
+// recent activity by anyone, so quiet repositories do not penalize everyone.
+// GitHub statuses that cannot be fetched are treated as available.
+func (c *Checker) Check(ctx context.Context, usernames []string, lastActive map[string]time.Time) map[string]Status {
+	result := make(map[string]Status)
+	worsen := func(username string, s Status) {
+		if s.Level > result[username].Level {
+			result[username] = s
+		}
+	}
+
+	now := c.now()
+	for _, username := range usernames {
+		if reason, away := c.calendar.Away(username, now); away {
+			worsen(username, Status{Level: Unavailable, Reason: reason})
+		}
+	}
+
+	if c.inactiveAfter > 0 {
+		var latest time.Time
+		for _, t := range lastActive {
+			if t.After(latest) {
+				latest = t
+			}
+		}
+		for _, username := range usernames {
+			t, ok := lastActive[username]
+			if !ok || t.IsZero() {
+				continue
+			}
+			if idle := latest.Sub(t); idle >= c.inactiveAfter {
+				worsen(username, Status{
+					Level:  Limited,
+					Reason: fmt.Sprintf("no activity for %d days", int(idle.Hours()/24)),
+				})
+			}
+		}
+	}
+
+	for username, s := range c.githubStatuses(ctx, usernames) {
+		worsen(username, s)
+	}
+
+	for username, s := range result {
+		slog.InfoContext(ctx, "Reviewer availability", "username", username, "level", s.Level.String(), "reason", s.Reason)
+	}
+	return result
+}
+
+// githubStatuses returns the availability implied by each user's GitHub status.
+func (c *Checker) githubStatuses(ctx context.Context, usernames []string) map[string]Status {
+	result := make(map[string]Status)
+	var uncached []string
+	for _, username := range usernames {
+		if v, ok := c.statuses.Get("status:" + username); ok {
+			if s, ok := v.(Status); ok && s.Level != Available {
+				result[username] = s
+			}
+			continue
+		}
+		uncached = append(uncached, username)
+	}
+
+	for start := 0; start < len(uncached); start += maxStatusBatch {
+		batch := uncached[start:min(start+maxStatusBatch, len(uncached))]
+		statuses, err := c.fetchStatuses(ctx, batch)
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to fetch GitHub user statuses, assuming available", "users", batch, "error", err)
+			continue
+		}
+		for _, username := range batch {
+			s := statuses[username]
+			c.statuses.Set("status:"+username, s)
+			if s.Level != Available {
+				result[username] = s
+			}
+		}
+	}
+	return result
+}
+
+// fetchStatuses fetches the GitHub status of up to maxStatusBatch users in one request.
+func (c *Checker) fetchStatuses(ctx context.Context, usernames []string) (map[string]Status, error) {
+	query, variables := statusQuery(usernames)
+	data, err := c.client.MakeGraphQLRequest(ctx, query, variables)
+	if err != nil {
+		return nil, err
+	}
+
+	root, ok := data["data"].(map[string]any)
+	if !ok {
+		return map[string]Status{}, nil
+	}
+
+	now := c.now()
+	result := make(map[string]Status, len(usernames))
+	for i, username := range usernames {
+		user, ok := root[fmt.Sprintf("u%d", i)].(map[string]any)
+		if !ok {
+			continue
+		}
+		status, ok := user["status"].(map[string]any)
+		if !ok {
+			continue
+		}
+		result[username] = parseStatus(status, now)
+	}
+	return result, nil
+}
+
+// statusQuery builds a GraphQL query fetching the status of each user under aliases u0, u1, ...
+func statusQuery(usernames []string) (string, map[string]any) {
+	var params, fields strings.Builder
+	variables := make(map[string]any, len(usernames))
+	for i, username := range usernames {
+		if i > 0 {
+			params.WriteString(", ")
+		}
+		fmt.Fprintf(&params, "$u%d: String!", i)
+		fmt.Fprintf(&fields, "\tu%d: user(login: $u%d) { status { indicatesLimitedAvailability message emoji expiresAt } }\n", i, i)
+		variables[fmt.Sprintf("u%d", i)] = username
+	}
+	return fmt.Sprintf("query UserStatuses(%s) {\n%s}", params.String(), fields.String()), variables
+}
+
+// parseStatus interprets a GitHub user status. A status that reads as out of office makes
+// the user unavailable; the "busy" setting (indicatesLimitedAvailability) limits them.
+func parseStatus(status map[string]any, now time.Time) Status {
+	if expires, ok := status["expiresAt"].(string); ok && expires != "" {
+		if t, err := time.Parse(time.RFC3339, expires); err == nil && t.Before(now) {
+			return Status{}
+		}
+	}
+
+	message, _ := status["message"].(string)
+	emoji, _ := status["emoji"].(string)
+	describe := func(prefix string) string {
+		if message == "" {
+			return prefix
+		}
+		return fmt.Sprintf("%s (%s)", prefix, message)
+	}
+
+	if isAway(message, emoji) {
+		return Status{Level: Unavailable, Reason: describe("GitHub status: away")}
+	}
+	if busy, ok := status["indicatesLimitedAvailability"].(bool); ok && busy {
+		return Status{Level: Limited, Reason: describe("GitHub status: busy")}
+	}
+	return Status{}
+}
+
+// isAway reports whether a status message or emoji says the user is out of office.
+func isAway(message, emoji string) bool {
+	lower := " " + strings.ToLower(message) + " "
+	for _, keyword := range awayKeywords {
+		if wordBoundary(lower, keyword) {
+			return true
+		}
+	}
+	for _, e := range awayEmoji {
+		if strings.Contains(emoji, e) || strings.Contains(message, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// wordBoundary reports whether keyword appears in s as a whole word, so "ooo" does not match "cooool".
+func wordBoundary(s, keyword string) bool {
+	for i := strings.Index(s, keyword); i >= 0; {
+		end := i + len(keyword)
+		if !isLetter(s[i-1]) && (end >= len(s) || !isLetter(s[end])) {
+			return true
+		}
+		next := strings.Index(s[i+1:], keyword)
+		if next < 0 {
+			return false
+		}
+		i += next + 1
+	}
+	return false
+}
+
+func isLetter(b byte) bool {
+	return (b >= 'a' && b <= 'z') || (b >= 'A' && b <= 'Z')
+}
@@ -0,0 +1,85 @@
+package availability
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/internal/testutil"
+)
+
+func TestParseStatus(t *testing.T) {
+	now := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		status map[string]any
+		want   Level
+	}{
+		{name: "empty", status: map[string]any{}, want: Available},
+		{name: "busy", status: map[string]any{"indicatesLimitedAvailability": true, "message": "Heads down"}, want: Limited},
+		{name: "vacation message", status: map[string]any{"message": "On vacation until Monday"}, want: Unavailable},
+		{name: "ooo word", status: map[string]any{"message": "OOO"}, want: Unavailable},
+		{name: "ooo inside word", status: map[string]any{"message": "cooool project"}, want: Available},
+		{name: "palm tree", status: map[string]any{"emoji": ":palm_tree:"}, want: Unavailable},
+		{name: "expired", status: map[string]any{"message": "vacation", "expiresAt": "2024-06-30T00:00:00Z"}, want: Available},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseStatus(tt.status, now); got.Level != tt.want {
+				t.Errorf("parseStatus() = %v (%q), want %v", got.Level, got.Reason, tt.want)
+			}
+		})
+	}
+}
+
+func TestChecker_Check(t *testing.T) {
+	now := time.Date(2024, 7, 5, 0, 0, 0, 0, time.UTC)
+	client := testutil.NewMockGitHubClient()
+	users := []string{"alice", "bob", "carol", "dave"}
+	query, _ := statusQuery(users)
+	client.SetGraphQLResponse(query, map[string]any{
+		"data": map[string]any{
+			"u0": map[string]any{"status": nil},
+			"u1": map[string]any{"status": map[string]any{"indicatesLimitedAvailability": true}},
+			"u2": map[string]any{"status": nil},
+			"u3": map[string]any{"status": map[string]any{"indicatesLimitedAvailability": true}},
+		},
+	})
+	cal, err := ParseYAML([]byte("- user: dave\n  start: 2024-07-01\n  end: 2024-07-10\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	checker := New(client, Config{Calendar: cal, InactiveAfter: 30 * 24 * time.Hour})
+	checker.now = func() time.Time { return now }
+
+	statuses := checker.Check(context.Background(), users, map[string]time.Time{
+		"alice": now.AddDate(0, 0, -1),
+		"carol": now.AddDate(0, 0, -60),
+	})
+
+	if _, ok := statuses["alice"]; ok {
+		t.Errorf("expected alice to be available, got %+v", statuses["alice"])
+	}
+	if statuses["bob"].Level != Limited || statuses["bob"].Reason != "GitHub status: busy" {
+		t.Errorf("expected bob limited by GitHub status, got %+v", statuses["bob"])
+	}
+	if statuses["carol"].Level != Limited || statuses["carol"].Reason != "no activity for 59 days" {
+		t.Errorf("expected carol limited by inactivity, got %+v", statuses["carol"])
+	}
+	if statuses["dave"].Level != Unavailable {
+		t.Errorf("expected calendar absence to outrank busy status, got %+v", statuses["dave"])
+	}
+}
+
+func TestChecker_Check_StatusError(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	query, _ := statusQuery([]string{"alice"})
+	client.SetError("MakeGraphQLRequest:"+query, errors.New("boom"))
+
+	statuses := New(client, Config{}).Check(context.Background(), []string{"alice"}, nil)
+	if len(statuses) != 0 {
+		t.Errorf("expected failed status lookups to be treated as available, got %+v", statuses)
+	}
+}
@@ -0,0 +1,209 @@
+package availability
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Calendar holds out-of-office periods for reviewers.
+type Calendar struct {
+	periods map[string][]Period // Lowercased username -> periods
+}
+
+// Period is a span of time a reviewer is away. End is exclusive.
+type Period struct {
+	Start  time.Time
+	End    time.Time
+	Reason string
+}
+
+// yamlPeriod is a calendar entry in the YAML format. Dates are inclusive.
+type yamlPeriod struct {
+	User   string `yaml:"user"`
+	Start  string `yaml:"start"`
+	End    string `yaml:"end"`
+	Reason string `yaml:"reason"`
+}
+
+// LoadCalendar reads an out-of-office calendar from an iCalendar (.ics) or YAML file.
+//
+// The YAML format is a list of entries with inclusive dates:
+//
+//   - user: alice
+//     start: 2024-07-01
+//     end: 2024-07-14
+//     reason: vacation
+//
+// In iCalendar files each VEVENT is an absence. The user is taken from an
+// X-GITHUB-LOGIN property if present, otherwise from the first word of the
+// SUMMARY; the rest of the summary is the reason, e.g. "alice: vacation".
+func LoadCalendar(path string) (*Calendar, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read OOO calendar: %w", err)
+	}
+	if strings.EqualFold(filepath.Ext(path), ".ics") || bytes.HasPrefix(bytes.TrimSpace(data), []byte("BEGIN:VCALENDAR")) {
+		return ParseICS(data)
+	}
+	return ParseYAML(data)
+}
+
+// ParseYAML parses a calendar in the YAML format described in LoadCalendar.
+func ParseYAML(data []byte) (*Calendar, error) {
+	var entries []yamlPeriod
+	dec := yaml.NewDecoder(bytes.NewReader(data))
+	dec.KnownFields(true)
+	if err := dec.Decode(&entries); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse OOO calendar: %w", err)
+	}
+
+	cal := &Calendar{periods: make(map[string][]Period)}
+	for i, e := range entries {
+		if strings.TrimSpace(e.User) == "" {
+			return nil, fmt.Errorf("OOO calendar entry %d: user is required", i+1)
+		}
+		start, err := time.Parse(time.DateOnly, e.Start)
+		if err != nil {
+			return nil, fmt.Errorf("OOO calendar entry %d: invalid start: %w", i+1, err)
+		}
+		end := start
+		if e.End != "" {
+			if end, err = time.Parse(time.DateOnly, e.End); err != nil {
+				return nil, fmt.Errorf("OOO calendar entry %d: invalid end: %w", i+1, err)
+			}
+		}
+		if end.Before(start) {
+			return nil, fmt.Errorf("OOO calendar entry %d: end is before start", i+1)
+		}
+		cal.add(e.User, Period{Start: start, End: end.AddDate(0, 0, 1), Reason: e.Reason})
+	}
+	return cal, nil
+}
+
+// ParseICS parses VEVENTs from an iCalendar file as described in LoadCalendar.
+// Recurring events are not expanded.
+func ParseICS(data []byte) (*Calendar, error) {
+	cal := &Calendar{periods: make(map[string][]Period)}
+
+	var event map[string]string
+	for _, line := range unfoldICS(data) {
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			continue
+		}
+		// Drop parameters such as DTSTART;VALUE=DATE or DTSTART;TZID=...
+		prop, _, _ := strings.Cut(strings.ToUpper(name), ";")
+
+		switch {
+		case prop == "BEGIN" && value == "VEVENT":
+			event = make(map[string]string)
+		case prop == "END" && value == "VEVENT":
+			if event == nil {
+				continue
+			}
+			if err := cal.addICSEvent(event); err != nil {
+				return nil, err
+			}
+			event = nil
+		case event != nil:
+			event[prop] = value
+		}
+	}
+	return cal, nil
+}
+
+// addICSEvent adds a parsed VEVENT's properties to the calendar.
+func (c *Calendar) addICSEvent(event map[string]string) error {
+	summary := strings.TrimSpace(unescapeICS(event["SUMMARY"]))
+	user := strings.TrimSpace(event["X-GITHUB-LOGIN"])
+	reason := summary
+	if user == "" {
+		var rest string
+		user, rest, _ = strings.Cut(summary, " ")
+		user = strings.TrimSuffix(user, ":")
+		reason = strings.TrimSpace(rest)
+	}
+	if strings.TrimPrefix(user, "@") == "" {
+		return nil // Not attributable to anyone
+	}
+
+	start, allDay, err := parseICSTime(event["DTSTART"])
+	if err != nil {
+		return fmt.Errorf("OOO calendar event %q: invalid DTSTART: %w", summary, err)
+	}
+	end := start.Add(time.Hour)
+	if allDay {
+		end = start.AddDate(0, 0, 1)
+	}
+	if raw := event["DTEND"]; raw != "" {
+		if end, _, err = parseICSTime(raw); err != nil {
+			return fmt.Errorf("OOO calendar event %q: invalid DTEND: %w", summary, err)
+		}
+	}
+	c.add(user, Period{Start: start, End: end, Reason: reason})
+	return nil
+}
+
+// parseICSTime parses an iCalendar DATE or DATE-TIME value. Floating times are taken as UTC.
+func parseICSTime(value string) (t time.Time, allDay bool, err error) {
+	value = strings.TrimSpace(value)
+	if len(value) == len("20060102") {
+		t, err = time.Parse("20060102", value)
+		return t, true, err
+	}
+	t, err = time.Parse("20060102T150405", strings.TrimSuffix(value, "Z"))
+	return t, false, err
+}
+
+// unfoldICS splits iCalendar data into logical lines, joining continuation lines.
+func unfoldICS(data []byte) []string {
+	var lines []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	return lines
+}
+
+// unescapeICS reverses iCalendar text escaping.
+func unescapeICS(s string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(s)
+}
+
+// add records an absence. Users may be written with a leading "@".
+func (c *Calendar) add(user string, p Period) {
+	key := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(user), "@"))
+	c.periods[key] = append(c.periods[key], p)
+}
+
+// Away reports whether username is out of office at t, with a description of the absence.
+func (c *Calendar) Away(username string, t time.Time) (string, bool) {
+	if c == nil {
+		return "", false
+	}
+	for _, p := range c.periods[strings.ToLower(username)] {
+		if t.Before(p.Start) || !t.Before(p.End) {
+			continue
+		}
+		reason := "out of office until " + p.End.Add(-time.Second).Format(time.DateOnly)
+		if p.Reason != "" {
+			reason += " (" + p.Reason + ")"
+		}
+		return reason, true
+	}
+	return "", false
+}
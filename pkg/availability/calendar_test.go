@@ -0,0 +1,125 @@
+package availability
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseYAML(t *testing.T) {
+	cal, err := ParseYAML([]byte(`
+- user: alice
+  start: 2024-07-01
+  end: 2024-07-14
+  reason: vacation
+- user: "@Bob"
+  start: 2024-07-03
+`))
+	if err != nil {
+		t.Fatalf("ParseYAML() error = %v", err)
+	}
+
+	tests := []struct {
+		user   string
+		at     string
+		want   bool
+		reason string
+	}{
+		{user: "alice", at: "2024-07-01T00:00:00Z", want: true, reason: "out of office until 2024-07-14 (vacation)"},
+		{user: "ALICE", at: "2024-07-14T23:59:00Z", want: true},
+		{user: "alice", at: "2024-07-15T00:00:00Z", want: false},
+		{user: "alice", at: "2024-06-30T23:59:00Z", want: false},
+		{user: "bob", at: "2024-07-03T12:00:00Z", want: true, reason: "out of office until 2024-07-03"},
+		{user: "carol", at: "2024-07-03T12:00:00Z", want: false},
+	}
+	for _, tt := range tests {
+		at, err := time.Parse(time.RFC3339, tt.at)
+		if err != nil {
+			t.Fatal(err)
+		}
+		reason, away := cal.Away(tt.user, at)
+		if away != tt.want {
+			t.Errorf("Away(%q, %s) = %v, want %v", tt.user, tt.at, away, tt.want)
+		}
+		if tt.reason != "" && reason != tt.reason {
+			t.Errorf("Away(%q, %s) reason = %q, want %q", tt.user, tt.at, reason, tt.reason)
+		}
+	}
+}
+
+func TestParseYAML_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "missing user", content: "- start: 2024-07-01\n", wantErr: "user is required"},
+		{name: "bad start", content: "- user: a\n  start: July\n", wantErr: "invalid start"},
+		{name: "end before start", content: "- user: a\n  start: 2024-07-02\n  end: 2024-07-01\n", wantErr: "end is before start"},
+		{name: "unknown key", content: "- user: a\n  start: 2024-07-01\n  until: 2024-07-02\n", wantErr: "field until not found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := ParseYAML([]byte(tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("ParseYAML() error = %v, want containing %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseICS(t *testing.T) {
+	ics := "BEGIN:VCALENDAR\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:alice: summer\r\n" +
+		"  vacation\r\n" +
+		"DTSTART;VALUE=DATE:20240701\r\n" +
+		"DTEND;VALUE=DATE:20240708\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"SUMMARY:Conference\r\n" +
+		"X-GITHUB-LOGIN:bob\r\n" +
+		"DTSTART:20240710T090000Z\r\n" +
+		"DTEND:20240712T170000Z\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	cal, err := ParseICS([]byte(ics))
+	if err != nil {
+		t.Fatalf("ParseICS() error = %v", err)
+	}
+
+	reason, away := cal.Away("alice", time.Date(2024, 7, 7, 12, 0, 0, 0, time.UTC))
+	if !away || reason != "out of office until 2024-07-07 (summer vacation)" {
+		t.Errorf("expected alice away with folded summary, got %v %q", away, reason)
+	}
+	if _, away := cal.Away("alice", time.Date(2024, 7, 8, 0, 0, 0, 0, time.UTC)); away {
+		t.Error("expected DTEND to be exclusive")
+	}
+	if reason, away := cal.Away("bob", time.Date(2024, 7, 11, 0, 0, 0, 0, time.UTC)); !away || !strings.Contains(reason, "Conference") {
+		t.Errorf("expected bob away for conference, got %v %q", away, reason)
+	}
+}
+
+func TestLoadCalendar(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "ooo.ics")
+	content := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:alice\nDTSTART:20240701\nEND:VEVENT\nEND:VCALENDAR\n"
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	cal, err := LoadCalendar(path)
+	if err != nil {
+		t.Fatalf("LoadCalendar() error = %v", err)
+	}
+	if _, away := cal.Away("alice", time.Date(2024, 7, 1, 8, 0, 0, 0, time.UTC)); !away {
+		t.Error("expected all-day event without DTEND to cover the day")
+	}
+
+	if _, err := LoadCalendar(filepath.Join(dir, "missing.yml")); err == nil {
+		t.Error("expected error for missing file")
+	}
+}
@@ -32,6 +32,9 @@ const (
 	// TTLCollaborators is for repo collaborator lists (changes occasionally).
 	TTLCollaborators = 6 * time.Hour
 
+	// TTLRepoConfig is for repository configuration files (edits should take effect promptly).
+	TTLRepoConfig = 1 * time.Hour
+
 	// TTLRecentActivity is for recent merged PRs, directory activity (changes daily).
 	TTLRecentActivity = 4 * time.Hour
 
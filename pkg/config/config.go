@@ -0,0 +1,467 @@
+// Package config loads per-repository best-reviewer settings from .github/best-reviewer.yml.
+package config
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/cache"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+	"gopkg.in/yaml.v3"
+)
+
+// Configuration file locations.
+const (
+	// RepoPath is the config file location within a repository.
+	RepoPath = ".github/best-reviewer.yml"
+	// OrgRepo is the organization-wide repository consulted when a repository has no config file.
+	OrgRepo = ".github"
+	// OrgPath is the config file location within the organization's .github repository.
+	OrgPath = "best-reviewer.yml"
+	// OptOutPath is the self-service opt-out list within the organization's .github repository.
+	OptOutPath = "best-reviewer-optout.yml"
+)
+
+// Reviewer selection modes.
+const (
+	// SelectionScore requests the top reviewers by total score.
+	SelectionScore = "score"
+	// SelectionCoverage requests the reviewers who together know the most changed files.
+	SelectionCoverage = "coverage"
+)
+
+// Rule actions.
+const (
+	// RuleSkip leaves matching PRs alone.
+	RuleSkip = "skip"
+	// RuleRoute requests the rule's reviewers and teams instead of searching for reviewers.
+	RuleRoute = "route"
+)
+
+// Validation limits.
+const (
+	maxReviewers = 10  // Upper bound on reviewers requested per PR
+	maxFiles     = 100 // Upper bound on files analyzed per PR
+	maxAPIBudget = 50  // Upper bound on GraphQL requests spent on history per PR
+)
+
+// Config holds the settings for a single repository.
+type Config struct {
+	Weights       Weights  `yaml:"weights"`
+	Source        string   `yaml:"-"` // Where the config was loaded from ("" for defaults)
+	ExcludeUsers  []string `yaml:"exclude_users"`
+	IgnorePaths   []string `yaml:"ignore_paths"` // Gitignore-style globs excluded from file analysis
+	Teams         []string `yaml:"teams"`        // Team slugs to request when CODEOWNERS names no team
+	Rules         []Rule   `yaml:"rules"`        // Skip and route rules; the first matching rule applies
+	Wait          Wait     `yaml:"wait"`
+	Selection     string   `yaml:"selection"`      // How reviewers are picked: SelectionScore or SelectionCoverage
+	Comment       bool     `yaml:"comment"`        // Post a PR comment explaining the requested reviewers
+	Reviewers     int      `yaml:"reviewers"`      // Number of individual reviewers to request
+	TeamReviewers int      `yaml:"team_reviewers"` // Number of teams to request (0 disables team requests)
+	MaxFiles      int      `yaml:"max_files"`      // Number of changed files to analyze
+	APIBudget     int      `yaml:"api_budget"`     // GraphQL requests to spend on file and directory history
+
+	excluded map[string]string // Lowercased username -> reason, for org-wide exclusions and opt-outs
+}
+
+// Wait holds how long to wait before assigning reviewers, depending on CI state.
+type Wait struct {
+	Min     time.Duration `yaml:"min"`     // Minimum time since last update
+	Pending time.Duration `yaml:"pending"` // Grace period while tests are pending
+	Failing time.Duration `yaml:"failing"` // Grace period while tests are failing
+}
+
+// Rule skips matching PRs or routes them to fixed reviewers. Each condition lists
+// alternatives, any of which matches; a rule matches when all its conditions do.
+type Rule struct {
+	Action        string   `yaml:"action"`         // RuleSkip or RuleRoute
+	Labels        []string `yaml:"labels"`         // Label names, case-insensitive
+	TitlePrefixes []string `yaml:"title_prefixes"` // Title prefixes, case-insensitive
+	Authors       []string `yaml:"authors"`        // Author logins; "dependabot" also matches "dependabot[bot]"
+	BaseBranches  []string `yaml:"base_branches"`  // Base branch names or globs such as "release-*"
+	Reviewers     []string `yaml:"reviewers"`      // Users to request (route only)
+	Teams         []string `yaml:"teams"`          // Team slugs to request (route only)
+}
+
+// Weights holds scoring weight overrides. Nil fields keep the built-in default.
+type Weights struct {
+	Assignee              *int `yaml:"assignee"`
+	Codeowner             *int `yaml:"codeowner"`
+	BlameLine             *int `yaml:"blame_line"`
+	MergerMultiplier      *int `yaml:"merger_multiplier"`
+	File                  *int `yaml:"file"`
+	Directory             *int `yaml:"directory"`
+	RecentActivityDivisor *int `yaml:"recent_activity_divisor"`
+	WorkloadPerPR         *int `yaml:"workload_per_pr"`
+	WorkloadMaxPercent    *int `yaml:"workload_max_percent"`
+	LimitedAvailability   *int `yaml:"limited_availability_percent"`
+	HalfLifeDays          *int `yaml:"half_life_days"`
+	AuthorAffinity        *int `yaml:"author_affinity"`
+	Responsiveness        *int `yaml:"responsiveness_percent"`
+}
+
+// WeightField is a scoring weight override keyed by its external name.
+type WeightField struct {
+	Value *int // Nil keeps the built-in default
+	Name  string
+}
+
+// Fields returns the weight overrides in a stable order, keyed by the names used in
+// config files and the -weights flag.
+func (w *Weights) Fields() []WeightField {
+	return []WeightField{
+		{w.Assignee, "assignee"},
+		{w.Codeowner, "codeowner"},
+		{w.BlameLine, "blame_line"},
+		{w.MergerMultiplier, "merger_multiplier"},
+		{w.File, "file"},
+		{w.Directory, "directory"},
+		{w.RecentActivityDivisor, "recent_activity_divisor"},
+		{w.WorkloadPerPR, "workload_per_pr"},
+		{w.WorkloadMaxPercent, "workload_max_percent"},
+		{w.LimitedAvailability, "limited_availability_percent"},
+		{w.HalfLifeDays, "half_life_days"},
+		{w.AuthorAffinity, "author_affinity"},
+		{w.Responsiveness, "responsiveness_percent"},
+	}
+}
+
+// weightLimits holds the weights with a narrower range than any non-negative value.
+var weightLimits = map[string]struct {
+	positive bool // Zero is not allowed
+	maximum  int  // 0 means unbounded
+}{
+	"recent_activity_divisor":      {positive: true},
+	"workload_max_percent":         {maximum: 100},
+	"limited_availability_percent": {maximum: 100},
+	"responsiveness_percent":       {maximum: 100},
+}
+
+// ValidateWeight checks a scoring weight's value against its valid range. The error
+// describes the problem without naming the weight, so callers can prefix it.
+func ValidateWeight(name string, value int) error {
+	limit := weightLimits[name]
+	switch {
+	case value < 0:
+		return errors.New("cannot be negative")
+	case value == 0 && limit.positive:
+		return errors.New("must be positive")
+	case limit.maximum > 0 && value > limit.maximum:
+		return fmt.Errorf("cannot exceed %d", limit.maximum)
+	}
+	return nil
+}
+
+// Default returns the settings used when no config file exists.
+func Default() *Config {
+	return &Config{
+		Reviewers: 2,
+		MaxFiles:  maxFiles,
+		APIBudget: 10,
+		Selection: SelectionScore,
+		Rules: []Rule{
+			{Action: RuleSkip, Labels: []string{"do-not-review", "wip"}},
+			{Action: RuleSkip, TitlePrefixes: []string{"WIP:", "[DNM]"}},
+		},
+		Wait: Wait{
+			Min:     2 * time.Minute,
+			Pending: 20 * time.Minute,
+			Failing: 90 * time.Minute,
+		},
+	}
+}
+
+// Parse parses and validates config file content, filling unset fields with defaults.
+func Parse(content []byte) (*Config, error) {
+	cfg := Default()
+
+	dec := yaml.NewDecoder(bytes.NewReader(content))
+	dec.KnownFields(true)
+	if err := dec.Decode(cfg); err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+
+	if err := cfg.Validate(); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// Validate checks that all settings are within acceptable ranges.
+func (c *Config) Validate() error {
+	var errs []error
+	if c.Reviewers < 1 || c.Reviewers > maxReviewers {
+		errs = append(errs, fmt.Errorf("reviewers must be between 1 and %d, got %d", maxReviewers, c.Reviewers))
+	}
+	if c.TeamReviewers < 0 || c.TeamReviewers > maxReviewers {
+		errs = append(errs, fmt.Errorf("team_reviewers must be between 0 and %d, got %d", maxReviewers, c.TeamReviewers))
+	}
+	if c.MaxFiles < 1 || c.MaxFiles > maxFiles {
+		errs = append(errs, fmt.Errorf("max_files must be between 1 and %d, got %d", maxFiles, c.MaxFiles))
+	}
+	if c.APIBudget < 1 || c.APIBudget > maxAPIBudget {
+		errs = append(errs, fmt.Errorf("api_budget must be between 1 and %d, got %d", maxAPIBudget, c.APIBudget))
+	}
+	if c.Selection != SelectionScore && c.Selection != SelectionCoverage {
+		errs = append(errs, fmt.Errorf("selection must be %q or %q, got %q", SelectionScore, SelectionCoverage, c.Selection))
+	}
+	if c.Wait.Min < 0 || c.Wait.Pending < 0 || c.Wait.Failing < 0 {
+		errs = append(errs, errors.New("wait periods cannot be negative"))
+	}
+	for _, user := range c.ExcludeUsers {
+		if strings.TrimSpace(user) == "" {
+			errs = append(errs, errors.New("exclude_users cannot contain empty entries"))
+			break
+		}
+	}
+	for _, team := range c.Teams {
+		if strings.TrimSpace(team) == "" {
+			errs = append(errs, errors.New("teams cannot contain empty entries"))
+			break
+		}
+	}
+	for _, pattern := range c.IgnorePaths {
+		if strings.TrimSpace(pattern) == "" {
+			errs = append(errs, errors.New("ignore_paths cannot contain empty patterns"))
+			break
+		}
+	}
+	for i, rule := range c.Rules {
+		if err := rule.validate(); err != nil {
+			errs = append(errs, fmt.Errorf("rules[%d]: %w", i, err))
+		}
+	}
+	for _, f := range c.Weights.Fields() {
+		if f.Value == nil {
+			continue
+		}
+		if err := ValidateWeight(f.Name, *f.Value); err != nil {
+			errs = append(errs, fmt.Errorf("weights.%s %w", f.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// validate checks that a rule has a known action, at least one condition and valid values.
+func (r *Rule) validate() error {
+	var errs []error
+	switch r.Action {
+	case RuleSkip:
+		if len(r.Reviewers) > 0 || len(r.Teams) > 0 {
+			errs = append(errs, errors.New("reviewers and teams only apply to route rules"))
+		}
+	case RuleRoute:
+		if len(r.Reviewers) == 0 && len(r.Teams) == 0 {
+			errs = append(errs, errors.New("route rules need reviewers or teams"))
+		}
+	default:
+		errs = append(errs, fmt.Errorf("action must be %q or %q, got %q", RuleSkip, RuleRoute, r.Action))
+	}
+	if len(r.Labels)+len(r.TitlePrefixes)+len(r.Authors)+len(r.BaseBranches) == 0 {
+		errs = append(errs, errors.New("at least one of labels, title_prefixes, authors or base_branches is required"))
+	}
+	for name, values := range map[string][]string{
+		"labels":         r.Labels,
+		"title_prefixes": r.TitlePrefixes,
+		"authors":        r.Authors,
+		"base_branches":  r.BaseBranches,
+		"reviewers":      r.Reviewers,
+		"teams":          r.Teams,
+	} {
+		if slices.ContainsFunc(values, func(v string) bool { return strings.TrimSpace(v) == "" }) {
+			errs = append(errs, fmt.Errorf("%s cannot contain empty entries", name))
+		}
+	}
+	for _, pattern := range r.BaseBranches {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, fmt.Errorf("invalid base branch pattern %q", pattern))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// IsExcluded reports whether a user is excluded from review assignment.
+func (c *Config) IsExcluded(username string) bool {
+	return c.ExclusionReason(username) != ""
+}
+
+// ExclusionReason returns why a user is excluded from review assignment, or "" if they are not.
+func (c *Config) ExclusionReason(username string) string {
+	for _, u := range c.ExcludeUsers {
+		if strings.EqualFold(strings.TrimPrefix(u, "@"), username) {
+			if c.Source == "" {
+				return "excluded by config"
+			}
+			return "excluded by " + c.Source
+		}
+	}
+	return c.excluded[strings.ToLower(username)]
+}
+
+// Exclude excludes a user from review assignment in addition to ExcludeUsers.
+// The first reason given for a user is kept.
+func (c *Config) Exclude(username, reason string) {
+	key := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(username), "@"))
+	if c.excluded == nil {
+		c.excluded = make(map[string]string)
+	}
+	if _, ok := c.excluded[key]; !ok {
+		c.excluded[key] = reason
+	}
+}
+
+// ParseOptOut parses an opt-out file: a YAML list of GitHub logins that never want review requests.
+func ParseOptOut(content []byte) ([]string, error) {
+	var users []string
+	if err := yaml.Unmarshal(content, &users); err != nil {
+		return nil, fmt.Errorf("failed to parse: %w", err)
+	}
+	for _, user := range users {
+		if strings.TrimSpace(user) == "" {
+			return nil, errors.New("opt-out list cannot contain empty entries")
+		}
+	}
+	return users, nil
+}
+
+// Loader fetches repository configs via the GitHub contents API.
+type Loader struct {
+	client github.API
+	cache  *cache.DiskCache
+}
+
+// NewLoader creates a Loader. Fetched config files are cached in c.
+func NewLoader(client github.API, c *cache.DiskCache) *Loader {
+	return &Loader{client: client, cache: c}
+}
+
+// Load returns the config for a repository, falling back to the organization's .github
+// repository and then to defaults. An invalid config file is returned as an error.
+//
+// The organization config's exclude_users apply to every repository, even those with
+// their own config file, as does the organization's opt-out list (OptOutPath).
+func (l *Loader) Load(ctx context.Context, owner, repo string) (*Config, error) {
+	source, content, err := l.fetch(ctx, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := Default()
+	if source != "" {
+		if cfg, err = Parse([]byte(content)); err != nil {
+			return nil, fmt.Errorf("invalid config %s: %w", source, err)
+		}
+		cfg.Source = source
+	}
+
+	if err := l.addOrgExclusions(ctx, owner, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// addOrgExclusions excludes the users listed in the organization config, when the
+// repository has its own, and those who opted out in the organization's opt-out list.
+func (l *Loader) addOrgExclusions(ctx context.Context, owner string, cfg *Config) error {
+	orgSource := owner + "/" + OrgRepo + "/" + OrgPath
+	if cfg.Source != orgSource {
+		content, err := l.orgFile(ctx, owner, OrgPath)
+		if err != nil {
+			return err
+		}
+		// Only exclude_users matters here, so other problems in the org config don't block repositories with their own
+		var orgCfg struct {
+			ExcludeUsers []string `yaml:"exclude_users"`
+		}
+		if err := yaml.Unmarshal([]byte(content), &orgCfg); err != nil {
+			return fmt.Errorf("invalid config %s: failed to parse: %w", orgSource, err)
+		}
+		for _, user := range orgCfg.ExcludeUsers {
+			if strings.TrimSpace(user) != "" {
+				cfg.Exclude(user, "excluded by "+orgSource)
+			}
+		}
+	}
+
+	optOutSource := owner + "/" + OrgRepo + "/" + OptOutPath
+	content, err := l.orgFile(ctx, owner, OptOutPath)
+	if err != nil {
+		return err
+	}
+	users, err := ParseOptOut([]byte(content))
+	if err != nil {
+		return fmt.Errorf("invalid opt-out list %s: %w", optOutSource, err)
+	}
+	for _, user := range users {
+		cfg.Exclude(user, "opted out in "+optOutSource)
+	}
+	return nil
+}
+
+// orgFile returns the content of a file in the organization's .github repository,
+// or "" if it does not exist.
+func (l *Loader) orgFile(ctx context.Context, owner, path string) (string, error) {
+	cacheKey := "org-file:" + owner + "/" + path
+	if cached, found := l.cache.Get(cacheKey); found {
+		if content, ok := cached.(string); ok {
+			return content, nil
+		}
+	}
+
+	content, err := l.client.FileContent(ctx, owner, OrgRepo, path, "")
+	if errors.Is(err, github.ErrNotFound) {
+		content, err = "", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s/%s/%s: %w", owner, OrgRepo, path, err)
+	}
+
+	l.cache.SetWithTTL(cacheKey, content, cache.TTLRepoConfig)
+	return content, nil
+}
+
+// fetch returns the location and raw content of the config file that applies to a repository.
+// Returns an empty source if no config file exists.
+func (l *Loader) fetch(ctx context.Context, owner, repo string) (source, content string, err error) {
+	sourceKey := "repo-config-source:" + owner + "/" + repo
+	contentKey := "repo-config-content:" + owner + "/" + repo
+	if cachedSource, found := l.cache.Get(sourceKey); found {
+		if cachedContent, found := l.cache.Get(contentKey); found {
+			s, sOK := cachedSource.(string)
+			c, cOK := cachedContent.(string)
+			if sOK && cOK {
+				return s, c, nil
+			}
+		}
+	}
+
+	locations := [][3]string{{owner, repo, RepoPath}}
+	if repo != OrgRepo {
+		locations = append(locations, [3]string{owner, OrgRepo, OrgPath})
+	}
+
+	for _, loc := range locations {
+		content, err = l.client.FileContent(ctx, loc[0], loc[1], loc[2], "")
+		if errors.Is(err, github.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return "", "", fmt.Errorf("failed to fetch config: %w", err)
+		}
+		source = loc[0] + "/" + loc[1] + "/" + loc[2]
+		slog.InfoContext(ctx, "Loaded reviewer config", "owner", owner, "repo", repo, "source", source)
+		break
+	}
+
+	l.cache.SetWithTTL(sourceKey, source, cache.TTLRepoConfig)
+	l.cache.SetWithTTL(contentKey, content, cache.TTLRepoConfig)
+	return source, content, nil
+}
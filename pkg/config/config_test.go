@@ -0,0 +1,255 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/cache"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/internal/testutil"
+)
+
+func newTestLoader(client *testutil.MockGitHubClient) *Loader {
+	return NewLoader(client, &cache.DiskCache{Cache: cache.New(time.Hour)})
+}
+
+func TestParse(t *testing.T) {
+	cfg, err := Parse([]byte(`
+reviewers: 3
+max_files: 5
+exclude_users: [alice, "@bob"]
+ignore_paths:
+  - "vendor/"
+  - "*.pb.go"
+wait:
+  pending: 10m
+weights:
+  codeowner: 25
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if cfg.Reviewers != 3 || cfg.MaxFiles != 5 {
+		t.Errorf("expected reviewers=3 max_files=5, got %d/%d", cfg.Reviewers, cfg.MaxFiles)
+	}
+	if cfg.Wait.Pending != 10*time.Minute {
+		t.Errorf("expected pending wait 10m, got %v", cfg.Wait.Pending)
+	}
+	if cfg.Wait.Failing != 90*time.Minute || cfg.Wait.Min != 2*time.Minute {
+		t.Errorf("expected unset waits to keep defaults, got %+v", cfg.Wait)
+	}
+	if cfg.Weights.Codeowner == nil || *cfg.Weights.Codeowner != 25 {
+		t.Errorf("expected codeowner weight 25, got %v", cfg.Weights.Codeowner)
+	}
+	if cfg.Weights.Assignee != nil {
+		t.Errorf("expected unset weight to be nil, got %v", *cfg.Weights.Assignee)
+	}
+	if !cfg.IsExcluded("Alice") || !cfg.IsExcluded("bob") || cfg.IsExcluded("carol") {
+		t.Errorf("unexpected exclusion results for %v", cfg.ExcludeUsers)
+	}
+}
+
+func TestParse_Empty(t *testing.T) {
+	cfg, err := Parse(nil)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if cfg.Reviewers != Default().Reviewers {
+		t.Errorf("expected default reviewers, got %d", cfg.Reviewers)
+	}
+}
+
+func TestParse_Rules(t *testing.T) {
+	if rules := Default().Rules; len(rules) != 2 || rules[0].Labels[0] != "do-not-review" || rules[1].TitlePrefixes[0] != "WIP:" {
+		t.Errorf("unexpected default rules %+v", rules)
+	}
+
+	cfg, err := Parse([]byte(`
+rules:
+  - action: route
+    authors: [dependabot, renovate]
+    teams: [deps]
+`))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(cfg.Rules) != 1 || cfg.Rules[0].Action != RuleRoute || cfg.Rules[0].Teams[0] != "deps" {
+		t.Errorf("expected configured rules to replace the defaults, got %+v", cfg.Rules)
+	}
+}
+
+func TestParse_Invalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{name: "unknown key", content: "reviewer: 2\n", wantErr: "field reviewer not found"},
+		{name: "malformed yaml", content: "reviewers: [\n", wantErr: "failed to parse"},
+		{name: "too many reviewers", content: "reviewers: 50\n", wantErr: "reviewers must be between"},
+		{name: "zero reviewers", content: "reviewers: 0\n", wantErr: "reviewers must be between"},
+		{name: "negative team reviewers", content: "team_reviewers: -1\n", wantErr: "team_reviewers must be between"},
+		{name: "negative wait", content: "wait:\n  failing: -5m\n", wantErr: "wait periods cannot be negative"},
+		{name: "bad duration", content: "wait:\n  failing: soon\n", wantErr: "failed to parse"},
+		{name: "negative weight", content: "weights:\n  file: -1\n", wantErr: "weights.file cannot be negative"},
+		{name: "zero divisor", content: "weights:\n  recent_activity_divisor: 0\n", wantErr: "must be positive"},
+		{name: "percent weight over 100", content: "weights:\n  responsiveness_percent: 101\n", wantErr: "weights.responsiveness_percent cannot exceed 100"},
+		{name: "empty ignore pattern", content: "ignore_paths: [\"\"]\n", wantErr: "ignore_paths cannot contain empty patterns"},
+		{name: "zero api budget", content: "api_budget: 0\n", wantErr: "api_budget must be between"},
+		{name: "unknown selection", content: "selection: random\n", wantErr: `selection must be "score" or "coverage"`},
+		{name: "unknown rule action", content: "rules:\n  - action: ignore\n    labels: [wip]\n", wantErr: `rules[0]: action must be`},
+		{name: "rule without conditions", content: "rules:\n  - action: skip\n", wantErr: "at least one of labels"},
+		{name: "route without reviewers", content: "rules:\n  - action: route\n    authors: [dependabot]\n", wantErr: "route rules need reviewers or teams"},
+		{name: "skip with reviewers", content: "rules:\n  - action: skip\n    labels: [wip]\n    reviewers: [bob]\n", wantErr: "only apply to route rules"},
+		{name: "bad branch pattern", content: "rules:\n  - action: skip\n    base_branches: [\"release-[\"]\n", wantErr: "invalid base branch pattern"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Parse([]byte(tt.content))
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestLoader_Load_RepoConfig(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("owner", "repo", RepoPath, "reviewers: 1\n")
+	client.SetFileContent("owner", OrgRepo, OrgPath, "reviewers: 4\n")
+
+	cfg, err := newTestLoader(client).Load(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Reviewers != 1 {
+		t.Errorf("expected repository config to take precedence, got reviewers=%d", cfg.Reviewers)
+	}
+	if cfg.Source != "owner/repo/"+RepoPath {
+		t.Errorf("unexpected source %q", cfg.Source)
+	}
+}
+
+func TestLoader_Load_OrgFallback(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("owner", OrgRepo, OrgPath, "reviewers: 4\n")
+
+	cfg, err := newTestLoader(client).Load(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Reviewers != 4 {
+		t.Errorf("expected org config, got reviewers=%d", cfg.Reviewers)
+	}
+}
+
+func TestLoader_Load_Defaults(t *testing.T) {
+	cfg, err := newTestLoader(testutil.NewMockGitHubClient()).Load(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Source != "" || cfg.Reviewers != Default().Reviewers {
+		t.Errorf("expected defaults, got %+v", cfg)
+	}
+}
+
+func TestLoader_Load_InvalidConfig(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("owner", "repo", RepoPath, "reviewers: -1\n")
+
+	_, err := newTestLoader(client).Load(context.Background(), "owner", "repo")
+	if err == nil {
+		t.Fatal("expected error for invalid config")
+	}
+	if !strings.Contains(err.Error(), "owner/repo/"+RepoPath) {
+		t.Errorf("expected error to name the config source, got %v", err)
+	}
+}
+
+func TestLoader_Load_FetchError(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	client.SetError("FileContent:owner/repo/"+RepoPath, errors.New("boom"))
+
+	if _, err := newTestLoader(client).Load(context.Background(), "owner", "repo"); err == nil {
+		t.Fatal("expected fetch error to be returned")
+	}
+}
+
+func TestLoader_Load_Cached(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("owner", "repo", RepoPath, "reviewers: 1\n")
+	loader := newTestLoader(client)
+
+	if _, err := loader.Load(context.Background(), "owner", "repo"); err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	client.SetFileContent("owner", "repo", RepoPath, "reviewers: 3\n")
+
+	cfg, err := loader.Load(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Reviewers != 1 {
+		t.Errorf("expected cached config, got reviewers=%d", cfg.Reviewers)
+	}
+}
+
+func TestLoader_Load_OrgExclusions(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("owner", "repo", RepoPath, "exclude_users: [alice]\n")
+	client.SetFileContent("owner", OrgRepo, OrgPath, "reviewers: 4\nexclude_users: [\"@exec\"]\n")
+	client.SetFileContent("owner", OrgRepo, OptOutPath, "# Users who never want review requests\n- Bob\n")
+
+	cfg, err := newTestLoader(client).Load(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if cfg.Reviewers != Default().Reviewers {
+		t.Errorf("expected org settings other than exclusions to be ignored, got reviewers=%d", cfg.Reviewers)
+	}
+
+	tests := map[string]string{
+		"alice": "excluded by owner/repo/" + RepoPath,
+		"exec":  "excluded by owner/.github/" + OrgPath,
+		"bob":   "opted out in owner/.github/" + OptOutPath,
+		"carol": "",
+	}
+	for user, want := range tests {
+		if got := cfg.ExclusionReason(user); got != want {
+			t.Errorf("ExclusionReason(%q) = %q, want %q", user, got, want)
+		}
+	}
+}
+
+func TestLoader_Load_OptOutWithoutConfig(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("owner", OrgRepo, OptOutPath, "- bob\n")
+
+	cfg, err := newTestLoader(client).Load(context.Background(), "owner", "repo")
+	if err != nil {
+		t.Fatalf("Load() error = %v", err)
+	}
+	if !cfg.IsExcluded("bob") {
+		t.Error("expected opt-out to apply to repositories using defaults")
+	}
+}
+
+func TestLoader_Load_InvalidOptOut(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("owner", OrgRepo, OptOutPath, "users: [bob]\n")
+
+	_, err := newTestLoader(client).Load(context.Background(), "owner", "repo")
+	if err == nil {
+		t.Fatal("expected error for invalid opt-out list")
+	}
+	if !strings.Contains(err.Error(), OptOutPath) {
+		t.Errorf("expected error to name the opt-out list, got %v", err)
+	}
+}
@@ -0,0 +1,258 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+)
+
+// Dispatcher defaults and limits.
+const (
+	DefaultDedupWindow = 5 * time.Second // Events for a PR within this window of the last one are dropped
+	queueSize          = 100             // Buffer between sources and workers
+	maxSeen            = 1000            // Dedup entries kept before old ones are cleaned up
+	seenMaxAge         = time.Hour       // Age after which dedup entries are cleaned up
+)
+
+// ProcessFunc handles one event. Errors are logged and counted; retries are up to the function.
+type ProcessFunc func(ctx context.Context, e Event) error
+
+// Stats counts events handled by a Dispatcher.
+type Stats struct {
+	Received     int64 `json:"received"`
+	Deduplicated int64 `json:"deduplicated"`
+	Processed    int64 `json:"processed"`
+	Failed       int64 `json:"failed"`
+}
+
+// Dispatcher collects events from any number of sources, drops repeats of a PR within the
+// dedup window, and processes the rest on a fixed pool of workers. Sources can be added
+// and removed while it runs. It is safe for concurrent use.
+type Dispatcher struct {
+	now     func() time.Time
+	process ProcessFunc
+	queue   chan Event
+	sources map[string]*registration
+	seen    map[github.PRRef]time.Time
+	stats   Stats
+	window  time.Duration
+	workers int
+	mu      sync.Mutex
+}
+
+// registration is an added source and the channel closed to stop forwarding its events.
+type registration struct {
+	source EventSource
+	done   chan struct{}
+}
+
+// NewDispatcher creates a dispatcher processing events with process on workers goroutines.
+func NewDispatcher(process ProcessFunc, workers int) *Dispatcher {
+	return &Dispatcher{
+		now:     time.Now,
+		process: process,
+		queue:   make(chan Event, queueSize),
+		sources: make(map[string]*registration),
+		seen:    make(map[github.PRRef]time.Time),
+		window:  DefaultDedupWindow,
+		workers: max(workers, 1),
+	}
+}
+
+// Start starts the workers. They stop when ctx is done.
+func (d *Dispatcher) Start(ctx context.Context) {
+	for i := range d.workers {
+		go d.work(ctx, i)
+	}
+	slog.Info("Event dispatcher started", "component", "events", "workers", d.workers)
+}
+
+// Add starts src and forwards its events until it is removed or ctx is done.
+func (d *Dispatcher) Add(ctx context.Context, src EventSource) error {
+	name := src.Name()
+	d.mu.Lock()
+	if _, exists := d.sources[name]; exists {
+		d.mu.Unlock()
+		return fmt.Errorf("event source %s already added", name)
+	}
+	reg := &registration{source: src, done: make(chan struct{})}
+	d.sources[name] = reg
+	d.mu.Unlock()
+
+	if err := src.Start(ctx); err != nil {
+		d.mu.Lock()
+		delete(d.sources, name)
+		d.mu.Unlock()
+		return fmt.Errorf("failed to start event source %s: %w", name, err)
+	}
+
+	go d.forward(ctx, reg)
+	slog.Info("Added event source", "component", "events", "source", name)
+	return nil
+}
+
+// Remove stops the named source. It reports whether the source was present.
+func (d *Dispatcher) Remove(name string) bool {
+	d.mu.Lock()
+	reg, ok := d.sources[name]
+	delete(d.sources, name)
+	d.mu.Unlock()
+	if !ok {
+		return false
+	}
+
+	close(reg.done)
+	reg.source.Stop()
+	slog.Info("Removed event source", "component", "events", "source", name)
+	return true
+}
+
+// Stop stops all sources.
+func (d *Dispatcher) Stop() {
+	for _, name := range d.Names() {
+		d.Remove(name)
+	}
+}
+
+// Names returns the names of all sources, sorted.
+func (d *Dispatcher) Names() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	names := make([]string, 0, len(d.sources))
+	for name := range d.sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Health returns each source's health, sorted by source name, with the name as "source".
+func (d *Dispatcher) Health() []map[string]any {
+	d.mu.Lock()
+	regs := make([]*registration, 0, len(d.sources))
+	for _, reg := range d.sources {
+		regs = append(regs, reg)
+	}
+	d.mu.Unlock()
+
+	health := make([]map[string]any, 0, len(regs))
+	for _, reg := range regs {
+		h := reg.source.Health()
+		if h == nil {
+			h = make(map[string]any)
+		}
+		h["source"] = reg.source.Name()
+		health = append(health, h)
+	}
+	sort.Slice(health, func(i, j int) bool {
+		return fmt.Sprint(health[i]["source"]) < fmt.Sprint(health[j]["source"])
+	})
+	return health
+}
+
+// Stats returns the event counts so far.
+func (d *Dispatcher) Stats() Stats {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.stats
+}
+
+// forward moves a source's events onto the queue. A full queue holds up only this source.
+func (d *Dispatcher) forward(ctx context.Context, reg *registration) {
+	events := reg.source.Events()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-reg.done:
+			return
+		case e, ok := <-events:
+			if !ok {
+				return
+			}
+			select {
+			case <-reg.done:
+				return // Removed while the event was pending
+			default:
+			}
+			if e.Source == "" {
+				e.Source = reg.source.Name()
+			}
+			if !d.admit(e) {
+				continue
+			}
+			select {
+			case d.queue <- e:
+			case <-ctx.Done():
+				return
+			case <-reg.done:
+				return
+			}
+		}
+	}
+}
+
+// admit records an event and reports whether it should be processed, i.e. the PR was not
+// seen within the dedup window.
+func (d *Dispatcher) admit(e Event) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.stats.Received++
+	now := d.now()
+	if last, ok := d.seen[e.PRRef]; ok && now.Sub(last) < d.window {
+		d.stats.Deduplicated++
+		slog.Debug("Dropping duplicate event", "component", "events", "source", e.Source, "pr", e.PRRef.String())
+		return false
+	}
+	d.seen[e.PRRef] = now
+
+	// Clean up old entries to prevent unbounded growth
+	if len(d.seen) > maxSeen {
+		cutoff := now.Add(-seenMaxAge)
+		for ref, t := range d.seen {
+			if t.Before(cutoff) {
+				delete(d.seen, ref)
+			}
+		}
+	}
+	return true
+}
+
+// work processes queued events until ctx is done.
+func (d *Dispatcher) work(ctx context.Context, id int) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case e := <-d.queue:
+			err := d.safeProcess(ctx, e)
+			d.mu.Lock()
+			if err != nil {
+				d.stats.Failed++
+			} else {
+				d.stats.Processed++
+			}
+			d.mu.Unlock()
+			if err != nil {
+				slog.Error("Failed to process event", "component", "events", "worker", id, "source", e.Source, "pr", e.PRRef.String(), "error", err)
+			}
+		}
+	}
+}
+
+// safeProcess runs the process function, turning a panic into an error so a bad event
+// cannot take a worker down.
+func (d *Dispatcher) safeProcess(ctx context.Context, e Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("panic while processing event: %v", r)
+		}
+	}()
+	return d.process(ctx, e)
+}
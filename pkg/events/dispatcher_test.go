@@ -0,0 +1,379 @@
+package events
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+)
+
+// fakeSource is an EventSource whose events are sent by the test.
+type fakeSource struct {
+	startErr error
+	events   chan Event
+	name     string
+	mu       sync.Mutex
+	started  bool
+	stopped  bool
+}
+
+func newFakeSource(name string) *fakeSource {
+	return &fakeSource{name: name, events: make(chan Event, 10)}
+}
+
+func (f *fakeSource) Name() string         { return f.name }
+func (f *fakeSource) Events() <-chan Event { return f.events }
+
+func (f *fakeSource) Start(context.Context) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.started = true
+	return f.startErr
+}
+
+func (f *fakeSource) Stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stopped = true
+}
+
+func (f *fakeSource) Health() map[string]any {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return map[string]any{"is_running": f.started && !f.stopped}
+}
+
+func (f *fakeSource) send(owner, repo string, number int) {
+	f.events <- Event{PRRef: github.PRRef{Owner: owner, Repo: repo, Number: number}}
+}
+
+// recorder is a ProcessFunc that reports processed events on a channel.
+type recorder struct {
+	done chan Event
+}
+
+func newRecorder() *recorder {
+	return &recorder{done: make(chan Event, 100)}
+}
+
+func (r *recorder) process(_ context.Context, e Event) error {
+	r.done <- e
+	return nil
+}
+
+// wait returns the next processed event, failing the test if none arrives.
+func (r *recorder) wait(t *testing.T) Event {
+	t.Helper()
+	select {
+	case e := <-r.done:
+		return e
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for an event to be processed")
+		return Event{}
+	}
+}
+
+// waitStats polls until cond holds for the dispatcher's stats.
+func waitStats(t *testing.T, d *Dispatcher, cond func(Stats) bool) Stats {
+	t.Helper()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		stats := d.Stats()
+		if cond(stats) {
+			return stats
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for stats, last: %+v", stats)
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDispatcherProcessesEvents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := newRecorder()
+	d := NewDispatcher(rec.process, 2)
+	d.Start(ctx)
+
+	src := newFakeSource("fake")
+	if err := d.Add(ctx, src); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+
+	src.send("org", "repo", 1)
+	got := rec.wait(t)
+	want := Event{Source: "fake", PRRef: github.PRRef{Owner: "org", Repo: "repo", Number: 1}}
+	if got != want {
+		t.Errorf("processed %+v, want %+v", got, want)
+	}
+
+	stats := waitStats(t, d, func(s Stats) bool { return s.Processed == 1 })
+	if stats.Received != 1 || stats.Failed != 0 {
+		t.Errorf("Stats() = %+v", stats)
+	}
+}
+
+func TestDispatcherDeduplicates(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	var mu sync.Mutex
+	now := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+
+	rec := newRecorder()
+	d := NewDispatcher(rec.process, 1)
+	d.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	d.Start(ctx)
+
+	// The same PR from two sources is processed once
+	webhook, sprinkler := newFakeSource("webhook"), newFakeSource("sprinkler/org")
+	for _, src := range []*fakeSource{webhook, sprinkler} {
+		if err := d.Add(ctx, src); err != nil {
+			t.Fatalf("Add(%s) error = %v", src.name, err)
+		}
+	}
+
+	webhook.send("org", "repo", 1)
+	rec.wait(t)
+	sprinkler.send("org", "repo", 1)
+	waitStats(t, d, func(s Stats) bool { return s.Deduplicated == 1 })
+
+	// Other PRs are not affected
+	sprinkler.send("org", "repo", 2)
+	if got := rec.wait(t); got.Number != 2 {
+		t.Errorf("processed PR %d, want 2", got.Number)
+	}
+
+	// After the window, the PR is processed again
+	advance(DefaultDedupWindow)
+	sprinkler.send("org", "repo", 1)
+	if got := rec.wait(t); got.Number != 1 || got.Source != "sprinkler/org" {
+		t.Errorf("processed %+v, want PR 1 from sprinkler/org", got)
+	}
+
+	stats := waitStats(t, d, func(s Stats) bool { return s.Processed == 3 })
+	if stats.Received != 4 || stats.Deduplicated != 1 {
+		t.Errorf("Stats() = %+v, want 4 received and 1 deduplicated", stats)
+	}
+}
+
+func TestDispatcherCountsFailures(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	d := NewDispatcher(func(_ context.Context, e Event) error {
+		switch e.Number {
+		case 1:
+			return errors.New("boom")
+		case 2:
+			panic("bad event")
+		}
+		return nil
+	}, 1)
+	d.Start(ctx)
+
+	src := newFakeSource("fake")
+	if err := d.Add(ctx, src); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	for n := 1; n <= 3; n++ {
+		src.send("org", "repo", n)
+	}
+
+	// A panic must not take down the only worker
+	stats := waitStats(t, d, func(s Stats) bool { return s.Processed+s.Failed == 3 })
+	if stats.Failed != 2 || stats.Processed != 1 {
+		t.Errorf("Stats() = %+v, want 2 failed and 1 processed", stats)
+	}
+}
+
+func TestDispatcherAddRemove(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	rec := newRecorder()
+	d := NewDispatcher(rec.process, 1)
+	d.Start(ctx)
+
+	a, b := newFakeSource("a"), newFakeSource("b")
+	for _, src := range []*fakeSource{b, a} {
+		if err := d.Add(ctx, src); err != nil {
+			t.Fatalf("Add(%s) error = %v", src.name, err)
+		}
+	}
+	if err := d.Add(ctx, newFakeSource("a")); err == nil {
+		t.Error("Add() with a duplicate name succeeded")
+	}
+
+	failing := newFakeSource("failing")
+	failing.startErr = errors.New("no connection")
+	if err := d.Add(ctx, failing); err == nil {
+		t.Error("Add() succeeded for a source that failed to start")
+	}
+
+	if got := fmt.Sprint(d.Names()); got != "[a b]" {
+		t.Errorf("Names() = %s, want [a b]", got)
+	}
+	health := d.Health()
+	if len(health) != 2 || health[0]["source"] != "a" || health[1]["source"] != "b" {
+		t.Errorf("Health() = %v, want sources a and b in order", health)
+	}
+	if running, ok := health[0]["is_running"].(bool); !ok || !running {
+		t.Errorf("Health()[0][is_running] = %v, want true", health[0]["is_running"])
+	}
+
+	if !d.Remove("a") {
+		t.Error("Remove(a) = false, want true")
+	}
+	if d.Remove("a") {
+		t.Error("Remove(a) twice = true, want false")
+	}
+	if !a.stopped {
+		t.Error("removed source was not stopped")
+	}
+
+	// Events from the removed source are no longer forwarded
+	a.send("org", "repo", 1)
+	b.send("org", "repo", 2)
+	if got := rec.wait(t); got.Number != 2 {
+		t.Errorf("processed PR %d, want 2", got.Number)
+	}
+
+	d.Stop()
+	if len(d.Names()) != 0 || !b.stopped {
+		t.Errorf("Stop() left sources %v", d.Names())
+	}
+}
+
+// TestDispatcherConcurrentOrgs runs PRs from two orgs through concurrent workers sharing one
+// GitHub App client, and checks every request carries its own org's installation token.
+func TestDispatcherConcurrentOrgs(t *testing.T) {
+	var mu sync.Mutex
+	var mismatches []string
+	inFlight, maxInFlight := 0, 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/app/installations":
+			fmt.Fprint(w, `[{"id": 1, "account": {"login": "org-a", "type": "Organization"}},
+				{"id": 2, "account": {"login": "org-b", "type": "Organization"}}]`)
+		case strings.HasPrefix(r.URL.Path, "/app/installations/"):
+			org := map[string]string{"1": "org-a", "2": "org-b"}[strings.Split(r.URL.Path, "/")[3]]
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token": "token-%s", "expires_at": %q}`, org, time.Now().Add(time.Hour).Format(time.RFC3339))
+		default:
+			// /repos/<org>/repo/pulls/<n>, held open briefly so requests for both orgs overlap
+			org := strings.Split(r.URL.Path, "/")[2]
+			mu.Lock()
+			if got := r.Header.Get("Authorization"); got != "Bearer token-"+org {
+				mismatches = append(mismatches, fmt.Sprintf("%s sent %q", org, got))
+			}
+			inFlight++
+			maxInFlight = max(maxInFlight, inFlight)
+			mu.Unlock()
+			time.Sleep(10 * time.Millisecond)
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+			fmt.Fprint(w, "{}")
+		}
+	}))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client, err := github.New(ctx, github.Config{
+		UseAppAuth:  true,
+		AppID:       "12345",
+		AppKeyPath:  writeTestKey(t),
+		APIURL:      srv.URL,
+		HTTPTimeout: 5 * time.Second,
+		CacheTTL:    time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("github.New() error = %v", err)
+	}
+	if _, err := client.ListAppInstallations(ctx); err != nil {
+		t.Fatalf("ListAppInstallations() error = %v", err)
+	}
+
+	rec := newRecorder()
+	d := NewDispatcher(func(ctx context.Context, e Event) error {
+		ctx = github.WithOrg(ctx, e.Owner)
+		// Processing a PR takes several requests, while other workers move on to other orgs
+		for _, path := range []string{"", "/files"} {
+			resp, err := client.MakeRequest(ctx, http.MethodGet, fmt.Sprintf("%s/repos/%s/%s/pulls/%d%s", srv.URL, e.Owner, e.Repo, e.Number, path), nil)
+			if err != nil {
+				return err
+			}
+			if err := resp.Body.Close(); err != nil {
+				return err
+			}
+		}
+		return rec.process(ctx, e)
+	}, 4)
+	d.Start(ctx)
+
+	src := newFakeSource("fake")
+	if err := d.Add(ctx, src); err != nil {
+		t.Fatalf("Add() error = %v", err)
+	}
+	const perOrg = 8
+	go func() {
+		for n := 1; n <= perOrg; n++ {
+			src.send("org-a", "repo", n)
+			src.send("org-b", "repo", n)
+		}
+	}()
+	for range 2 * perOrg {
+		rec.wait(t)
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	if len(mismatches) > 0 {
+		t.Errorf("requests used the wrong token: %v", mismatches)
+	}
+	if maxInFlight < 2 {
+		t.Errorf("at most %d requests overlapped, want concurrent processing", maxInFlight)
+	}
+}
+
+// writeTestKey writes a throwaway GitHub App private key and returns its path.
+func writeTestKey(t *testing.T) string {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "app.pem")
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		t.Fatalf("failed to write key: %v", err)
+	}
+	return path
+}
@@ -0,0 +1,30 @@
+// Package events delivers pull request events from interchangeable sources, such as the
+// sprinkler WebSocket service, GitHub webhooks or polling, to a shared worker pool.
+package events
+
+import (
+	"context"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+)
+
+// Event is a pull request that may need reviewers.
+type Event struct {
+	Source string // Name of the EventSource that reported it
+	github.PRRef
+}
+
+// EventSource reports pull requests that may need reviewers.
+type EventSource interface {
+	// Name identifies the source, e.g. "sprinkler/myorg". Names are unique within a Dispatcher.
+	Name() string
+	// Start begins delivering events without blocking. The source stops when ctx is done.
+	Start(ctx context.Context) error
+	// Stop stops delivering events.
+	Stop()
+	// Events returns the channel events are delivered on.
+	Events() <-chan Event
+	// Health returns the source's status for health checks. "is_running" and, for
+	// connection-based sources, "is_connected" are reported as booleans.
+	Health() map[string]any
+}
@@ -0,0 +1,136 @@
+package events
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+)
+
+// ListFunc returns the pull requests a PollingSource reports.
+type ListFunc func(ctx context.Context) ([]github.PRRef, error)
+
+// PollingSource reports every pull request returned by a list function, once per interval.
+// It is a fallback where no push-based source is available.
+type PollingSource struct {
+	lastPoll time.Time
+	lastErr  error
+	list     ListFunc
+	events   chan Event
+	stop     chan struct{}
+	name     string
+	interval time.Duration
+	polls    int64
+	mu       sync.Mutex
+	running  bool
+}
+
+// NewPollingSource creates a source named name that calls list every interval.
+func NewPollingSource(name string, interval time.Duration, list ListFunc) *PollingSource {
+	return &PollingSource{
+		name:     name,
+		interval: interval,
+		list:     list,
+		events:   make(chan Event, queueSize),
+		stop:     make(chan struct{}),
+	}
+}
+
+// Name returns the source name.
+func (p *PollingSource) Name() string { return p.name }
+
+// Events returns the channel polled PRs are delivered on.
+func (p *PollingSource) Events() <-chan Event { return p.events }
+
+// Start polls immediately and then every interval until Stop or ctx is done.
+func (p *PollingSource) Start(ctx context.Context) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.running {
+		return nil
+	}
+	p.running = true
+	go p.run(ctx)
+	return nil
+}
+
+// Stop stops polling.
+func (p *PollingSource) Stop() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.running {
+		return
+	}
+	p.running = false
+	close(p.stop)
+}
+
+// Health reports when the source last polled and whether that failed.
+func (p *PollingSource) Health() map[string]any {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	status := map[string]any{
+		"is_running": p.running,
+		"interval":   p.interval.String(),
+		"polls":      p.polls,
+	}
+	if !p.lastPoll.IsZero() {
+		status["last_poll_at"] = p.lastPoll
+	}
+	if p.lastErr != nil {
+		status["last_error"] = p.lastErr.Error()
+	}
+	return status
+}
+
+// run polls until stopped.
+func (p *PollingSource) run(ctx context.Context) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("Polling source panic", "component", "events", "source", p.name, "panic", r)
+		}
+	}()
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+	for {
+		if !p.poll(ctx) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-p.stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll lists PRs and delivers them, waiting for room on the channel. It reports false once
+// the source should stop.
+func (p *PollingSource) poll(ctx context.Context) bool {
+	refs, err := p.list(ctx)
+	p.mu.Lock()
+	p.lastPoll, p.lastErr = time.Now(), err
+	p.polls++
+	p.mu.Unlock()
+	if err != nil {
+		slog.Warn("Polling for pull requests failed", "component", "events", "source", p.name, "error", err)
+		return true
+	}
+
+	slog.Debug("Polled pull requests", "component", "events", "source", p.name, "count", len(refs))
+	for _, ref := range refs {
+		select {
+		case p.events <- Event{Source: p.name, PRRef: ref}:
+		case <-ctx.Done():
+			return false
+		case <-p.stop:
+			return false
+		}
+	}
+	return true
+}
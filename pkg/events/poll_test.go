@@ -0,0 +1,56 @@
+package events
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+)
+
+func TestPollingSource(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	calls := make(chan struct{}, 10)
+	fail := true
+	p := NewPollingSource("poll", 10*time.Millisecond, func(context.Context) ([]github.PRRef, error) {
+		calls <- struct{}{}
+		if fail {
+			fail = false
+			return nil, errors.New("search failed")
+		}
+		return []github.PRRef{{Owner: "org", Repo: "repo", Number: 1}, {Owner: "org", Repo: "repo", Number: 2}}, nil
+	})
+
+	if err := p.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	// A failed poll is retried on the next tick
+	for _, want := range []int{1, 2} {
+		select {
+		case e := <-p.Events():
+			if e.Source != "poll" || e.Owner != "org" || e.Number != want {
+				t.Errorf("event = %+v, want PR %d from poll", e, want)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for polled events")
+		}
+	}
+
+	health := p.Health()
+	if polls, ok := health["polls"].(int64); !ok || polls < 2 {
+		t.Errorf("Health()[polls] = %v, want at least 2", health["polls"])
+	}
+	if running, ok := health["is_running"].(bool); !ok || !running {
+		t.Errorf("Health()[is_running] = %v, want true", health["is_running"])
+	}
+
+	p.Stop()
+	p.Stop() // Stopping twice is harmless
+	if running, ok := p.Health()["is_running"].(bool); !ok || running {
+		t.Errorf("Health()[is_running] after Stop = %v, want false", running)
+	}
+}
@@ -0,0 +1,194 @@
+package events
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+)
+
+// maxWebhookPayload is GitHub's limit on webhook payload size.
+const maxWebhookPayload = 25 << 20
+
+// webhookPRActions are the pull_request actions after which a PR may need reviewers.
+var webhookPRActions = map[string]bool{
+	"opened":           true,
+	"ready_for_review": true,
+	"synchronize":      true,
+	"reopened":         true,
+}
+
+// WebhookConfig configures a WebhookSource.
+type WebhookConfig struct {
+	// PRsForCommit returns the open PRs containing a commit, for status events.
+	PRsForCommit func(ctx context.Context, owner, repo, sha string) ([]int, error)
+	// OnInstallation is called after the app is installed, uninstalled or given other repositories.
+	OnInstallation func(ctx context.Context)
+	// Secret verifies X-Hub-Signature-256. Deliveries are rejected without one.
+	Secret []byte
+}
+
+// WebhookSource reports PRs from GitHub webhook deliveries: pull_request events, and
+// check_suite and status completions for the PRs they belong to. It is an http.Handler.
+type WebhookSource struct {
+	ctx          context.Context //nolint:containedctx // Deliveries are routed after the request completes
+	lastDelivery time.Time
+	events       chan Event
+	cfg          WebhookConfig
+	deliveries   int64
+	rejected     int64
+	mu           sync.Mutex
+	running      bool
+}
+
+// NewWebhookSource creates a webhook source.
+func NewWebhookSource(cfg WebhookConfig) *WebhookSource {
+	return &WebhookSource{cfg: cfg, events: make(chan Event, queueSize)}
+}
+
+// Name returns "webhook".
+func (*WebhookSource) Name() string { return "webhook" }
+
+// Events returns the channel webhook PRs are delivered on.
+func (s *WebhookSource) Events() <-chan Event { return s.events }
+
+// Start accepts deliveries until Stop or ctx is done.
+func (s *WebhookSource) Start(ctx context.Context) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.ctx, s.running = ctx, true
+	return nil
+}
+
+// Stop rejects further deliveries.
+func (s *WebhookSource) Stop() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.running = false
+}
+
+// Health reports delivery counts and when the last delivery arrived.
+func (s *WebhookSource) Health() map[string]any {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := map[string]any{
+		"is_running": s.running,
+		"deliveries": s.deliveries,
+		"rejected":   s.rejected,
+	}
+	if !s.lastDelivery.IsZero() {
+		status["last_delivery_at"] = s.lastDelivery
+	}
+	return status
+}
+
+// ServeHTTP handles a webhook delivery. Deliveries must be signed with the secret.
+func (s *WebhookSource) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	s.mu.Lock()
+	ctx, running := s.ctx, s.running
+	s.mu.Unlock()
+	if !running {
+		http.Error(w, "not accepting webhooks", http.StatusServiceUnavailable)
+		return
+	}
+
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookPayload))
+	if err != nil {
+		http.Error(w, "failed to read payload", http.StatusBadRequest)
+		return
+	}
+
+	delivery := r.Header.Get("X-GitHub-Delivery")
+	if err := github.VerifyWebhookSignature(s.cfg.Secret, body, r.Header.Get("X-Hub-Signature-256")); err != nil {
+		s.mu.Lock()
+		s.rejected++
+		s.mu.Unlock()
+		slog.Warn("Rejected webhook with invalid signature", "component", "webhook", "delivery", delivery, "error", err)
+		http.Error(w, "invalid signature", http.StatusUnauthorized)
+		return
+	}
+
+	s.mu.Lock()
+	s.deliveries++
+	s.lastDelivery = time.Now()
+	s.mu.Unlock()
+
+	eventType := r.Header.Get("X-GitHub-Event")
+	switch eventType {
+	case "pull_request", "check_suite", "status", "installation", "installation_repositories":
+	default:
+		// Includes "ping", sent when the webhook is created
+		slog.Debug("Ignoring webhook event", "component", "webhook", "event", eventType, "delivery", delivery)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	event, err := github.ParseWebhookEvent(eventType, body)
+	if err != nil {
+		slog.Warn("Failed to parse webhook", "component", "webhook", "event", eventType, "delivery", delivery, "error", err)
+		http.Error(w, "invalid payload", http.StatusBadRequest)
+		return
+	}
+
+	// GitHub expects an answer within 10 seconds, so events are routed in the background
+	w.WriteHeader(http.StatusAccepted)
+	go s.route(ctx, event, delivery)
+}
+
+// route delivers the PRs a webhook event concerns, or reports installation changes.
+func (s *WebhookSource) route(ctx context.Context, event *github.WebhookEvent, delivery string) {
+	defer func() {
+		if r := recover(); r != nil {
+			slog.Error("Webhook routing panic", "component", "webhook", "delivery", delivery, "panic", r)
+		}
+	}()
+
+	log := slog.With("component", "webhook", "event", event.Type, "action", event.Action, "owner", event.Owner, "repo", event.Repo, "delivery", delivery)
+
+	prs := event.PRs
+	switch event.Type {
+	case "pull_request":
+		if !webhookPRActions[event.Action] {
+			log.Debug("Ignoring pull_request action")
+			return
+		}
+	case "check_suite":
+		if event.Action != "completed" {
+			return
+		}
+	case "status":
+		if event.State == "pending" || s.cfg.PRsForCommit == nil {
+			return
+		}
+		var err error
+		if prs, err = s.cfg.PRsForCommit(ctx, event.Owner, event.Repo, event.SHA); err != nil {
+			log.Warn("Failed to find PRs for status event", "sha", event.SHA, "error", err)
+			return
+		}
+	case "installation", "installation_repositories":
+		if s.cfg.OnInstallation != nil {
+			log.Info("Installation changed")
+			s.cfg.OnInstallation(ctx)
+		}
+		return
+	default:
+		return
+	}
+
+	for _, number := range prs {
+		e := Event{Source: s.Name(), PRRef: github.PRRef{Owner: event.Owner, Repo: event.Repo, Number: number}}
+		select {
+		case s.events <- e:
+		default:
+			log.Warn("Webhook event channel full, dropping event", "pr", e.PRRef.String())
+		}
+	}
+}
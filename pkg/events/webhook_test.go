@@ -0,0 +1,140 @@
+package events
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testSecret = "s3cret"
+
+func deliver(s *WebhookSource, eventType, body, signature string) int {
+	req := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	req.Header.Set("X-GitHub-Event", eventType)
+	req.Header.Set("X-Hub-Signature-256", signature)
+	rec := httptest.NewRecorder()
+	s.ServeHTTP(rec, req)
+	return rec.Code
+}
+
+func sign(body string) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookSource(t *testing.T) {
+	const repository = `"repository": {"name": "repo", "owner": {"login": "org"}}`
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	installed := make(chan struct{}, 1)
+	s := NewWebhookSource(WebhookConfig{
+		Secret: []byte(testSecret),
+		PRsForCommit: func(_ context.Context, owner, repo, sha string) ([]int, error) {
+			if owner != "org" || repo != "repo" || sha != "def" {
+				t.Errorf("PRsForCommit(%s, %s, %s)", owner, repo, sha)
+			}
+			return []int{7, 8}, nil
+		},
+		OnInstallation: func(context.Context) { installed <- struct{}{} },
+	})
+
+	opened := `{"action": "opened", "pull_request": {"number": 12}, ` + repository + `}`
+	if code := deliver(s, "pull_request", opened, sign(opened)); code != http.StatusServiceUnavailable {
+		t.Errorf("delivery before Start = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+	if err := s.Start(ctx); err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		eventType string
+		body      string
+		signature string
+		wantCode  int
+		wantPRs   []int
+	}{
+		{name: "unsigned", eventType: "pull_request", body: opened, wantCode: http.StatusUnauthorized},
+		{name: "ping", eventType: "ping", body: `{}`, wantCode: http.StatusNoContent},
+		{name: "opened", eventType: "pull_request", body: opened, wantCode: http.StatusAccepted, wantPRs: []int{12}},
+		{
+			name:      "closed",
+			eventType: "pull_request",
+			body:      `{"action": "closed", "pull_request": {"number": 13}, ` + repository + `}`,
+			wantCode:  http.StatusAccepted,
+		},
+		{
+			name:      "pending status",
+			eventType: "status",
+			body:      `{"sha": "def", "state": "pending", ` + repository + `}`,
+			wantCode:  http.StatusAccepted,
+		},
+		{
+			name:      "status",
+			eventType: "status",
+			body:      `{"sha": "def", "state": "success", ` + repository + `}`,
+			wantCode:  http.StatusAccepted,
+			wantPRs:   []int{7, 8},
+		},
+		{name: "malformed", eventType: "pull_request", body: `{`, wantCode: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signature := tt.signature
+			if tt.wantCode != http.StatusUnauthorized {
+				signature = sign(tt.body)
+			}
+			if code := deliver(s, tt.eventType, tt.body, signature); code != tt.wantCode {
+				t.Fatalf("delivery = %d, want %d", code, tt.wantCode)
+			}
+
+			// Events are routed in the background, so wait for the expected ones and
+			// briefly for any unexpected ones
+			for _, want := range tt.wantPRs {
+				select {
+				case e := <-s.Events():
+					if e.Source != "webhook" || e.Owner != "org" || e.Repo != "repo" || e.Number != want {
+						t.Errorf("event = %+v, want org/repo#%d from webhook", e, want)
+					}
+				case <-time.After(5 * time.Second):
+					t.Fatalf("timed out waiting for PR %d", want)
+				}
+			}
+			select {
+			case e := <-s.Events():
+				t.Errorf("unexpected event %+v", e)
+			case <-time.After(20 * time.Millisecond):
+			}
+		})
+	}
+
+	installation := `{"action": "created", "installation": {"account": {"login": "neworg"}}}`
+	if code := deliver(s, "installation", installation, sign(installation)); code != http.StatusAccepted {
+		t.Errorf("installation delivery = %d, want %d", code, http.StatusAccepted)
+	}
+	select {
+	case <-installed:
+	case <-time.After(5 * time.Second):
+		t.Error("OnInstallation was not called")
+	}
+
+	health := s.Health()
+	if health["rejected"] != int64(1) || health["deliveries"] != int64(7) {
+		t.Errorf("Health() = %v, want 1 rejected and 7 deliveries", health)
+	}
+
+	s.Stop()
+	if code := deliver(s, "pull_request", opened, sign(opened)); code != http.StatusServiceUnavailable {
+		t.Errorf("delivery after Stop = %d, want %d", code, http.StatusServiceUnavailable)
+	}
+}
@@ -133,11 +133,13 @@ func newPersonalTokenClient(ctx context.Context, token string, httpTimeout time.
 	}
 
 	return &Client{
-		httpClient: &http.Client{Timeout: httpTimeout},
-		cache:      c,
-		userCache:  NewUserCache(),
-		token:      token,
-		isAppAuth:  false,
+		httpClient:  &http.Client{Timeout: httpTimeout},
+		cache:       c,
+		userCache:   NewUserCache(),
+		rateLimiter: NewRateLimiter(),
+		httpCache:   newHTTPCache(c),
+		token:       token,
+		isAppAuth:   false,
 	}, nil
 }
 
@@ -303,6 +305,8 @@ func createAppAuthClient(appID, keyPath string, privateKeyContent []byte, jwtTok
 		httpClient:         &http.Client{Timeout: httpTimeout},
 		cache:              c,
 		userCache:          NewUserCache(),
+		rateLimiter:        NewRateLimiter(),
+		httpCache:          newHTTPCache(c),
 		token:              jwtToken,
 		isAppAuth:          true,
 		appID:              appID,
@@ -418,7 +422,7 @@ func (c *Client) getInstallationToken(ctx context.Context, org string) (string,
 
 	// Create installation access token with retry logic
 	slog.Info("Creating installation access token for org", "component", "auth", "org", org, "installation_id", installationID)
-	apiURL := fmt.Sprintf("https://api.github.com/app/installations/%d/access_tokens", installationID)
+	apiURL := c.api("/app/installations/%d/access_tokens", installationID)
 
 	var tokenResp struct {
 		ExpiresAt time.Time `json:"expires_at"`
@@ -493,7 +497,7 @@ func (c *Client) ListAppInstallations(ctx context.Context) ([]string, error) {
 	}
 
 	slog.Info("Fetching GitHub App installations", "component", "api")
-	apiURL := "https://api.github.com/app/installations"
+	apiURL := c.api("/app/installations")
 	resp, err := c.doRequest(ctx, "GET", apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get app installations: %w", err)
@@ -517,9 +521,11 @@ func (c *Client) ListAppInstallations(ctx context.Context) ([]string, error) {
 	for _, installation := range installations {
 		// Include both organization and user accounts
 		orgs = append(orgs, installation.Account.Login)
-		// Store the installation ID and type for later use
+		// Store the installation ID and type for later use; requests for other orgs may be reading them
+		c.tokenMutex.Lock()
 		c.installationIDs[installation.Account.Login] = installation.ID
 		c.installationTypes[installation.Account.Login] = installation.Account.Type
+		c.tokenMutex.Unlock()
 
 		if installation.Account.Type == "Organization" {
 			slog.Info("Found installation in org", "component", "app", "org", installation.Account.Login, "installation_id", installation.ID)
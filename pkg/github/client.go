@@ -28,9 +28,14 @@ type Client struct {
 	installationIDs    map[string]int
 	installationTypes  map[string]string
 	userCache          *UserCache
+	rateLimiter        *RateLimiter
+	httpCache          *httpCache
 	prxClient          interface { // prx.Client interface to avoid import cycle
 		PullRequestWithReferenceTime(ctx context.Context, owner, repo string, prNumber int, referenceTime time.Time) (any, error)
 	}
+	apiURL            string // REST API base URL (empty for GitHub.com)
+	graphQLEndpoint   string // GraphQL endpoint (empty for GitHub.com)
+	host              string // Web host (empty for GitHub.com)
 	appID             string
 	token             string
 	privateKeyPath    string
@@ -46,6 +51,8 @@ type Config struct {
 	AppID       string
 	AppKeyPath  string
 	Token       string // Personal access token (for non-app auth)
+	APIURL      string // REST API base URL (empty = https://api.github.com; GitHub Enterprise Server: https://HOST/api/v3)
+	GraphQLURL  string // GraphQL endpoint (empty = derived from APIURL)
 	HTTPTimeout time.Duration
 	CacheTTL    time.Duration
 	UseAppAuth  bool
@@ -53,24 +60,91 @@ type Config struct {
 
 // New creates a new GitHub API client using gh auth token or GitHub App authentication.
 func New(ctx context.Context, cfg Config) (*Client, error) {
+	ep, err := resolveEndpoints(cfg.APIURL, cfg.GraphQLURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var c *Client
 	if cfg.UseAppAuth {
-		return newAppAuthClient(ctx, cfg.AppID, cfg.AppKeyPath, cfg.HTTPTimeout, cfg.CacheTTL, cfg.CacheDir)
+		c, err = newAppAuthClient(ctx, cfg.AppID, cfg.AppKeyPath, cfg.HTTPTimeout, cfg.CacheTTL, cfg.CacheDir)
+	} else {
+		c, err = newPersonalTokenClient(ctx, cfg.Token, cfg.HTTPTimeout, cfg.CacheTTL, cfg.CacheDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if ep.api != defaultAPIURL {
+		c.apiURL, c.graphQLEndpoint, c.host = ep.api, ep.graphQL, ep.host
+		slog.Info("Using GitHub Enterprise Server", "component", "auth", "api_url", ep.api, "graphql_url", ep.graphQL, "host", ep.host)
 	}
-	return newPersonalTokenClient(ctx, cfg.Token, cfg.HTTPTimeout, cfg.CacheTTL, cfg.CacheDir)
+	return c, nil
 }
 
-// SetCurrentOrg sets the current organization being processed.
+// SetCurrentOrg sets the current organization being processed. It applies to every
+// request made through the client, so concurrent callers should use WithOrg instead.
 func (c *Client) SetCurrentOrg(org string) {
 	c.tokenMutex.Lock()
 	defer c.tokenMutex.Unlock()
 	c.currentOrg = org
 }
 
+// orgKey is the context key for the organization requests are made for.
+type orgKey struct{}
+
+// WithOrg returns a context whose requests are made for org, using its installation token
+// with App authentication. It takes precedence over SetCurrentOrg, so goroutines sharing
+// a client can each work on a different org.
+func WithOrg(ctx context.Context, org string) context.Context {
+	return context.WithValue(ctx, orgKey{}, org)
+}
+
+// org returns the organization requests made with ctx are for: the one given to WithOrg,
+// or else the current org.
+func (c *Client) org(ctx context.Context) string {
+	if org, ok := ctx.Value(orgKey{}).(string); ok {
+		return org
+	}
+	c.tokenMutex.RLock()
+	defer c.tokenMutex.RUnlock()
+	return c.currentOrg
+}
+
+// baseToken returns the JWT or personal access token.
+func (c *Client) baseToken() string {
+	c.tokenMutex.RLock()
+	defer c.tokenMutex.RUnlock()
+	return c.token
+}
+
+// authToken returns the token for a request made with ctx: with App authentication and an
+// org, that org's installation token, falling back to the JWT; otherwise the base token.
+func (c *Client) authToken(ctx context.Context) string {
+	org := c.org(ctx)
+	if !c.isAppAuth || org == "" {
+		return c.baseToken()
+	}
+	installToken, err := c.getInstallationToken(ctx, org)
+	if err != nil {
+		// Graceful degradation: try with JWT token
+		slog.WarnContext(ctx, "Failed to get installation token, attempting with JWT (may have limited access)", "org", org, "error", err)
+		return c.baseToken()
+	}
+	slog.DebugContext(ctx, "Using installation token for org", "org", org)
+	return installToken
+}
+
 // SetPrxClient sets the prx client for enhanced PR data fetching.
 func (c *Client) SetPrxClient(prxClient PrxClient) {
 	c.prxClient = prxClient
 }
 
+// Cache returns the client's cache so other components can share it.
+func (c *Client) Cache() *cache.DiskCache {
+	return c.cache
+}
+
 // IsUserAccount checks if the given account is a user account (not an organization).
 func (c *Client) IsUserAccount(account string) bool {
 	c.tokenMutex.RLock()
@@ -79,15 +153,13 @@ func (c *Client) IsUserAccount(account string) bool {
 }
 
 // Token returns the current GitHub token for external use (e.g., sprinkler).
-// For App authentication with a currentOrg set, returns the installation token.
-// Otherwise returns the base token (JWT or personal access token).
+// For App authentication with an org set by WithOrg or SetCurrentOrg, returns the
+// installation token. Otherwise returns the base token (JWT or personal access token).
 func (c *Client) Token(ctx context.Context) (string, error) {
-	if c.isAppAuth && c.currentOrg != "" {
-		return c.getInstallationToken(ctx, c.currentOrg)
+	if org := c.org(ctx); c.isAppAuth && org != "" {
+		return c.getInstallationToken(ctx, org)
 	}
-	c.tokenMutex.RLock()
-	defer c.tokenMutex.RUnlock()
-	return c.token, nil
+	return c.baseToken(), nil
 }
 
 // drainAndCloseBody drains and closes an HTTP response body to prevent resource leaks.
@@ -119,8 +191,19 @@ func (c *Client) doRequest(ctx context.Context, method, apiURL string, body any)
 	sanitizedURL := sanitizeURLForLogging(apiURL)
 	slog.Info("HTTP request", "component", "http", "method", method, "url", sanitizedURL)
 
+	// Only plain GETs are made conditional; writes must always reach GitHub
+	var httpCacheKey string
+	if method == http.MethodGet && body == nil && c.httpCache != nil {
+		httpCacheKey = c.httpCache.key(c.rateLimitKey(ctx), apiURL)
+	}
+
 	var resp *http.Response
 	err := retryWithBackoff(ctx, fmt.Sprintf("%s %s", method, apiURL), func() error {
+		limitKey, resource := c.rateLimitKey(ctx), rateLimitResource(apiURL)
+		if err := c.rateLimiter.Wait(ctx, limitKey, resource); err != nil {
+			return err
+		}
+
 		var bodyReader io.Reader
 		if body != nil {
 			bodyBytes, err := json.Marshal(body)
@@ -135,19 +218,8 @@ func (c *Client) doRequest(ctx context.Context, method, apiURL string, body any)
 			return fmt.Errorf("failed to create request: %w", err)
 		}
 
-		// Use the appropriate token based on authentication type and current org
-		authToken := c.token
-		if c.isAppAuth && c.currentOrg != "" {
-			// For app auth with a specific org, use installation token
-			installToken, err := c.getInstallationToken(ctx, c.currentOrg)
-			if err == nil {
-				authToken = installToken
-				slog.Debug("Using installation token for org", "org", c.currentOrg)
-			} else {
-				// Graceful degradation: try with JWT token
-				slog.Warn("Failed to get installation token, attempting with JWT (may have limited access)", "org", c.currentOrg, "error", err)
-			}
-		}
+		// Use the appropriate token based on authentication type and org
+		authToken := c.authToken(ctx)
 
 		if c.isAppAuth {
 			req.Header.Set("Authorization", "Bearer "+authToken)
@@ -159,6 +231,10 @@ func (c *Client) doRequest(ctx context.Context, method, apiURL string, body any)
 		if method == "PATCH" || method == "POST" || method == "PUT" {
 			req.Header.Set("Content-Type", "application/json")
 		}
+		var cached *httpCacheEntry
+		if httpCacheKey != "" {
+			cached = c.httpCache.prepare(req, httpCacheKey)
+		}
 
 		var localResp *http.Response
 		localResp, err = c.httpClient.Do(req) //nolint:bodyclose // body is closed via defer or passed to caller
@@ -167,10 +243,11 @@ func (c *Client) doRequest(ctx context.Context, method, apiURL string, body any)
 		}
 
 		// Check for rate limiting or server errors that should trigger retry
-		if localResp.StatusCode == http.StatusTooManyRequests {
+		c.rateLimiter.Update(limitKey, resource, localResp.Header)
+		if c.rateLimiter.Limited(limitKey, localResp) {
 			drainAndCloseBody(localResp.Body)
-			slog.Warn("Rate limited - will retry with backoff", "method", method, "url", sanitizedURL, "status", 429)
-			return fmt.Errorf("http %d: rate limited", localResp.StatusCode)
+			slog.Warn("Rate limited - will retry once the limit allows", "method", method, "url", sanitizedURL, "status", localResp.StatusCode)
+			return errRateLimited(localResp.StatusCode)
 		}
 
 		if localResp.StatusCode >= http.StatusInternalServerError && localResp.StatusCode < 600 {
@@ -179,6 +256,12 @@ func (c *Client) doRequest(ctx context.Context, method, apiURL string, body any)
 			return fmt.Errorf("http %d: server error", localResp.StatusCode)
 		}
 
+		if httpCacheKey != "" {
+			if localResp, err = c.httpCache.handle(httpCacheKey, cached, localResp); err != nil {
+				return err
+			}
+		}
+
 		// Success - assign to outer resp variable and let caller handle body
 		resp = localResp
 		return nil
@@ -235,10 +318,22 @@ func retryWithBackoff(ctx context.Context, operation string, fn func() error) er
 
 // AddReviewers adds reviewers to a pull request.
 func (c *Client) AddReviewers(ctx context.Context, owner, repo string, prNumber int, reviewers []string) error {
-	url := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d/requested_reviewers", owner, repo, prNumber)
+	return c.AddReviewRequests(ctx, owner, repo, prNumber, reviewers, nil)
+}
+
+// AddReviewRequests requests reviews from users and teams in a single call.
+// Teams are given by slug (e.g. "core" for @org/core) and must belong to the repository's organization.
+func (c *Client) AddReviewRequests(ctx context.Context, owner, repo string, prNumber int, users, teams []string) error {
+	url := c.api("/repos/%s/%s/pulls/%d/requested_reviewers", owner, repo, prNumber)
 
+	if users == nil {
+		users = []string{} // Encode as [] rather than null when only teams are requested
+	}
 	payload := map[string]any{
-		"reviewers": reviewers,
+		"reviewers": users,
+	}
+	if len(teams) > 0 {
+		payload["team_reviewers"] = teams
 	}
 
 	resp, err := c.doRequest(ctx, "POST", url, payload) //nolint:bodyclose // body is closed via defer drainAndCloseBody
@@ -255,6 +350,6 @@ func (c *Client) AddReviewers(ctx context.Context, owner, repo string, prNumber
 		return fmt.Errorf("failed to add reviewers: status %d: %s", resp.StatusCode, string(body))
 	}
 
-	slog.Info("Added reviewers to PR", "owner", owner, "repo", repo, "pr", prNumber, "reviewers", reviewers)
+	slog.Info("Added reviewers to PR", "owner", owner, "repo", repo, "pr", prNumber, "reviewers", users, "teams", teams)
 	return nil
 }
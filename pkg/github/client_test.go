@@ -6,6 +6,7 @@ import (
 	"io"
 	"net/http"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 )
@@ -263,3 +264,60 @@ func TestRetryConstants(t *testing.T) {
 		t.Errorf("expected maxRetryDelay to be 2 minutes, got %v", maxRetryDelay)
 	}
 }
+
+func TestClient_WithOrg(t *testing.T) {
+	var mu sync.Mutex
+	var mismatches []string
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			// Requests are for /repos/<org>/repo and must carry that org's token
+			org := strings.Split(req.URL.Path, "/")[2]
+			if got := req.Header.Get("Authorization"); got != "Bearer token-"+org {
+				mu.Lock()
+				mismatches = append(mismatches, fmt.Sprintf("%s sent %q", org, got))
+				mu.Unlock()
+			}
+			return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}")), Header: make(http.Header)}, nil
+		},
+	}
+
+	expiry := time.Now().Add(time.Hour)
+	c := &Client{
+		cache:              mustNewDiskCache(t),
+		httpClient:         &http.Client{Transport: mockTransport},
+		isAppAuth:          true,
+		token:              "jwt",
+		tokenExpiry:        expiry,
+		installationTokens: map[string]string{"org-a": "token-org-a", "org-b": "token-org-b"},
+		installationExpiry: map[string]time.Time{"org-a": expiry, "org-b": expiry},
+	}
+	// A current org set elsewhere must not leak into requests for another org
+	c.SetCurrentOrg("org-a")
+
+	var wg sync.WaitGroup
+	for i := range 20 {
+		org := []string{"org-a", "org-b"}[i%2]
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := WithOrg(context.Background(), org)
+			resp, err := c.MakeRequest(ctx, http.MethodGet, "https://api.github.com/repos/"+org+"/repo", nil)
+			if err != nil {
+				t.Errorf("MakeRequest(%s) error = %v", org, err)
+				return
+			}
+			drainAndCloseBody(resp.Body)
+		}()
+	}
+	wg.Wait()
+
+	if len(mismatches) > 0 {
+		t.Errorf("requests used the wrong token: %v", mismatches)
+	}
+	if got := c.rateLimitKey(WithOrg(context.Background(), "org-b")); got != "installation:org-b" {
+		t.Errorf("rateLimitKey(WithOrg(org-b)) = %q", got)
+	}
+	if got := c.rateLimitKey(context.Background()); got != "installation:org-a" {
+		t.Errorf("rateLimitKey() without WithOrg = %q, want the current org", got)
+	}
+}
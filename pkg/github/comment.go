@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// maxCommentPages bounds how many pages of PR comments are searched for an existing comment.
+const maxCommentPages = 10
+
+// UpsertComment posts a comment on a pull request, or edits the existing comment containing
+// marker so repeated runs keep a single comment. body should contain marker.
+func (c *Client) UpsertComment(ctx context.Context, owner, repo string, prNumber int, marker, body string) error {
+	id, err := c.findComment(ctx, owner, repo, prNumber, marker)
+	if err != nil {
+		return err
+	}
+
+	method, apiURL, wantStatus := http.MethodPost, c.api("/repos/%s/%s/issues/%d/comments", owner, repo, prNumber), http.StatusCreated
+	if id != 0 {
+		method, apiURL, wantStatus = http.MethodPatch, c.api("/repos/%s/%s/issues/comments/%d", owner, repo, id), http.StatusOK
+	}
+
+	resp, err := c.MakeRequest(ctx, method, apiURL, map[string]string{"body": body})
+	if err != nil {
+		return fmt.Errorf("failed to post comment: %w", err)
+	}
+	defer drainAndCloseBody(resp.Body)
+
+	if resp.StatusCode != wantStatus {
+		respBody, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to post comment: status %d (could not read body: %w)", resp.StatusCode, err)
+		}
+		return fmt.Errorf("failed to post comment: status %d: %s", resp.StatusCode, string(respBody))
+	}
+
+	slog.Info("Posted PR comment", "owner", owner, "repo", repo, "pr", prNumber, "updated", id != 0)
+	return nil
+}
+
+// issueComment is a comment on an issue or pull request.
+type issueComment struct {
+	Body string `json:"body"`
+	ID   int64  `json:"id"`
+}
+
+// findComment returns the ID of the first comment on a pull request containing marker, or 0 if there is none.
+func (c *Client) findComment(ctx context.Context, owner, repo string, prNumber int, marker string) (int64, error) {
+	for page := 1; page <= maxCommentPages; page++ {
+		apiURL := c.api("/repos/%s/%s/issues/%d/comments?per_page=%d&page=%d", owner, repo, prNumber, perPageLimit, page)
+		comments, err := func() ([]issueComment, error) {
+			resp, err := c.MakeRequest(ctx, http.MethodGet, apiURL, nil)
+			if err != nil {
+				return nil, err
+			}
+			defer drainAndCloseBody(resp.Body)
+
+			if resp.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("status %d", resp.StatusCode)
+			}
+			var comments []issueComment
+			if err := json.NewDecoder(resp.Body).Decode(&comments); err != nil {
+				return nil, err
+			}
+			return comments, nil
+		}()
+		if err != nil {
+			return 0, fmt.Errorf("failed to list PR comments: %w", err)
+		}
+
+		for _, comment := range comments {
+			if strings.Contains(comment.Body, marker) {
+				return comment.ID, nil
+			}
+		}
+		if len(comments) < perPageLimit {
+			break
+		}
+	}
+	return 0, nil
+}
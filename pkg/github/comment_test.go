@@ -0,0 +1,85 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestClient_UpsertComment(t *testing.T) {
+	const marker = "<!-- test-marker -->"
+
+	tests := []struct {
+		name       string
+		existing   string
+		wantMethod string
+		wantURL    string
+	}{
+		{
+			name:       "creates comment",
+			existing:   `[{"id": 1, "body": "LGTM"}]`,
+			wantMethod: http.MethodPost,
+			wantURL:    "https://api.github.com/repos/owner/repo/issues/7/comments",
+		},
+		{
+			name:       "updates existing comment",
+			existing:   `[{"id": 1, "body": "LGTM"}, {"id": 42, "body": "` + marker + `\nold"}]`,
+			wantMethod: http.MethodPatch,
+			wantURL:    "https://api.github.com/repos/owner/repo/issues/comments/42",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotMethod, gotURL, gotBody string
+			mockTransport := &mockRoundTripperFunc{
+				roundTripFunc: func(req *http.Request) (*http.Response, error) {
+					if req.Method == http.MethodGet {
+						return &http.Response{
+							StatusCode: http.StatusOK,
+							Body:       io.NopCloser(strings.NewReader(tt.existing)),
+							Header:     make(http.Header),
+						}, nil
+					}
+					gotMethod, gotURL = req.Method, req.URL.String()
+					var payload struct {
+						Body string `json:"body"`
+					}
+					if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+						t.Errorf("failed to decode request body: %v", err)
+					}
+					gotBody = payload.Body
+					status := http.StatusOK
+					if req.Method == http.MethodPost {
+						status = http.StatusCreated
+					}
+					return &http.Response{
+						StatusCode: status,
+						Body:       io.NopCloser(strings.NewReader(`{}`)),
+						Header:     make(http.Header),
+					}, nil
+				},
+			}
+
+			c := &Client{
+				cache:      mustNewDiskCache(t),
+				httpClient: &http.Client{Transport: mockTransport},
+				token:      "test-token",
+			}
+
+			body := marker + "\nnew"
+			if err := c.UpsertComment(context.Background(), "owner", "repo", 7, marker, body); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if gotMethod != tt.wantMethod || gotURL != tt.wantURL {
+				t.Errorf("got %s %s, want %s %s", gotMethod, gotURL, tt.wantMethod, tt.wantURL)
+			}
+			if gotBody != body {
+				t.Errorf("got body %q, want %q", gotBody, body)
+			}
+		})
+	}
+}
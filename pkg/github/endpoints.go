@@ -0,0 +1,138 @@
+package github
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// GitHub.com endpoints, used unless Config names a GitHub Enterprise Server.
+const (
+	defaultAPIURL = "https://api.github.com"
+	defaultHost   = "github.com"
+)
+
+// endpoints are the REST and GraphQL URLs of a GitHub instance, and the host its web UI
+// (and so its PR URLs) is served from.
+type endpoints struct {
+	api     string
+	graphQL string
+	host    string
+}
+
+// resolveEndpoints validates the configured API URLs and fills in defaults. An empty apiURL
+// means GitHub.com. An empty graphQLURL is derived from apiURL: GitHub Enterprise Server
+// serves REST from https://HOST/api/v3 and GraphQL from https://HOST/api/graphql.
+func resolveEndpoints(apiURL, graphQLURL string) (endpoints, error) {
+	apiURL = strings.TrimSuffix(apiURL, "/")
+	graphQLURL = strings.TrimSuffix(graphQLURL, "/")
+	if apiURL == "" {
+		apiURL = defaultAPIURL
+	}
+
+	u, err := parseBaseURL(apiURL)
+	if err != nil {
+		return endpoints{}, fmt.Errorf("invalid API URL %q: %w", apiURL, err)
+	}
+
+	if graphQLURL == "" {
+		switch {
+		case apiURL == defaultAPIURL:
+			graphQLURL = graphQLEndpoint
+		case strings.HasSuffix(apiURL, "/api/v3"):
+			graphQLURL = strings.TrimSuffix(apiURL, "/v3") + "/graphql"
+		default:
+			graphQLURL = apiURL + "/graphql"
+		}
+	}
+	if _, err := parseBaseURL(graphQLURL); err != nil {
+		return endpoints{}, fmt.Errorf("invalid GraphQL URL %q: %w", graphQLURL, err)
+	}
+
+	return endpoints{api: apiURL, graphQL: graphQLURL, host: webHost(u)}, nil
+}
+
+// parseBaseURL parses an absolute http(s) URL without query or fragment.
+func parseBaseURL(raw string) (*url.URL, error) {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return nil, err
+	}
+	if (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return nil, errors.New("must be an absolute http or https URL")
+	}
+	if u.RawQuery != "" || u.Fragment != "" {
+		return nil, errors.New("must not have a query or fragment")
+	}
+	return u, nil
+}
+
+// webHost returns the web host for an API URL: api.github.com serves github.com, and
+// GitHub Enterprise Server serves both from the same host.
+func webHost(apiURL *url.URL) string {
+	return strings.TrimPrefix(strings.ToLower(apiURL.Host), "api.")
+}
+
+// WebHost returns the host PR URLs use for the GitHub instance serving apiURL
+// (empty for GitHub.com), e.g. "github.com" or "github.example.com".
+func WebHost(apiURL string) (string, error) {
+	ep, err := resolveEndpoints(apiURL, "")
+	if err != nil {
+		return "", err
+	}
+	return ep.host, nil
+}
+
+// api returns the REST API URL for a path, formatted with args.
+func (c *Client) api(format string, args ...any) string {
+	base := c.apiURL
+	if base == "" {
+		base = defaultAPIURL
+	}
+	return base + fmt.Sprintf(format, args...)
+}
+
+// graphQLURL returns the GraphQL endpoint.
+func (c *Client) graphQLURL() string {
+	if c.graphQLEndpoint == "" {
+		return graphQLEndpoint
+	}
+	return c.graphQLEndpoint
+}
+
+// Host returns the web host of the GitHub instance, e.g. "github.com".
+func (c *Client) Host() string {
+	if c.host == "" {
+		return defaultHost
+	}
+	return c.host
+}
+
+// PRURL returns the web URL of a pull request.
+func (c *Client) PRURL(ref PRRef) string {
+	return fmt.Sprintf("https://%s/%s/%s/pull/%d", c.Host(), ref.Owner, ref.Repo, ref.Number)
+}
+
+// ParsePRURL parses a pull request URL such as https://github.com/owner/repo/pull/123 on
+// host. Trailing path segments, as in .../pull/123/files, are ignored.
+func ParsePRURL(rawURL, host string) (PRRef, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") {
+		return PRRef{}, fmt.Errorf("invalid PR URL: %s", rawURL)
+	}
+	if !strings.EqualFold(u.Host, host) {
+		return PRRef{}, fmt.Errorf("PR URL %s is not on %s", rawURL, host)
+	}
+
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 4 || parts[0] == "" || parts[1] == "" || parts[2] != "pull" {
+		return PRRef{}, fmt.Errorf("invalid PR URL format: %s", rawURL)
+	}
+	number, err := strconv.Atoi(parts[3])
+	if err != nil || number <= 0 {
+		return PRRef{}, fmt.Errorf("invalid PR number in URL: %s", rawURL)
+	}
+	return PRRef{Owner: parts[0], Repo: parts[1], Number: number}, nil
+}
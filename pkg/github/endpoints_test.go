@@ -0,0 +1,146 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestResolveEndpoints(t *testing.T) {
+	tests := []struct {
+		name        string
+		apiURL      string
+		graphQLURL  string
+		wantAPI     string
+		wantGraphQL string
+		wantHost    string
+		wantErr     bool
+	}{
+		{
+			name:        "github.com by default",
+			wantAPI:     "https://api.github.com",
+			wantGraphQL: "https://api.github.com/graphql",
+			wantHost:    "github.com",
+		},
+		{
+			name:        "enterprise server",
+			apiURL:      "https://github.example.com/api/v3/",
+			wantAPI:     "https://github.example.com/api/v3",
+			wantGraphQL: "https://github.example.com/api/graphql",
+			wantHost:    "github.example.com",
+		},
+		{
+			name:        "api subdomain",
+			apiURL:      "https://api.octocorp.ghe.com",
+			wantAPI:     "https://api.octocorp.ghe.com",
+			wantGraphQL: "https://api.octocorp.ghe.com/graphql",
+			wantHost:    "octocorp.ghe.com",
+		},
+		{
+			name:        "explicit graphql url",
+			apiURL:      "https://github.example.com/api/v3",
+			graphQLURL:  "https://graphql.example.com/query",
+			wantAPI:     "https://github.example.com/api/v3",
+			wantGraphQL: "https://graphql.example.com/query",
+			wantHost:    "github.example.com",
+		},
+		{name: "relative url", apiURL: "github.example.com/api/v3", wantErr: true},
+		{name: "query string", apiURL: "https://github.example.com/api/v3?x=1", wantErr: true},
+		{name: "bad graphql url", graphQLURL: "ftp://github.example.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ep, err := resolveEndpoints(tt.apiURL, tt.graphQLURL)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("resolveEndpoints() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if ep.api != tt.wantAPI || ep.graphQL != tt.wantGraphQL || ep.host != tt.wantHost {
+				t.Errorf("resolveEndpoints() = %+v, want api %s graphql %s host %s", ep, tt.wantAPI, tt.wantGraphQL, tt.wantHost)
+			}
+		})
+	}
+}
+
+func TestParsePRURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		host    string
+		want    PRRef
+		wantErr bool
+	}{
+		{name: "github.com", url: "https://github.com/owner/repo/pull/123", host: "github.com", want: PRRef{Owner: "owner", Repo: "repo", Number: 123}},
+		{name: "files tab", url: "https://github.com/owner/repo/pull/7/files", host: "github.com", want: PRRef{Owner: "owner", Repo: "repo", Number: 7}},
+		{name: "enterprise server", url: "https://GitHub.Example.com/owner/repo/pull/9", host: "github.example.com", want: PRRef{Owner: "owner", Repo: "repo", Number: 9}},
+		{name: "other host", url: "https://github.com/owner/repo/pull/9", host: "github.example.com", wantErr: true},
+		{name: "issue", url: "https://github.com/owner/repo/issues/9", host: "github.com", wantErr: true},
+		{name: "bad number", url: "https://github.com/owner/repo/pull/abc", host: "github.com", wantErr: true},
+		{name: "too short", url: "https://github.com/owner/repo", host: "github.com", wantErr: true},
+		{name: "no scheme", url: "github.com/owner/repo/pull/1", host: "github.com", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParsePRURL(tt.url, tt.host)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParsePRURL() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParsePRURL() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_EnterpriseServerURLs(t *testing.T) {
+	var gotURLs []string
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			gotURLs = append(gotURLs, req.URL.String())
+			body := `{"total_count": 0, "items": []}`
+			if req.Method == http.MethodPost {
+				body = `{"data": {"viewer": {"login": "bot"}}}`
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(body)),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+
+	ep, err := resolveEndpoints("https://github.example.com/api/v3", "")
+	if err != nil {
+		t.Fatalf("resolveEndpoints() error = %v", err)
+	}
+	c := &Client{
+		cache:           mustNewDiskCache(t),
+		httpClient:      &http.Client{Transport: mockTransport},
+		token:           "test-token",
+		apiURL:          ep.api,
+		graphQLEndpoint: ep.graphQL,
+		host:            ep.host,
+	}
+
+	if _, err := c.searchPRCount(context.Background(), "is:pr author:alice"); err != nil {
+		t.Fatalf("searchPRCount() error = %v", err)
+	}
+	if _, err := c.MakeGraphQLRequest(context.Background(), "query { viewer { login } }", nil); err != nil {
+		t.Fatalf("MakeGraphQLRequest() error = %v", err)
+	}
+
+	if len(gotURLs) != 2 ||
+		!strings.HasPrefix(gotURLs[0], "https://github.example.com/api/v3/search/issues?") ||
+		gotURLs[1] != "https://github.example.com/api/graphql" {
+		t.Errorf("requested %q, want the enterprise server search and GraphQL endpoints", gotURLs)
+	}
+	if got := c.PRURL(PRRef{Owner: "owner", Repo: "repo", Number: 1}); got != "https://github.example.com/owner/repo/pull/1" {
+		t.Errorf("PRURL() = %s", got)
+	}
+}
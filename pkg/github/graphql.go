@@ -21,7 +21,21 @@ const (
 	graphQLEndpoint     = "https://api.github.com/graphql"
 )
 
-// MakeGraphQLRequest makes a GraphQL request to GitHub API.
+// GraphQLErrors is returned when a GraphQL response carries errors. In a batched query
+// one alias can fail while the others resolve, so Response keeps the whole response,
+// including whatever data GitHub returned alongside the errors.
+type GraphQLErrors struct {
+	Response map[string]any
+	Errors   any
+}
+
+// Error implements error.
+func (e *GraphQLErrors) Error() string {
+	return fmt.Sprintf("graphql errors: %v", e.Errors)
+}
+
+// MakeGraphQLRequest makes a GraphQL request to GitHub API. A response with errors is
+// returned as a *GraphQLErrors.
 func (c *Client) MakeGraphQLRequest(ctx context.Context, query string, variables map[string]any) (map[string]any, error) {
 	if err := validateGraphQLVariables(variables); err != nil {
 		return nil, fmt.Errorf("invalid GraphQL variables: %w", err)
@@ -53,23 +67,17 @@ func (c *Client) MakeGraphQLRequest(ctx context.Context, query string, variables
 
 	var result map[string]any
 	err = retryWithBackoff(ctx, fmt.Sprintf("GraphQL %s query", queryType), func() error {
-		req, err := http.NewRequestWithContext(ctx, http.MethodPost, graphQLEndpoint, bytes.NewReader(bodyBytes))
-		if err != nil {
-			return fmt.Errorf("failed to create GraphQL request: %w", err)
+		limitKey := c.rateLimitKey(ctx)
+		if err := c.rateLimiter.Wait(ctx, limitKey, "graphql"); err != nil {
+			return err
 		}
 
-		authToken := c.token
-		if c.isAppAuth && c.currentOrg != "" {
-			installToken, err := c.getInstallationToken(ctx, c.currentOrg)
-			if err == nil {
-				authToken = installToken
-				slog.DebugContext(ctx, "Using installation token for GraphQL", "org", c.currentOrg)
-			} else {
-				slog.WarnContext(ctx, "Failed to get installation token for GraphQL, using JWT", "org", c.currentOrg, "error", err)
-			}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.graphQLURL(), bytes.NewReader(bodyBytes))
+		if err != nil {
+			return fmt.Errorf("failed to create GraphQL request: %w", err)
 		}
 
-		req.Header.Set("Authorization", "Bearer "+authToken)
+		req.Header.Set("Authorization", "Bearer "+c.authToken(ctx))
 		req.Header.Set("Content-Type", "application/json")
 
 		resp, err := c.httpClient.Do(req)
@@ -82,23 +90,34 @@ func (c *Client) MakeGraphQLRequest(ctx context.Context, query string, variables
 			}
 		}()
 
+		c.rateLimiter.Update(limitKey, "graphql", resp.Header)
+		if c.rateLimiter.Limited(limitKey, resp) {
+			slog.WarnContext(ctx, "GraphQL query rate limited - will retry once the limit allows", "type", queryType, "status", resp.StatusCode)
+			return errRateLimited(resp.StatusCode)
+		}
+
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
 			return fmt.Errorf("failed to read response: %w", err)
 		}
 
 		if resp.StatusCode != http.StatusOK {
-			slog.ErrorContext(ctx, "GraphQL query failed", "type", queryType, "status", resp.StatusCode, "org", c.currentOrg, "body", string(body))
+			slog.ErrorContext(ctx, "GraphQL query failed", "type", queryType, "status", resp.StatusCode, "org", c.org(ctx), "body", string(body))
 			return fmt.Errorf("graphql request failed with status %d: %s", resp.StatusCode, string(body))
 		}
 
 		if err := json.Unmarshal(body, &result); err != nil {
 			return fmt.Errorf("failed to decode GraphQL response: %w", err)
 		}
+		c.rateLimiter.UpdateGraphQL(limitKey, result)
 
 		if errors, ok := result["errors"]; ok {
-			slog.ErrorContext(ctx, "GraphQL query returned errors", "type", queryType, "org", c.currentOrg, "errors", errors)
-			return fmt.Errorf("graphql errors: %v", errors)
+			if graphQLRateLimited(errors) {
+				slog.WarnContext(ctx, "GraphQL query rate limited - will retry once the limit allows", "type", queryType, "org", c.org(ctx))
+				return errRateLimited(resp.StatusCode)
+			}
+			slog.ErrorContext(ctx, "GraphQL query returned errors", "type", queryType, "org", c.org(ctx), "errors", errors)
+			return &GraphQLErrors{Response: result, Errors: errors}
 		}
 
 		return nil
@@ -2,6 +2,7 @@ package github
 
 import (
 	"context"
+	"errors"
 	"io"
 	"net/http"
 	"strings"
@@ -150,6 +151,33 @@ func TestClient_MakeGraphQLRequest_GraphQLErrors(t *testing.T) {
 	}
 }
 
+func TestClient_MakeGraphQLRequest_PartialData(t *testing.T) {
+	// One alias of a batched query fails while the other resolves
+	responseBody := `{
+		"data": {"a": {"login": "alice"}, "b": null},
+		"errors": [{"path": ["b"], "message": "Could not resolve to a User with the login of 'ghost'."}]
+	}`
+	c := &Client{
+		cache: mustNewDiskCache(t),
+		httpClient: &http.Client{Transport: &mockRoundTripper{response: &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(strings.NewReader(responseBody)),
+			Header:     make(http.Header),
+		}}},
+		token: "test-token",
+	}
+
+	_, err := c.MakeGraphQLRequest(context.Background(), "query { a: user(login: \"alice\") { login } b: user(login: \"ghost\") { login } }", nil)
+	var gqlErr *GraphQLErrors
+	if !errors.As(err, &gqlErr) {
+		t.Fatalf("MakeGraphQLRequest() error = %v, want *GraphQLErrors", err)
+	}
+	data, _ := gqlErr.Response["data"].(map[string]any)
+	if a, _ := data["a"].(map[string]any); a["login"] != "alice" {
+		t.Errorf("Response data = %v, want the resolved alias kept", data)
+	}
+}
+
 func TestClient_MakeGraphQLRequest_InvalidJSONResponse(t *testing.T) {
 	mockTransport := &mockRoundTripper{
 		response: &http.Response{
@@ -0,0 +1,139 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync/atomic"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/cache"
+)
+
+// httpCacheTTL is how long a response is kept for revalidation. Entries are revalidated on
+// every use, so this only bounds how long an unused response takes up space.
+const httpCacheTTL = 24 * time.Hour
+
+// httpCacheEntry is a cached REST response body with the validators GitHub sent for it.
+type httpCacheEntry struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"last_modified,omitempty"`
+	Body         []byte `json:"body"`
+}
+
+// HTTPCacheStats counts how conditional REST requests were answered.
+type HTTPCacheStats struct {
+	Hits   int64 `json:"hits"`   // 304 Not Modified, served from cache without using rate limit budget
+	Misses int64 `json:"misses"` // Full 200 responses stored for later revalidation
+}
+
+// httpCache makes REST GET requests conditional: responses are stored with their ETag or
+// Last-Modified, later requests send If-None-Match or If-Modified-Since, and a 304 Not
+// Modified is answered with the stored body. A nil httpCache caches nothing.
+type httpCache struct {
+	cache  *cache.DiskCache
+	hits   atomic.Int64
+	misses atomic.Int64
+}
+
+// newHTTPCache creates an HTTP cache storing responses in c.
+func newHTTPCache(c *cache.DiskCache) *httpCache {
+	return &httpCache{cache: c}
+}
+
+// key returns the cache key for a GET of apiURL. Responses depend on what the token may
+// see, so each token has its own entries.
+func (*httpCache) key(limitKey, apiURL string) string {
+	return makeCacheKey("http", limitKey, apiURL)
+}
+
+// entry returns the stored response for key. Entries restored from disk come back as
+// generic JSON and are decoded again.
+func (h *httpCache) entry(key string) (*httpCacheEntry, bool) {
+	if h == nil {
+		return nil, false
+	}
+	cached, found := h.cache.Get(key)
+	if !found {
+		return nil, false
+	}
+	if e, ok := cached.(*httpCacheEntry); ok {
+		return e, true
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return nil, false
+	}
+	var e httpCacheEntry
+	if err := json.Unmarshal(data, &e); err != nil || (e.ETag == "" && e.LastModified == "") {
+		return nil, false
+	}
+	return &e, true
+}
+
+// prepare adds conditional headers to req if a response for key is stored, returning the entry.
+func (h *httpCache) prepare(req *http.Request, key string) *httpCacheEntry {
+	e, ok := h.entry(key)
+	if !ok {
+		return nil
+	}
+	if e.ETag != "" {
+		req.Header.Set("If-None-Match", e.ETag)
+	}
+	if e.LastModified != "" {
+		req.Header.Set("If-Modified-Since", e.LastModified)
+	}
+	return e
+}
+
+// handle turns a 304 for a stored entry into the stored 200 response, and stores 200
+// responses that carry a validator. Other responses are returned unchanged.
+func (h *httpCache) handle(key string, e *httpCacheEntry, resp *http.Response) (*http.Response, error) {
+	if h == nil {
+		return resp, nil
+	}
+
+	if resp.StatusCode == http.StatusNotModified && e != nil {
+		drainAndCloseBody(resp.Body)
+		h.hits.Add(1)
+		slog.Debug("HTTP cache hit", "component", "http", "key", key)
+		resp.StatusCode = http.StatusOK
+		resp.Status = fmt.Sprintf("%d %s", http.StatusOK, http.StatusText(http.StatusOK))
+		resp.Body = io.NopCloser(bytes.NewReader(e.Body))
+		resp.ContentLength = int64(len(e.Body))
+		return resp, nil
+	}
+
+	etag, lastModified := resp.Header.Get("ETag"), resp.Header.Get("Last-Modified")
+	if resp.StatusCode != http.StatusOK || (etag == "" && lastModified == "") {
+		return resp, nil
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if closeErr := resp.Body.Close(); closeErr != nil {
+		slog.Warn("Failed to close response body", "error", closeErr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read response body: %w", err)
+	}
+	h.cache.SetWithTTL(key, &httpCacheEntry{ETag: etag, LastModified: lastModified, Body: body}, httpCacheTTL)
+	h.misses.Add(1)
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// stats returns the hit and miss counts so far.
+func (h *httpCache) stats() HTTPCacheStats {
+	if h == nil {
+		return HTTPCacheStats{}
+	}
+	return HTTPCacheStats{Hits: h.hits.Load(), Misses: h.misses.Load()}
+}
+
+// HTTPCacheStats returns how many conditional REST requests were answered from cache.
+func (c *Client) HTTPCacheStats() HTTPCacheStats {
+	return c.httpCache.stats()
+}
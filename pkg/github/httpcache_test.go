@@ -0,0 +1,154 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/cache"
+)
+
+func TestClient_ConditionalRequests(t *testing.T) {
+	const etag = `"abc123"`
+	const apiURL = "https://api.github.com/repos/owner/repo/pulls/1/files?per_page=100"
+
+	var conditional []string
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			conditional = append(conditional, req.Header.Get("If-None-Match"))
+			if req.Header.Get("If-None-Match") == etag {
+				return &http.Response{
+					StatusCode: http.StatusNotModified,
+					Body:       io.NopCloser(strings.NewReader("")),
+					Header:     make(http.Header),
+				}, nil
+			}
+			h := make(http.Header)
+			h.Set("ETag", etag)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`[{"filename": "main.go"}]`)),
+				Header:     h,
+			}, nil
+		},
+	}
+
+	diskCache := mustNewDiskCache(t)
+	c := &Client{
+		cache:      diskCache,
+		httpClient: &http.Client{Transport: mockTransport},
+		token:      "test-token",
+		httpCache:  newHTTPCache(diskCache),
+	}
+
+	for range 2 {
+		resp, err := c.MakeRequest(context.Background(), http.MethodGet, apiURL, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("failed to read body: %v", err)
+		}
+		drainAndCloseBody(resp.Body)
+		if resp.StatusCode != http.StatusOK || string(body) != `[{"filename": "main.go"}]` {
+			t.Errorf("got status %d body %q", resp.StatusCode, body)
+		}
+	}
+
+	if len(conditional) != 2 || conditional[0] != "" || conditional[1] != etag {
+		t.Errorf("If-None-Match headers = %q, want none then %s", conditional, etag)
+	}
+	if got := c.HTTPCacheStats(); got.Hits != 1 || got.Misses != 1 {
+		t.Errorf("HTTPCacheStats() = %+v, want 1 hit and 1 miss", got)
+	}
+}
+
+func TestClient_ConditionalRequests_SkipsWrites(t *testing.T) {
+	var conditional bool
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			conditional = conditional || req.Header.Get("If-None-Match") != ""
+			h := make(http.Header)
+			h.Set("ETag", `"abc123"`)
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       io.NopCloser(strings.NewReader(`{}`)),
+				Header:     h,
+			}, nil
+		},
+	}
+
+	diskCache := mustNewDiskCache(t)
+	c := &Client{
+		cache:      diskCache,
+		httpClient: &http.Client{Transport: mockTransport},
+		token:      "test-token",
+		httpCache:  newHTTPCache(diskCache),
+	}
+
+	for range 2 {
+		if err := c.AddReviewers(context.Background(), "owner", "repo", 1, []string{"alice"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if conditional {
+		t.Error("expected no conditional headers on POST")
+	}
+	if got := c.HTTPCacheStats(); got != (HTTPCacheStats{}) {
+		t.Errorf("HTTPCacheStats() = %+v, want none", got)
+	}
+}
+
+func TestHTTPCache_MissesCountStoredResponses(t *testing.T) {
+	tests := []struct {
+		name       string
+		status     int
+		etag       string
+		wantMisses int64
+	}{
+		{name: "stored", status: http.StatusOK, etag: `"abc"`, wantMisses: 1},
+		{name: "no validator", status: http.StatusOK},
+		{name: "not found", status: http.StatusNotFound, etag: `"abc"`},
+		{name: "server error", status: http.StatusBadGateway},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := newHTTPCache(mustNewDiskCache(t))
+			resp := &http.Response{StatusCode: tt.status, Body: io.NopCloser(strings.NewReader(`{}`)), Header: make(http.Header)}
+			if tt.etag != "" {
+				resp.Header.Set("ETag", tt.etag)
+			}
+			if _, err := h.handle(h.key("token", "https://api.github.com/repos/owner/repo"), nil, resp); err != nil {
+				t.Fatalf("handle() error = %v", err)
+			}
+			if got := h.stats(); got.Misses != tt.wantMisses || got.Hits != 0 {
+				t.Errorf("stats() = %+v, want %d misses", got, tt.wantMisses)
+			}
+		})
+	}
+}
+
+func TestHTTPCache_EntryFromDisk(t *testing.T) {
+	dir := t.TempDir()
+	diskCache, err := cache.NewDiskCache(httpCacheTTL, dir)
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	h := newHTTPCache(diskCache)
+	key := h.key("token", "https://api.github.com/repos/owner/repo")
+	diskCache.SetWithTTL(key, &httpCacheEntry{ETag: `W/"x"`, Body: []byte(`{"id": 1}`)}, httpCacheTTL)
+
+	// A new cache over the same directory only has the JSON written to disk
+	reloaded, err := cache.NewDiskCache(httpCacheTTL, dir)
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	e, ok := newHTTPCache(reloaded).entry(key)
+	if !ok || e.ETag != `W/"x"` || string(e.Body) != `{"id": 1}` {
+		t.Errorf("entry() = %+v, %v", e, ok)
+	}
+}
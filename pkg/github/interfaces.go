@@ -45,14 +45,22 @@ type API interface {
 	ChangedFiles(ctx context.Context, owner, repo string, prNumber int) ([]types.ChangedFile, error)
 	FilePatch(ctx context.Context, owner, repo string, prNumber int, filename string) (string, error)
 	AddReviewers(ctx context.Context, owner, repo string, prNumber int, reviewers []string) error
+	AddReviewRequests(ctx context.Context, owner, repo string, prNumber int, users, teams []string) error
+	UpsertComment(ctx context.Context, owner, repo string, prNumber int, marker, body string) error
 
 	// User operations
 	IsUserBot(ctx context.Context, username string) bool
 	HasWriteAccess(ctx context.Context, owner, repo, username string) bool
 	OpenPRCount(ctx context.Context, org, user string, cacheTTL time.Duration) (int, error)
-	BatchOpenPRCount(ctx context.Context, org string, users []string, cacheTTL time.Duration) (map[string]int, error)
+	BatchOpenPRCount(ctx context.Context, org string, users []string, cacheTTL time.Duration) (map[string]types.OpenPRCount, error)
+	ReviewResponseTimes(ctx context.Context, org string, users []string) (map[string]types.ResponseTime, error)
 	Collaborators(ctx context.Context, owner, repo string) ([]string, error)
 
+	// Repository operations
+	FileContent(ctx context.Context, owner, repo, path, ref string) (string, error)
+	TeamMembers(ctx context.Context, org, teamSlug string) ([]string, error)
+	MergeBase(ctx context.Context, owner, repo, base, head string) (string, error)
+
 	// GraphQL operations
 	MakeGraphQLRequest(ctx context.Context, query string, variables map[string]any) (map[string]any, error)
 
@@ -5,11 +5,9 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"io"
 	"log/slog"
 	"net/http"
 	"net/url"
-	"strings"
 	"time"
 
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/cache"
@@ -46,7 +44,7 @@ func (c *Client) pullRequestWithUpdatedAt(
 	}
 
 	slog.Info("Fetching PR details to get title, state, author, assignees, reviewers, and metadata", "component", "api", "owner", owner, "repo", repo, "pr", prNumber)
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d", owner, repo, prNumber)
+	apiURL := c.api("/repos/%s/%s/pulls/%d", owner, repo, prNumber)
 	resp, err := c.doRequest(ctx, "GET", apiURL, nil)
 	if err != nil {
 		return nil, err
@@ -72,12 +70,21 @@ func (c *Client) pullRequestWithUpdatedAt(
 		Head struct {
 			SHA string `json:"sha"`
 		} `json:"head"`
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
 		Assignees []struct {
 			Login string `json:"login"`
 		} `json:"assignees"`
 		RequestedReviewers []struct {
 			Login string `json:"login"`
 		} `json:"requested_reviewers"`
+		RequestedTeams []struct {
+			Slug string `json:"slug"`
+		} `json:"requested_teams"`
+		Labels []struct {
+			Name string `json:"name"`
+		} `json:"labels"`
 		Number int  `json:"number"`
 		Draft  bool `json:"draft"`
 	}
@@ -102,23 +109,37 @@ func (c *Client) pullRequestWithUpdatedAt(
 		reviewers = append(reviewers, reviewer.Login)
 	}
 
+	var reviewerTeams []string
+	for _, team := range prData.RequestedTeams {
+		reviewerTeams = append(reviewerTeams, team.Slug)
+	}
+
 	var assignees []string
 	for _, assignee := range prData.Assignees {
 		assignees = append(assignees, assignee.Login)
 	}
 
+	var labels []string
+	for _, label := range prData.Labels {
+		labels = append(labels, label.Name)
+	}
+
 	pr := &types.PullRequest{
-		Number:     prData.Number,
-		Title:      prData.Title,
-		State:      prData.State,
-		Draft:      prData.Draft,
-		Author:     prData.User.Login,
-		Assignees:  assignees,
-		CreatedAt:  createdAt,
-		UpdatedAt:  updatedAt,
-		Repository: repo,
-		Owner:      owner,
-		Reviewers:  reviewers,
+		Number:        prData.Number,
+		Title:         prData.Title,
+		State:         prData.State,
+		Draft:         prData.Draft,
+		Author:        prData.User.Login,
+		Assignees:     assignees,
+		CreatedAt:     createdAt,
+		UpdatedAt:     updatedAt,
+		Repository:    repo,
+		Owner:         owner,
+		Reviewers:     reviewers,
+		ReviewerTeams: reviewerTeams,
+		Labels:        labels,
+		BaseRef:       prData.Base.Ref,
+		HeadSHA:       prData.Head.SHA,
 	}
 
 	// Get changed files
@@ -159,80 +180,20 @@ func (c *Client) OpenPullRequestsForOrg(ctx context.Context, org string) ([]*typ
 	query := fmt.Sprintf("is:pr is:open org:%s review:none", org)
 	slog.Info("Searching for open PRs without reviewers across organization", "org", org)
 
-	allPRs := make([]*types.PullRequest, 0, 100) // Pre-allocate for typical case
-	page := 1
-	perPage := 100
-
-	for {
-		encodedQuery := url.QueryEscape(query)
-		apiURL := fmt.Sprintf("https://api.github.com/search/issues?q=%s&per_page=%d&page=%d", encodedQuery, perPage, page)
+	refs, err := c.SearchPullRequests(ctx, query, 0)
+	if err != nil {
+		return nil, err
+	}
 
-		resp, err := c.doRequest(ctx, "GET", apiURL, nil) //nolint:bodyclose // body is closed immediately, not deferred
+	allPRs := make([]*types.PullRequest, 0, len(refs))
+	for _, ref := range refs {
+		// Fetch full PR details (this uses prx which has caching and retry)
+		pr, err := c.PullRequest(ctx, ref.Owner, ref.Repo, ref.Number)
 		if err != nil {
-			return nil, fmt.Errorf("failed to search PRs: %w", err)
-		}
-
-		if resp.StatusCode != http.StatusOK {
-			body, err := io.ReadAll(resp.Body)
-			drainAndCloseBody(resp.Body)
-			if err != nil {
-				return nil, fmt.Errorf("failed to search PRs: status %d (could not read body: %w)", resp.StatusCode, err)
-			}
-			return nil, fmt.Errorf("failed to search PRs: status %d: %s", resp.StatusCode, string(body))
-		}
-
-		var searchResult struct {
-			Items []struct {
-				Title         string `json:"title"`
-				State         string `json:"state"`
-				UpdatedAt     string `json:"updated_at"`
-				RepositoryURL string `json:"repository_url"`
-				PullRequest   struct {
-					URL string `json:"url"`
-				} `json:"pull_request"`
-				Number int  `json:"number"`
-				Draft  bool `json:"draft"`
-			} `json:"items"`
-			TotalCount int `json:"total_count"`
-		}
-
-		if err := json.NewDecoder(resp.Body).Decode(&searchResult); err != nil {
-			drainAndCloseBody(resp.Body)
-			return nil, fmt.Errorf("failed to decode search results: %w", err)
-		}
-		drainAndCloseBody(resp.Body)
-
-		slog.Debug("Search results page", "org", org, "page", page, "items", len(searchResult.Items), "total_count", searchResult.TotalCount)
-
-		if len(searchResult.Items) == 0 {
-			break
-		}
-
-		// For each PR, we need to fetch full details
-		for _, item := range searchResult.Items {
-			// Extract repo from repository_url: https://api.github.com/repos/owner/repo
-			parts := strings.Split(item.RepositoryURL, "/")
-			if len(parts) < 2 {
-				slog.Warn("Invalid repository URL", "url", item.RepositoryURL, "org", org)
-				continue
-			}
-			repo := parts[len(parts)-1]
-
-			// Fetch full PR details (this uses prx which has caching and retry)
-			pr, err := c.PullRequest(ctx, org, repo, item.Number)
-			if err != nil {
-				slog.Warn("Failed to fetch PR details", "org", org, "repo", repo, "pr", item.Number, "error", err)
-				continue
-			}
-
-			allPRs = append(allPRs, pr)
+			slog.Warn("Failed to fetch PR details", "org", org, "repo", ref.Repo, "pr", ref.Number, "error", err)
+			continue
 		}
-
-		if len(searchResult.Items) < perPage {
-			break
-		}
-
-		page++
+		allPRs = append(allPRs, pr)
 	}
 
 	slog.Info("Found open PRs for org", "org", org, "count", len(allPRs))
@@ -247,7 +208,7 @@ func (c *Client) OpenPullRequests(ctx context.Context, owner, repo string) ([]*t
 
 	for {
 		slog.Info("Requesting page of open PRs (pagination)", "component", "api", "owner", owner, "repo", repo, "page", page)
-		apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls?state=open&per_page=100&page=%d", owner, repo, page)
+		apiURL := c.api("/repos/%s/%s/pulls?state=open&per_page=100&page=%d", owner, repo, page)
 
 		// Extract API call to avoid defer in loop
 		prs, shouldBreak, err := func() ([]json.RawMessage, bool, error) {
@@ -319,7 +280,7 @@ func (c *Client) ChangedFiles(ctx context.Context, owner, repo string, prNumber
 	}
 
 	slog.Info("Fetching changed files for PR to determine modified files for reviewer expertise matching", "component", "api", "owner", owner, "repo", repo, "pr", prNumber, "cache", "miss")
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d/files?per_page=100", owner, repo, prNumber)
+	apiURL := c.api("/repos/%s/%s/pulls/%d/files?per_page=100", owner, repo, prNumber)
 	resp, err := c.doRequest(ctx, "GET", apiURL, nil)
 	if err != nil {
 		return nil, err
@@ -331,11 +292,12 @@ func (c *Client) ChangedFiles(ctx context.Context, owner, repo string, prNumber
 	}()
 
 	var files []struct {
-		Filename  string `json:"filename"`
-		Status    string `json:"status"`
-		Patch     string `json:"patch"`
-		Additions int    `json:"additions"`
-		Deletions int    `json:"deletions"`
+		Filename         string `json:"filename"`
+		PreviousFilename string `json:"previous_filename"`
+		Status           string `json:"status"`
+		Patch            string `json:"patch"`
+		Additions        int    `json:"additions"`
+		Deletions        int    `json:"deletions"`
 	}
 
 	if err := json.NewDecoder(resp.Body).Decode(&files); err != nil {
@@ -345,11 +307,12 @@ func (c *Client) ChangedFiles(ctx context.Context, owner, repo string, prNumber
 	changedFiles := make([]types.ChangedFile, 0, len(files))
 	for _, f := range files {
 		changedFiles = append(changedFiles, types.ChangedFile{
-			Filename:  f.Filename,
-			Status:    f.Status,
-			Additions: f.Additions,
-			Deletions: f.Deletions,
-			Patch:     f.Patch,
+			Filename:         f.Filename,
+			PreviousFilename: f.PreviousFilename,
+			Status:           f.Status,
+			Additions:        f.Additions,
+			Deletions:        f.Deletions,
+			Patch:            f.Patch,
 		})
 	}
 
@@ -366,7 +329,7 @@ func (c *Client) ChangedFiles(ctx context.Context, owner, repo string, prNumber
 // lastCommitTime returns the timestamp of the last commit.
 func (c *Client) lastCommitTime(ctx context.Context, owner, repo, sha string) (time.Time, error) {
 	slog.Info("Fetching commit details to get last commit timestamp for PR staleness analysis", "component", "api", "owner", owner, "repo", repo, "sha", sha)
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/commits/%s", owner, repo, sha)
+	apiURL := c.api("/repos/%s/%s/commits/%s", owner, repo, sha)
 	resp, err := c.doRequest(ctx, "GET", apiURL, nil)
 	if err != nil {
 		return time.Time{}, err
@@ -395,7 +358,7 @@ func (c *Client) lastCommitTime(ctx context.Context, owner, repo, sha string) (t
 // lastReviewTime returns the timestamp of the last review.
 func (c *Client) lastReviewTime(ctx context.Context, owner, repo string, prNumber int) (time.Time, error) {
 	slog.Info("Fetching review history for PR to determine last review timestamp for staleness detection", "component", "api", "owner", owner, "repo", repo, "pr", prNumber)
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/pulls/%d/reviews", owner, repo, prNumber)
+	apiURL := c.api("/repos/%s/%s/pulls/%d/reviews", owner, repo, prNumber)
 	resp, err := c.doRequest(ctx, "GET", apiURL, nil)
 	if err != nil {
 		return time.Time{}, err
@@ -427,6 +390,61 @@ func (c *Client) lastReviewTime(ctx context.Context, owner, repo string, prNumbe
 	return lastTime, nil
 }
 
+// requestedTeams returns the slugs of teams with pending review requests on a PR.
+func (c *Client) requestedTeams(ctx context.Context, owner, repo string, prNumber int) ([]string, error) {
+	slog.Info("Fetching requested reviewers for PR to determine pending team review requests", "component", "api", "owner", owner, "repo", repo, "pr", prNumber)
+	apiURL := c.api("/repos/%s/%s/pulls/%d/requested_reviewers", owner, repo, prNumber)
+	resp, err := c.doRequest(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer drainAndCloseBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to get requested reviewers (status %d)", resp.StatusCode)
+	}
+
+	var requested struct {
+		Teams []struct {
+			Slug string `json:"slug"`
+		} `json:"teams"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&requested); err != nil {
+		return nil, err
+	}
+
+	var teams []string
+	for _, team := range requested.Teams {
+		teams = append(teams, team.Slug)
+	}
+	return teams, nil
+}
+
+// baseRef returns the name of the branch a PR merges into.
+func (c *Client) baseRef(ctx context.Context, owner, repo string, prNumber int) (string, error) {
+	slog.Info("Fetching PR base branch to blame changed lines against the branch it merges into", "component", "api", "owner", owner, "repo", repo, "pr", prNumber)
+	apiURL := c.api("/repos/%s/%s/pulls/%d", owner, repo, prNumber)
+	resp, err := c.doRequest(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return "", err
+	}
+	defer drainAndCloseBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to get PR (status %d)", resp.StatusCode)
+	}
+
+	var prData struct {
+		Base struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&prData); err != nil {
+		return "", err
+	}
+	return prData.Base.Ref, nil
+}
+
 // FilePatch returns the patch for a specific file in a PR.
 func (c *Client) FilePatch(ctx context.Context, owner, repo string, prNumber int, filename string) (string, error) {
 	files, err := c.ChangedFiles(ctx, owner, repo, prNumber)
@@ -452,6 +470,7 @@ func (c *Client) convertPrxToPullRequest(ctx context.Context, owner, repo string
 		UpdatedAt          time.Time
 		RequestedReviewers []string
 		Assignees          []string
+		Labels             []string
 		Title              string
 		State              string
 		Author             string
@@ -489,6 +508,8 @@ func (c *Client) convertPrxToPullRequest(ctx context.Context, owner, repo string
 		TestState:  data.PullRequest.TestState,
 		Reviewers:  data.PullRequest.RequestedReviewers,
 		Assignees:  data.PullRequest.Assignees,
+		Labels:     data.PullRequest.Labels,
+		HeadSHA:    data.PullRequest.HeadSHA,
 		// These fields will be populated by separate API calls if needed
 		LastCommit:   time.Time{},
 		LastReview:   time.Time{},
@@ -513,6 +534,22 @@ func (c *Client) convertPrxToPullRequest(ctx context.Context, owner, repo string
 		}
 	}
 
+	// prx doesn't report team review requests, so fetch them separately
+	teams, err := c.requestedTeams(ctx, owner, repo, data.PullRequest.Number)
+	if err != nil {
+		slog.Warn("Failed to fetch requested teams for prx PR", "error", err, "owner", owner, "repo", repo, "pr", data.PullRequest.Number)
+	} else {
+		pr.ReviewerTeams = teams
+	}
+
+	// prx doesn't report the base branch either, which blame needs for PRs not targeting the default branch
+	baseRef, err := c.baseRef(ctx, owner, repo, data.PullRequest.Number)
+	if err != nil {
+		slog.Warn("Failed to fetch base branch for prx PR", "error", err, "owner", owner, "repo", repo, "pr", data.PullRequest.Number)
+	} else {
+		pr.BaseRef = baseRef
+	}
+
 	// Fetch last review time separately
 	lastReview, err := c.lastReviewTime(ctx, owner, repo, data.PullRequest.Number)
 	if err != nil {
@@ -523,3 +560,34 @@ func (c *Client) convertPrxToPullRequest(ctx context.Context, owner, repo string
 
 	return pr, nil
 }
+
+// OpenPullRequestsForCommit returns the numbers of open PRs in the repository whose head includes sha.
+func (c *Client) OpenPullRequestsForCommit(ctx context.Context, owner, repo, sha string) ([]int, error) {
+	slog.Info("Fetching PRs for commit", "component", "api", "owner", owner, "repo", repo, "sha", sha)
+	apiURL := c.api("/repos/%s/%s/commits/%s/pulls", owner, repo, url.PathEscape(sha))
+	resp, err := c.doRequest(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch PRs for commit: %w", err)
+	}
+	defer drainAndCloseBody(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch PRs for commit (status %d)", resp.StatusCode)
+	}
+
+	var prs []struct {
+		State  string `json:"state"`
+		Number int    `json:"number"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&prs); err != nil {
+		return nil, fmt.Errorf("failed to decode PRs for commit: %w", err)
+	}
+
+	var numbers []int
+	for _, pr := range prs {
+		if pr.State == "open" {
+			numbers = append(numbers, pr.Number)
+		}
+	}
+	return numbers, nil
+}
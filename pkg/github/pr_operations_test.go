@@ -2,10 +2,13 @@ package github
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"strings"
 	"sync"
 	"testing"
@@ -34,7 +37,9 @@ func TestClient_PullRequest_Success(t *testing.T) {
 					"draft": false,
 					"head": {"sha": "abc123"},
 					"assignees": [{"login": "assignee1"}],
-					"requested_reviewers": [{"login": "reviewer1"}]
+					"requested_reviewers": [{"login": "reviewer1"}],
+					"requested_teams": [{"slug": "core"}],
+					"labels": [{"name": "wip"}]
 				}`
 			} else {
 				// Second call: changed files
@@ -86,6 +91,12 @@ func TestClient_PullRequest_Success(t *testing.T) {
 	if len(pr.ChangedFiles) != 1 {
 		t.Errorf("expected 1 changed file, got %d", len(pr.ChangedFiles))
 	}
+	if !reflect.DeepEqual(pr.ReviewerTeams, []string{"core"}) {
+		t.Errorf("expected requested team 'core', got %v", pr.ReviewerTeams)
+	}
+	if !reflect.DeepEqual(pr.Labels, []string{"wip"}) {
+		t.Errorf("expected label 'wip', got %v", pr.Labels)
+	}
 }
 
 // mockRoundTripperFunc allows custom function-based mocking
@@ -513,6 +524,44 @@ func TestClient_AddReviewers_Error(t *testing.T) {
 	}
 }
 
+func TestClient_AddReviewRequests_Teams(t *testing.T) {
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			var payload struct {
+				Reviewers     []string `json:"reviewers"`
+				TeamReviewers []string `json:"team_reviewers"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&payload); err != nil {
+				t.Fatalf("failed to decode payload: %v", err)
+			}
+			if !reflect.DeepEqual(payload.Reviewers, []string{"alice"}) {
+				t.Errorf("unexpected reviewers: %v", payload.Reviewers)
+			}
+			if !reflect.DeepEqual(payload.TeamReviewers, []string{"core"}) {
+				t.Errorf("unexpected team reviewers: %v", payload.TeamReviewers)
+			}
+
+			return &http.Response{
+				StatusCode: http.StatusCreated,
+				Body:       io.NopCloser(strings.NewReader(`{"number": 123}`)),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+
+	c := &Client{
+		cache:      mustNewDiskCache(t),
+		httpClient: &http.Client{Transport: mockTransport},
+		token:      "test-token",
+		isAppAuth:  false,
+	}
+
+	err := c.AddReviewRequests(context.Background(), "owner", "repo", 123, []string{"alice"}, []string{"core"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+}
+
 func TestClient_AddReviewers_EmptyList(t *testing.T) {
 	c := &Client{
 		cache:      mustNewDiskCache(t),
@@ -1085,3 +1134,154 @@ func TestClient_ChangedFiles_CacheHit(t *testing.T) {
 		t.Errorf("expected no additional API calls (cache hit), got %d total calls", callCount)
 	}
 }
+
+func TestClient_FileContent_Success(t *testing.T) {
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/repos/owner/repo/contents/.github/CODEOWNERS" {
+				t.Errorf("unexpected path: %s", req.URL.Path)
+			}
+			if ref := req.URL.Query().Get("ref"); ref != "release/1.2" {
+				t.Errorf("ref = %q, want release/1.2", ref)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				// "* @alice\n" base64-encoded
+				Body:   io.NopCloser(strings.NewReader(`{"type": "file", "encoding": "base64", "content": "KiBAYWxp\nY2UK\n"}`)),
+				Header: make(http.Header),
+			}, nil
+		},
+	}
+
+	c := &Client{
+		cache:      mustNewDiskCache(t),
+		httpClient: &http.Client{Transport: mockTransport},
+		token:      "test-token",
+	}
+
+	content, err := c.FileContent(context.Background(), "owner", "repo", ".github/CODEOWNERS", "release/1.2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if content != "* @alice\n" {
+		t.Errorf("unexpected content: %q", content)
+	}
+}
+
+func TestClient_FileContent_NotFound(t *testing.T) {
+	callCount := 0
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			callCount++
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(strings.NewReader(`{"message": "Not Found"}`)),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+
+	c := &Client{
+		cache:      mustNewDiskCache(t),
+		httpClient: &http.Client{Transport: mockTransport},
+		token:      "test-token",
+	}
+
+	for range 2 {
+		if _, err := c.FileContent(context.Background(), "owner", "repo", "CODEOWNERS", ""); !errors.Is(err, ErrNotFound) {
+			t.Fatalf("expected ErrNotFound, got %v", err)
+		}
+	}
+	if callCount != 1 {
+		t.Errorf("expected missing file to be cached, got %d calls", callCount)
+	}
+}
+
+func TestClient_TeamMembers_Success(t *testing.T) {
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			if req.URL.Path != "/orgs/acme/teams/core/members" {
+				t.Errorf("unexpected path: %s", req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(strings.NewReader(`[
+					{"login": "alice", "type": "User"},
+					{"login": "ci-bot", "type": "Bot"}
+				]`)),
+				Header: make(http.Header),
+			}, nil
+		},
+	}
+
+	dir := t.TempDir()
+	diskCache, err := cache.NewDiskCache(time.Hour, dir)
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	c := &Client{
+		cache:      diskCache,
+		httpClient: &http.Client{Transport: mockTransport},
+		token:      "test-token",
+	}
+
+	members, err := c.TeamMembers(context.Background(), "acme", "core")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(members) != 1 || members[0] != "alice" {
+		t.Errorf("expected [alice], got %v", members)
+	}
+
+	// A new cache over the same directory only has the JSON written to disk
+	reloaded, err := cache.NewDiskCache(time.Hour, dir)
+	if err != nil {
+		t.Fatalf("failed to create cache: %v", err)
+	}
+	c = &Client{
+		cache: reloaded,
+		httpClient: &http.Client{Transport: &mockRoundTripperFunc{roundTripFunc: func(*http.Request) (*http.Response, error) {
+			return nil, errors.New("unexpected request after reload")
+		}}},
+		token: "test-token",
+	}
+	if members, err := c.TeamMembers(context.Background(), "acme", "core"); err != nil || len(members) != 1 || members[0] != "alice" {
+		t.Errorf("TeamMembers() after reload = %v, %v, want [alice] from cache", members, err)
+	}
+}
+
+func TestClient_MergeBase_Success(t *testing.T) {
+	calls := 0
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			calls++
+			if req.URL.Path != "/repos/owner/repo/compare/release/1.2...abc123" {
+				t.Errorf("unexpected path: %s", req.URL.Path)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"merge_base_commit": {"sha": "def456"}}`)),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+
+	c := &Client{
+		cache:      mustNewDiskCache(t),
+		httpClient: &http.Client{Transport: mockTransport},
+		token:      "test-token",
+	}
+
+	for range 2 {
+		sha, err := c.MergeBase(context.Background(), "owner", "repo", "release/1.2", "abc123")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sha != "def456" {
+			t.Errorf("expected def456, got %s", sha)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected 1 API call with caching, got %d", calls)
+	}
+}
@@ -0,0 +1,273 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Rate limit handling constants.
+const (
+	rateLimitReserve = 0.05             // Share of a budget below which requests are paced until it resets
+	maxRateLimitWait = 65 * time.Minute // Upper bound on a single wait, guarding against bogus reset times
+	defaultRetryWait = time.Minute      // Wait after a secondary rate limit without Retry-After
+)
+
+// RateLimit is the state of one GitHub rate limit budget as last reported by the API.
+type RateLimit struct {
+	Reset     time.Time `json:"reset"`
+	Updated   time.Time `json:"updated"`
+	Key       string    `json:"key"`      // Token the budget belongs to, e.g. "installation:myorg" or "token"
+	Resource  string    `json:"resource"` // "core", "graphql", "search", ...
+	Limit     int       `json:"limit"`
+	Remaining int       `json:"remaining"`
+}
+
+// RateLimiter tracks GitHub rate limits per token and resource, as reported by response
+// headers and GraphQL rateLimit fields. It paces requests when a budget runs low, waits
+// out exhausted budgets until they reset, and honors secondary rate limit Retry-After.
+// A nil RateLimiter never waits. It is safe for concurrent use.
+type RateLimiter struct {
+	now     func() time.Time
+	limits  map[string]*RateLimit // key + "/" + resource -> last reported state
+	blocked map[string]time.Time  // key -> end of a secondary rate limit
+	mu      sync.Mutex
+}
+
+// NewRateLimiter creates an empty rate limiter.
+func NewRateLimiter() *RateLimiter {
+	return &RateLimiter{
+		now:     time.Now,
+		limits:  make(map[string]*RateLimit),
+		blocked: make(map[string]time.Time),
+	}
+}
+
+// Wait blocks until a request for resource with the token identified by key may be made.
+func (r *RateLimiter) Wait(ctx context.Context, key, resource string) error {
+	d := r.delay(key, resource)
+	if d <= 0 {
+		return nil
+	}
+	slog.WarnContext(ctx, "Throttling GitHub requests to stay within rate limit", "key", key, "resource", resource, "wait", d.Round(time.Second))
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// delay returns how long to wait before the next request for resource with key.
+func (r *RateLimiter) delay(key, resource string) time.Duration {
+	if r == nil {
+		return 0
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	now := r.now()
+	var d time.Duration
+	if until, ok := r.blocked[key]; ok {
+		if now.Before(until) {
+			d = until.Sub(now)
+		} else {
+			delete(r.blocked, key)
+		}
+	}
+
+	if l, ok := r.limits[key+"/"+resource]; ok && l.Limit > 0 && now.Before(l.Reset) {
+		untilReset := l.Reset.Sub(now) + time.Second // Reset times have second precision
+		switch {
+		case l.Remaining <= 0:
+			d = max(d, untilReset)
+		case float64(l.Remaining) <= rateLimitReserve*float64(l.Limit):
+			// Spread what is left over the rest of the window instead of stalling once it runs out
+			d = max(d, untilReset/time.Duration(l.Remaining+1))
+		default:
+		}
+	}
+	return min(d, maxRateLimitWait)
+}
+
+// Update records the rate limit state reported by a response's X-RateLimit headers.
+// resource is used when the response does not name its resource.
+func (r *RateLimiter) Update(key, resource string, h http.Header) {
+	if r == nil || h.Get("X-RateLimit-Remaining") == "" {
+		return
+	}
+	limit, errLimit := strconv.Atoi(h.Get("X-RateLimit-Limit"))
+	remaining, errRemaining := strconv.Atoi(h.Get("X-RateLimit-Remaining"))
+	reset, errReset := strconv.ParseInt(h.Get("X-RateLimit-Reset"), 10, 64)
+	if errLimit != nil || errRemaining != nil || errReset != nil {
+		slog.Debug("Ignoring malformed rate limit headers", "key", key, "remaining", h.Get("X-RateLimit-Remaining"))
+		return
+	}
+	if name := h.Get("X-RateLimit-Resource"); name != "" {
+		resource = name
+	}
+	r.set(key, resource, limit, remaining, time.Unix(reset, 0))
+}
+
+// UpdateGraphQL records the budget reported by a GraphQL response's rateLimit field, if the query asked for it.
+func (r *RateLimiter) UpdateGraphQL(key string, result map[string]any) {
+	if r == nil {
+		return
+	}
+	data, ok := result["data"].(map[string]any)
+	if !ok {
+		return
+	}
+	rl, ok := data["rateLimit"].(map[string]any)
+	if !ok {
+		return
+	}
+	remaining, okRemaining := rl["remaining"].(float64)
+	resetAt, okReset := rl["resetAt"].(string)
+	reset, err := time.Parse(time.RFC3339, resetAt)
+	if !okRemaining || !okReset || err != nil {
+		return
+	}
+	limit, ok := rl["limit"].(float64)
+	if !ok {
+		limit = remaining // Without a limit only exhaustion can be detected
+		if l, ok := r.Limit(key, "graphql"); ok {
+			limit = float64(l.Limit)
+		}
+	}
+	if cost, ok := rl["cost"].(float64); ok {
+		slog.Debug("GraphQL query cost", "key", key, "cost", int(cost), "remaining", int(remaining))
+	}
+	r.set(key, "graphql", int(limit), int(remaining), reset)
+}
+
+// set stores the state of a budget.
+func (r *RateLimiter) set(key, resource string, limit, remaining int, reset time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.limits[key+"/"+resource] = &RateLimit{
+		Key:       key,
+		Resource:  resource,
+		Limit:     limit,
+		Remaining: remaining,
+		Reset:     reset,
+		Updated:   r.now(),
+	}
+}
+
+// Limited reports whether a response was rejected by a rate limit, recording how long
+// requests with key must wait. Secondary rate limits come as 403 or 429 with Retry-After;
+// exhausted primary limits as 403 or 429 with no requests remaining.
+func (r *RateLimiter) Limited(key string, resp *http.Response) bool {
+	if resp.StatusCode != http.StatusForbidden && resp.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+
+	retryAfter := resp.Header.Get("Retry-After")
+	exhausted := resp.Header.Get("X-RateLimit-Remaining") == "0"
+	if resp.StatusCode == http.StatusForbidden && retryAfter == "" && !exhausted {
+		return false // A permissions problem, not a rate limit
+	}
+	if r == nil || (exhausted && retryAfter == "") {
+		return true // Update has recorded the exhausted budget and its reset time
+	}
+
+	wait := defaultRetryWait
+	if seconds, err := strconv.Atoi(retryAfter); err == nil {
+		wait = time.Duration(seconds) * time.Second
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	until := r.now().Add(wait)
+	if until.After(r.blocked[key]) {
+		r.blocked[key] = until
+	}
+	slog.Warn("Hit GitHub secondary rate limit", "key", key, "retry_after", wait)
+	return true
+}
+
+// graphQLRateLimited reports whether GraphQL errors include a RATE_LIMITED error, which
+// GitHub returns with status 200 once the GraphQL budget is spent.
+func graphQLRateLimited(errs any) bool {
+	list, _ := errs.([]any)
+	for _, e := range list {
+		if m, ok := e.(map[string]any); ok && m["type"] == "RATE_LIMITED" {
+			return true
+		}
+	}
+	return false
+}
+
+// Limit returns the last reported state of a budget.
+func (r *RateLimiter) Limit(key, resource string) (RateLimit, bool) {
+	if r == nil {
+		return RateLimit{}, false
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	l, ok := r.limits[key+"/"+resource]
+	if !ok {
+		return RateLimit{}, false
+	}
+	return *l, true
+}
+
+// Snapshot returns the last reported state of every budget, sorted by key and resource.
+func (r *RateLimiter) Snapshot() []RateLimit {
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	limits := make([]RateLimit, 0, len(r.limits))
+	for _, l := range r.limits {
+		limits = append(limits, *l)
+	}
+	sort.Slice(limits, func(i, j int) bool {
+		if limits[i].Key != limits[j].Key {
+			return limits[i].Key < limits[j].Key
+		}
+		return limits[i].Resource < limits[j].Resource
+	})
+	return limits
+}
+
+// RateLimits returns the client's last known rate limit budgets, e.g. for health checks.
+func (c *Client) RateLimits() []RateLimit {
+	return c.rateLimiter.Snapshot()
+}
+
+// rateLimitKey identifies the token requests made with ctx use: each installation and
+// personal token has its own budget.
+func (c *Client) rateLimitKey(ctx context.Context) string {
+	org := c.org(ctx)
+	switch {
+	case c.isAppAuth && org != "":
+		return "installation:" + org
+	case c.isAppAuth:
+		return "app"
+	default:
+		return "token"
+	}
+}
+
+// rateLimitResource returns the rate limit resource a REST API URL counts against.
+func rateLimitResource(apiURL string) string {
+	if strings.Contains(apiURL, "/search/") {
+		return "search"
+	}
+	return "core"
+}
+
+// errRateLimited builds the retryable error for a rate-limited response.
+func errRateLimited(status int) error {
+	return fmt.Errorf("http %d: rate limited", status)
+}
@@ -0,0 +1,201 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func newTestRateLimiter(now time.Time) *RateLimiter {
+	r := NewRateLimiter()
+	r.now = func() time.Time { return now }
+	return r
+}
+
+func rateLimitHeader(limit, remaining int, reset time.Time) http.Header {
+	h := make(http.Header)
+	h.Set("X-RateLimit-Limit", strconv.Itoa(limit))
+	h.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	h.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	return h
+}
+
+func TestRateLimiter_Delay(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	reset := now.Add(10 * time.Minute)
+
+	tests := []struct {
+		name      string
+		remaining int
+		want      time.Duration
+	}{
+		{name: "plenty left", remaining: 4000, want: 0},
+		{name: "paced below reserve", remaining: 99, want: (10*time.Minute + time.Second) / 100},
+		{name: "exhausted waits for reset", remaining: 0, want: 10*time.Minute + time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRateLimiter(now)
+			r.Update("token", "core", rateLimitHeader(5000, tt.remaining, reset))
+			if got := r.delay("token", "core"); got != tt.want {
+				t.Errorf("delay() = %v, want %v", got, tt.want)
+			}
+			if got := r.delay("installation:other", "core"); got != 0 {
+				t.Errorf("delay() for another token = %v, want 0", got)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_DelayAfterReset(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	r := newTestRateLimiter(now)
+	r.Update("token", "core", rateLimitHeader(5000, 0, now.Add(-time.Second)))
+	if got := r.delay("token", "core"); got != 0 {
+		t.Errorf("delay() after reset = %v, want 0", got)
+	}
+}
+
+func TestRateLimiter_UpdateResourceHeader(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	r := newTestRateLimiter(now)
+	h := rateLimitHeader(30, 29, now.Add(time.Minute))
+	h.Set("X-RateLimit-Resource", "search")
+	r.Update("token", "core", h)
+
+	if _, ok := r.Limit("token", "core"); ok {
+		t.Error("expected no core budget")
+	}
+	l, ok := r.Limit("token", "search")
+	if !ok || l.Limit != 30 || l.Remaining != 29 || !l.Reset.Equal(now.Add(time.Minute)) {
+		t.Errorf("search budget = %+v, %v", l, ok)
+	}
+}
+
+func TestRateLimiter_Limited(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+
+	tests := []struct {
+		header      map[string]string
+		name        string
+		status      int
+		wantLimited bool
+		wantDelay   time.Duration
+	}{
+		{name: "success", status: http.StatusOK},
+		{name: "forbidden", status: http.StatusForbidden},
+		{
+			name: "secondary limit", status: http.StatusForbidden, header: map[string]string{"Retry-After": "30"},
+			wantLimited: true, wantDelay: 30 * time.Second,
+		},
+		{name: "too many requests", status: http.StatusTooManyRequests, wantLimited: true, wantDelay: defaultRetryWait},
+		{
+			name: "primary limit exhausted", status: http.StatusForbidden, header: map[string]string{"X-RateLimit-Remaining": "0"},
+			wantLimited: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestRateLimiter(now)
+			resp := &http.Response{StatusCode: tt.status, Header: make(http.Header)}
+			for k, v := range tt.header {
+				resp.Header.Set(k, v)
+			}
+			if got := r.Limited("token", resp); got != tt.wantLimited {
+				t.Errorf("Limited() = %v, want %v", got, tt.wantLimited)
+			}
+			if got := r.delay("token", "graphql"); got != tt.wantDelay {
+				t.Errorf("delay() = %v, want %v", got, tt.wantDelay)
+			}
+		})
+	}
+}
+
+func TestRateLimiter_UpdateGraphQL(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	r := newTestRateLimiter(now)
+	r.UpdateGraphQL("app", map[string]any{
+		"data": map[string]any{
+			"rateLimit": map[string]any{
+				"limit":     5000.0,
+				"remaining": 4990.0,
+				"cost":      1.0,
+				"resetAt":   now.Add(time.Hour).UTC().Format(time.RFC3339),
+			},
+		},
+	})
+
+	l, ok := r.Limit("app", "graphql")
+	if !ok || l.Limit != 5000 || l.Remaining != 4990 || !l.Reset.Equal(now.Add(time.Hour)) {
+		t.Errorf("graphql budget = %+v, %v", l, ok)
+	}
+}
+
+func TestRateLimiter_Nil(t *testing.T) {
+	var r *RateLimiter
+	r.Update("token", "core", rateLimitHeader(5000, 0, time.Now().Add(time.Hour)))
+	if err := r.Wait(context.Background(), "token", "core"); err != nil {
+		t.Errorf("Wait() error = %v", err)
+	}
+	if r.Snapshot() != nil {
+		t.Error("expected no snapshot")
+	}
+	if !r.Limited("token", &http.Response{StatusCode: http.StatusTooManyRequests, Header: make(http.Header)}) {
+		t.Error("expected 429 to be rate limited")
+	}
+}
+
+func TestRateLimiter_Snapshot(t *testing.T) {
+	now := time.Unix(1_700_000_000, 0)
+	r := newTestRateLimiter(now)
+	r.Update("token", "core", rateLimitHeader(5000, 10, now))
+	r.Update("installation:b", "core", rateLimitHeader(5000, 20, now))
+	r.Update("installation:a", "search", rateLimitHeader(30, 5, now))
+	r.Update("installation:a", "core", rateLimitHeader(5000, 30, now))
+
+	var got []string
+	for _, l := range r.Snapshot() {
+		got = append(got, l.Key+"/"+l.Resource)
+	}
+	want := "installation:a/core installation:a/search installation:b/core token/core"
+	if strings.Join(got, " ") != want {
+		t.Errorf("Snapshot() = %v, want %s", got, want)
+	}
+}
+
+func TestClient_RecordsRateLimits(t *testing.T) {
+	reset := time.Now().Add(time.Hour).Truncate(time.Second)
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"total_count": 1, "items": []}`)),
+				Header:     rateLimitHeader(30, 25, reset),
+			}, nil
+		},
+	}
+
+	c := &Client{
+		cache:       mustNewDiskCache(t),
+		httpClient:  &http.Client{Transport: mockTransport},
+		token:       "test-token",
+		rateLimiter: NewRateLimiter(),
+	}
+	if _, err := c.searchPRCount(context.Background(), "is:pr author:alice"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	limits := c.RateLimits()
+	if len(limits) != 1 {
+		t.Fatalf("expected 1 budget, got %+v", limits)
+	}
+	if l := limits[0]; l.Key != "token" || l.Resource != "search" || l.Remaining != 25 || !l.Reset.Equal(reset) {
+		t.Errorf("unexpected budget %+v", l)
+	}
+}
@@ -0,0 +1,200 @@
+package github
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/cache"
+)
+
+// ErrNotFound is returned when a requested GitHub resource does not exist.
+var ErrNotFound = errors.New("not found")
+
+// Repository-related constants.
+const (
+	fileContentCacheTTL = cache.TTLRepoConfig // CODEOWNERS and config edits should take effect promptly
+	teamMembersCacheTTL = 6 * time.Hour       // Team membership changes occasionally
+	mergeBaseCacheTTL   = 24 * time.Hour      // Fixed for a given head commit unless the base branch is rewritten
+)
+
+// FileContent returns the decoded contents of a file at ref, a branch, tag or commit.
+// An empty ref reads the repository's default branch. Returns ErrNotFound if the file
+// does not exist.
+func (c *Client) FileContent(ctx context.Context, owner, repo, path, ref string) (string, error) {
+	cacheKey := makeCacheKey("file-content", owner, repo, path, ref)
+	notFoundKey := makeCacheKey("file-content-missing", owner, repo, path, ref)
+
+	if _, found := c.cache.Get(notFoundKey); found {
+		return "", ErrNotFound
+	}
+	cached, hitType := c.cache.Lookup(cacheKey)
+	if hitType != cache.CacheMiss {
+		if content, ok := cached.(string); ok {
+			slog.InfoContext(ctx, "Fetching file content", "owner", owner, "repo", repo, "path", path, "ref", ref, "cache", hitType)
+			return content, nil
+		}
+	}
+
+	slog.InfoContext(ctx, "Fetching file content", "component", "api", "owner", owner, "repo", repo, "path", path, "ref", ref, "cache", "miss")
+	apiURL := c.api("/repos/%s/%s/contents/%s", owner, repo, escapePath(path))
+	if ref != "" {
+		apiURL += "?ref=" + url.QueryEscape(ref)
+	}
+	resp, err := c.doRequest(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch %s: %w", path, err)
+	}
+	defer drainAndCloseBody(resp.Body)
+
+	if resp.StatusCode == http.StatusNotFound {
+		c.cache.SetWithTTL(notFoundKey, true, fileContentCacheTTL)
+		return "", ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to fetch %s (status %d)", path, resp.StatusCode)
+	}
+
+	var file struct {
+		Type     string `json:"type"`
+		Encoding string `json:"encoding"`
+		Content  string `json:"content"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&file); err != nil {
+		return "", fmt.Errorf("failed to decode contents response: %w", err)
+	}
+	if file.Type != "" && file.Type != "file" {
+		return "", fmt.Errorf("%s is a %s, not a file", path, file.Type)
+	}
+
+	content := file.Content
+	if file.Encoding == "base64" {
+		// GitHub wraps base64 content at 60 columns
+		decoded, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(file.Content, "\n", ""))
+		if err != nil {
+			return "", fmt.Errorf("failed to decode %s: %w", path, err)
+		}
+		content = string(decoded)
+	}
+
+	c.cache.SetWithTTL(cacheKey, content, fileContentCacheTTL)
+	return content, nil
+}
+
+// TeamMembers returns the logins of all members of an organization team.
+func (c *Client) TeamMembers(ctx context.Context, org, teamSlug string) ([]string, error) {
+	cacheKey := makeCacheKey("team-members", org, teamSlug)
+	if members, ok := cachedAs[[]string](c.cache, cacheKey); ok {
+		return members, nil
+	}
+
+	slog.InfoContext(ctx, "Fetching team members", "component", "api", "org", org, "team", teamSlug)
+
+	var members []string
+	for page := 1; ; page++ {
+		apiURL := c.api("/orgs/%s/teams/%s/members?per_page=%d&page=%d",
+			url.PathEscape(org), url.PathEscape(teamSlug), perPageLimit, page)
+
+		logins, err := func() ([]string, error) {
+			resp, err := c.doRequest(ctx, "GET", apiURL, nil)
+			if err != nil {
+				return nil, err
+			}
+			defer drainAndCloseBody(resp.Body)
+
+			if resp.StatusCode == http.StatusNotFound {
+				return nil, ErrNotFound
+			}
+			if resp.StatusCode != http.StatusOK {
+				return nil, fmt.Errorf("status %d", resp.StatusCode)
+			}
+
+			var users []struct {
+				Login string `json:"login"`
+				Type  string `json:"type"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&users); err != nil {
+				return nil, fmt.Errorf("failed to decode team members: %w", err)
+			}
+
+			logins := make([]string, 0, len(users))
+			for _, u := range users {
+				if u.Type != "Bot" {
+					logins = append(logins, u.Login)
+				}
+			}
+			return logins, nil
+		}()
+		if err != nil {
+			return nil, fmt.Errorf("failed to fetch members of %s/%s: %w", org, teamSlug, err)
+		}
+
+		members = append(members, logins...)
+		if len(logins) < perPageLimit {
+			break
+		}
+	}
+
+	c.cache.SetWithTTL(cacheKey, members, teamMembersCacheTTL)
+	slog.InfoContext(ctx, "Fetched team members", "org", org, "team", teamSlug, "count", len(members))
+	return members, nil
+}
+
+// MergeBase returns the SHA of the best common ancestor of base and head, the commit a
+// PR's diff is computed against. base and head may be branch names or commit SHAs.
+func (c *Client) MergeBase(ctx context.Context, owner, repo, base, head string) (string, error) {
+	cacheKey := makeCacheKey("merge-base", owner, repo, base, head)
+	if cached, found := c.cache.Get(cacheKey); found {
+		if sha, ok := cached.(string); ok {
+			return sha, nil
+		}
+	}
+
+	slog.InfoContext(ctx, "Fetching merge base", "component", "api", "owner", owner, "repo", repo, "base", base, "head", head)
+	// per_page=1 keeps GitHub from listing every commit in the comparison
+	apiURL := c.api("/repos/%s/%s/compare/%s...%s?per_page=1",
+		owner, repo, escapePath(base), escapePath(head))
+	resp, err := c.doRequest(ctx, "GET", apiURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to compare %s...%s: %w", base, head, err)
+	}
+	defer drainAndCloseBody(resp.Body)
+
+	if resp.StatusCode == http.StatusNotFound {
+		return "", ErrNotFound
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("failed to compare %s...%s (status %d)", base, head, resp.StatusCode)
+	}
+
+	var comparison struct {
+		MergeBaseCommit struct {
+			SHA string `json:"sha"`
+		} `json:"merge_base_commit"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&comparison); err != nil {
+		return "", fmt.Errorf("failed to decode comparison: %w", err)
+	}
+	if comparison.MergeBaseCommit.SHA == "" {
+		return "", fmt.Errorf("no merge base for %s...%s", base, head)
+	}
+
+	c.cache.SetWithTTL(cacheKey, comparison.MergeBaseCommit.SHA, mergeBaseCacheTTL)
+	return comparison.MergeBaseCommit.SHA, nil
+}
+
+// escapePath escapes each segment of a repository file path for use in a URL.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = url.PathEscape(s)
+	}
+	return strings.Join(segments, "/")
+}
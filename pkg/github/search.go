@@ -0,0 +1,99 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// PRRef identifies a pull request.
+type PRRef struct {
+	Owner  string
+	Repo   string
+	Number int
+}
+
+// String formats the reference as owner/repo#number.
+func (r PRRef) String() string {
+	return fmt.Sprintf("%s/%s#%d", r.Owner, r.Repo, r.Number)
+}
+
+// SearchPullRequests returns pull requests matching a GitHub search query,
+// e.g. "repo:owner/name is:open review:none". "is:pr" is added if the query
+// does not already restrict results to pull requests. A limit of 0 or less
+// returns all results (GitHub caps searches at 1000).
+func (c *Client) SearchPullRequests(ctx context.Context, query string, limit int) ([]PRRef, error) {
+	if !strings.Contains(" "+query+" ", " is:pr ") && !strings.Contains(" "+query+" ", " type:pr ") {
+		query = "is:pr " + query
+	}
+	slog.Info("Searching for pull requests", "query", query, "limit", limit)
+
+	var refs []PRRef
+	for page := 1; ; page++ {
+		apiURL := c.api("/search/issues?q=%s&per_page=%d&page=%d", url.QueryEscape(query), perPageLimit, page)
+
+		resp, err := c.doRequest(ctx, "GET", apiURL, nil) //nolint:bodyclose // body is closed immediately, not deferred
+		if err != nil {
+			return nil, fmt.Errorf("failed to search PRs: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			body, err := io.ReadAll(resp.Body)
+			drainAndCloseBody(resp.Body)
+			if err != nil {
+				return nil, fmt.Errorf("failed to search PRs: status %d (could not read body: %w)", resp.StatusCode, err)
+			}
+			return nil, fmt.Errorf("failed to search PRs: status %d: %s", resp.StatusCode, string(body))
+		}
+
+		var searchResult struct {
+			Items []struct {
+				RepositoryURL string `json:"repository_url"`
+				Number        int    `json:"number"`
+			} `json:"items"`
+			TotalCount int `json:"total_count"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&searchResult); err != nil {
+			drainAndCloseBody(resp.Body)
+			return nil, fmt.Errorf("failed to decode search results: %w", err)
+		}
+		drainAndCloseBody(resp.Body)
+
+		slog.Debug("Search results page", "query", query, "page", page, "items", len(searchResult.Items), "total_count", searchResult.TotalCount)
+
+		for _, item := range searchResult.Items {
+			owner, repo, ok := repoFromURL(item.RepositoryURL)
+			if !ok {
+				slog.Warn("Invalid repository URL", "url", item.RepositoryURL, "query", query)
+				continue
+			}
+			refs = append(refs, PRRef{Owner: owner, Repo: repo, Number: item.Number})
+			if limit > 0 && len(refs) >= limit {
+				return refs, nil
+			}
+		}
+
+		if len(searchResult.Items) < perPageLimit {
+			break
+		}
+	}
+
+	slog.Info("Found pull requests for search", "query", query, "count", len(refs))
+	return refs, nil
+}
+
+// repoFromURL extracts the owner and repository name from an API repository URL
+// such as https://api.github.com/repos/owner/repo.
+func repoFromURL(repositoryURL string) (owner, repo string, ok bool) {
+	parts := strings.Split(strings.TrimSuffix(repositoryURL, "/"), "/")
+	if len(parts) < 3 || parts[len(parts)-3] != "repos" {
+		return "", "", false
+	}
+	owner, repo = parts[len(parts)-2], parts[len(parts)-1]
+	return owner, repo, owner != "" && repo != ""
+}
@@ -2,9 +2,11 @@ package github
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -173,8 +175,243 @@ func TestClient_BatchOpenPRCount_MultipleUsers(t *testing.T) {
 	}
 
 	for _, user := range users {
-		if counts[user] != expectedCounts[user] {
-			t.Errorf("expected count %d for %s, got %d", expectedCounts[user], user, counts[user])
+		if counts[user].Count != expectedCounts[user] || counts[user].FetchedAt.IsZero() {
+			t.Errorf("expected count %d for %s, got %+v", expectedCounts[user], user, counts[user])
 		}
 	}
 }
+
+func TestClient_ReviewResponseTimes(t *testing.T) {
+	callCount := 0
+	recent := time.Now().Add(-time.Hour).Format(time.RFC3339)
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			callCount++
+			// alice: reviewed three requested PRs after 1h, 3h and 10h (one review came before a
+			// re-request and is ignored), and ignored a fourth request; the third PR is also still
+			// pending a re-request and must count once. bob: reviewed without ever being requested,
+			// and has one request that is too recent to count as a miss.
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(strings.NewReader(strings.Replace(`{
+					"data": {
+						"r0": {"nodes": [
+							{"id": "PR1", "timelineItems": {"nodes": [
+								{"__typename": "ReviewRequestedEvent", "createdAt": "2024-05-01T10:00:00Z", "requestedReviewer": {"login": "alice"}},
+								{"__typename": "PullRequestReview", "submittedAt": "2024-05-01T11:00:00Z", "author": {"login": "alice"}}
+							]}},
+							{"id": "PR2", "timelineItems": {"nodes": [
+								{"__typename": "ReviewRequestedEvent", "createdAt": "2024-05-02T10:00:00Z", "requestedReviewer": {"login": "carol"}},
+								{"__typename": "ReviewRequestedEvent", "createdAt": "2024-05-02T12:00:00Z", "requestedReviewer": {"login": "Alice"}},
+								{"__typename": "PullRequestReview", "submittedAt": "2024-05-02T15:00:00Z", "author": {"login": "alice"}},
+								{"__typename": "PullRequestReview", "submittedAt": "2024-05-03T15:00:00Z", "author": {"login": "alice"}}
+							]}},
+							{"id": "PR3", "timelineItems": {"nodes": [
+								{"__typename": "ReviewRequestedEvent", "createdAt": "2024-05-03T00:00:00Z", "requestedReviewer": {"login": "alice"}},
+								{"__typename": "PullRequestReview", "submittedAt": "2024-05-03T10:00:00Z", "author": {"login": "alice"}},
+								{"__typename": "ReviewRequestedEvent", "createdAt": "2024-05-04T00:00:00Z", "requestedReviewer": {"login": "alice"}}
+							]}}
+						]},
+						"p0": {"nodes": [
+							{"id": "PR3", "timelineItems": {"nodes": [
+								{"__typename": "ReviewRequestedEvent", "createdAt": "2024-05-03T00:00:00Z", "requestedReviewer": {"login": "alice"}},
+								{"__typename": "PullRequestReview", "submittedAt": "2024-05-03T10:00:00Z", "author": {"login": "alice"}},
+								{"__typename": "ReviewRequestedEvent", "createdAt": "2024-05-04T00:00:00Z", "requestedReviewer": {"login": "alice"}}
+							]}},
+							{"id": "PR4", "timelineItems": {"nodes": [
+								{"__typename": "ReviewRequestedEvent", "createdAt": "2024-05-05T00:00:00Z", "requestedReviewer": {"login": "alice"}}
+							]}}
+						]},
+						"r1": {"nodes": [
+							{"id": "PR5", "timelineItems": {"nodes": [
+								{"__typename": "PullRequestReview", "submittedAt": "2024-05-01T11:00:00Z", "author": {"login": "bob"}}
+							]}}
+						]},
+						"p1": {"nodes": [
+							{"id": "PR6", "timelineItems": {"nodes": [
+								{"__typename": "ReviewRequestedEvent", "createdAt": "RECENT", "requestedReviewer": {"login": "bob"}}
+							]}}
+						]}
+					}
+				}`, "RECENT", recent, 1))),
+				Header: make(http.Header),
+			}, nil
+		},
+	}
+
+	c := &Client{
+		cache:      mustNewDiskCache(t),
+		httpClient: &http.Client{Transport: mockTransport},
+		token:      "test-token",
+	}
+
+	times, err := c.ReviewResponseTimes(context.Background(), "testorg", []string{"alice", "bob"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// 1h, 3h, 10h and a miss lasting the whole sample window
+	if got := times["alice"]; got.Samples != 4 || got.Median != 13*time.Hour/2 {
+		t.Errorf("alice = %+v, want median 6.5h over 4 samples", got)
+	}
+	if got := times["bob"]; got.Samples != 0 || got.Median != 0 {
+		t.Errorf("bob = %+v, want no samples", got)
+	}
+
+	// Second call should be served from cache
+	if _, err := c.ReviewResponseTimes(context.Background(), "testorg", []string{"alice", "bob"}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if callCount != 1 {
+		t.Errorf("expected 1 API call, got %d", callCount)
+	}
+}
+
+func TestClient_ReviewResponseTimes_Batches(t *testing.T) {
+	var batches []int
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			var body struct {
+				Variables map[string]any `json:"variables"`
+			}
+			if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+				t.Errorf("failed to decode request: %v", err)
+			}
+			batches = append(batches, len(body.Variables)/2) // A reviewed and a pending search per user
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`{"data": {}}`)),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+
+	c := &Client{
+		cache:      mustNewDiskCache(t),
+		httpClient: &http.Client{Transport: mockTransport},
+		token:      "test-token",
+	}
+
+	// A value reloaded from disk is generic JSON rather than a types.ResponseTime
+	c.cache.Set(makeCacheKey("response-time", "testorg", "cached"), map[string]any{"Median": float64(time.Hour), "Samples": float64(4)})
+
+	users := []string{"cached"}
+	for i := range maxResponseTimeBatch + 2 {
+		users = append(users, fmt.Sprintf("user%d", i))
+	}
+	times, err := c.ReviewResponseTimes(context.Background(), "testorg", users)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := times["cached"]; got.Median != time.Hour || got.Samples != 4 {
+		t.Errorf("cached = %+v, want median 1h over 4 samples", got)
+	}
+	if len(times) != len(users) {
+		t.Errorf("got %d response times, want %d", len(times), len(users))
+	}
+	if want := []int{maxResponseTimeBatch, 2}; !slices.Equal(batches, want) {
+		t.Errorf("batch sizes = %v, want %v", batches, want)
+	}
+}
+
+func TestClient_SearchPullRequests(t *testing.T) {
+	var gotQuery string
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			gotQuery = req.URL.Query().Get("q")
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(strings.NewReader(`{
+					"total_count": 3,
+					"items": [
+						{"number": 1, "repository_url": "https://api.github.com/repos/owner/repo1"},
+						{"number": 2, "repository_url": "invalid"},
+						{"number": 3, "repository_url": "https://api.github.com/repos/other/repo2"}
+					]
+				}`)),
+				Header: make(http.Header),
+			}, nil
+		},
+	}
+
+	c := &Client{
+		cache:      mustNewDiskCache(t),
+		httpClient: &http.Client{Transport: mockTransport},
+		token:      "test-token",
+	}
+
+	refs, err := c.SearchPullRequests(context.Background(), "repo:owner/repo1 is:open review:none", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotQuery != "is:pr repo:owner/repo1 is:open review:none" {
+		t.Errorf("expected is:pr to be added to query, got %q", gotQuery)
+	}
+	want := []PRRef{{Owner: "owner", Repo: "repo1", Number: 1}, {Owner: "other", Repo: "repo2", Number: 3}}
+	if len(refs) != len(want) || refs[0] != want[0] || refs[1] != want[1] {
+		t.Errorf("expected %v, got %v", want, refs)
+	}
+
+	refs, err = c.SearchPullRequests(context.Background(), "is:pr is:open", 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotQuery != "is:pr is:open" {
+		t.Errorf("expected query to be left alone, got %q", gotQuery)
+	}
+	if len(refs) != 1 || refs[0].String() != "owner/repo1#1" {
+		t.Errorf("expected limit of 1 result, got %v", refs)
+	}
+}
+
+func TestClient_BatchOpenPRCount_RecordsGraphQLRateLimit(t *testing.T) {
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			body, err := io.ReadAll(req.Body)
+			if err != nil {
+				t.Fatalf("reading request body: %v", err)
+			}
+			if !strings.Contains(string(body), "rateLimit {") {
+				t.Errorf("query does not select rateLimit: %s", body)
+			}
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body: io.NopCloser(strings.NewReader(`{
+					"data": {
+						"rateLimit": {"limit": 5000, "cost": 1, "remaining": 4990, "resetAt": "2030-01-01T00:00:00Z"},
+						"assigned0": {"issueCount": 2},
+						"review0": {"issueCount": 1}
+					}
+				}`)),
+				Header: make(http.Header),
+			}, nil
+		},
+	}
+
+	c := &Client{
+		cache:       mustNewDiskCache(t),
+		httpClient:  &http.Client{Transport: mockTransport},
+		rateLimiter: NewRateLimiter(),
+		token:       "test-token",
+	}
+
+	counts, err := c.BatchOpenPRCount(context.Background(), "testorg", []string{"alice"}, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if counts["alice"].Count != 3 {
+		t.Errorf("BatchOpenPRCount() alice = %d, want 3", counts["alice"].Count)
+	}
+
+	var got *RateLimit
+	for _, l := range c.rateLimiter.Snapshot() {
+		if l.Resource == "graphql" {
+			got = &l
+		}
+	}
+	if got == nil {
+		t.Fatalf("Snapshot() = %v, want a graphql budget", c.rateLimiter.Snapshot())
+	}
+	if got.Remaining != 4990 || got.Limit != 5000 {
+		t.Errorf("graphql budget = %d/%d, want 4990/5000", got.Remaining, got.Limit)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
 	"sync"
@@ -22,6 +23,10 @@ const (
 	prStaleDaysThreshold   = 90               // PRs older than this are considered stale
 	prCountCacheTTL        = 6 * time.Hour    // PR count for workload balancing (default)
 	prCountFailureCacheTTL = 10 * time.Minute // Cache failures to avoid repeated API calls
+	responseTimePRs        = 15               // Reviewed and pending-request PRs each sampled per user for response times
+	responseTimeGrace      = 24 * time.Hour   // Unanswered review requests younger than this are not yet counted as misses
+	responseTimeEvents     = 50               // Timeline events read per PR when looking for requests and reviews
+	maxResponseTimeBatch   = 10               // Users whose response times are fetched per GraphQL request
 )
 
 // UserCache provides caching for user information.
@@ -262,24 +267,22 @@ func (c *Client) OpenPRCount(ctx context.Context, org, user string, cacheTTL tim
 }
 
 // BatchOpenPRCount fetches PR counts for multiple users in a single GraphQL query.
-// Returns a map of username -> PR count. Uses cache for each user individually.
-func (c *Client) BatchOpenPRCount(ctx context.Context, org string, users []string, cacheTTL time.Duration) (map[string]int, error) {
+// Returns a map of username -> PR count and when it was fetched. Uses cache for each user
+// individually.
+func (c *Client) BatchOpenPRCount(ctx context.Context, org string, users []string, cacheTTL time.Duration) (map[string]types.OpenPRCount, error) {
 	if len(users) == 0 {
-		return make(map[string]int), nil
+		return make(map[string]types.OpenPRCount), nil
 	}
 
-	result := make(map[string]int)
+	result := make(map[string]types.OpenPRCount)
 	var usersToFetch []string
 
 	// Check cache for each user first
 	for _, user := range users {
-		cacheKey := makeCacheKey("pr-count", org, user)
-		if cached, found := c.cache.Get(cacheKey); found {
-			if count, ok := cached.(int); ok {
-				result[user] = count
-				slog.Debug("Using cached PR count", "user", user, "count", count)
-				continue
-			}
+		if count, ok := cachedAs[types.OpenPRCount](c.cache, makeCacheKey("open-prs", org, user)); ok {
+			result[user] = count
+			slog.Debug("Using cached PR count", "user", user, "count", count.Count, "fetched_at", count.FetchedAt)
+			continue
 		}
 		usersToFetch = append(usersToFetch, user)
 	}
@@ -295,7 +298,7 @@ func (c *Client) BatchOpenPRCount(ctx context.Context, org string, users []strin
 	cutoffDate := time.Now().AddDate(0, 0, -prStaleDaysThreshold).Format("2006-01-02")
 
 	// Build GraphQL query with search for each user
-	queryParts := []string{"query {"}
+	queryParts := []string{"query {\n  rateLimit { limit cost remaining resetAt }"}
 	for i, user := range usersToFetch {
 		// GraphQL field names can't have hyphens, use index
 		assignedQuery := fmt.Sprintf("is:pr is:open org:%s assignee:%s updated:>=%s", org, user, cutoffDate)
@@ -323,6 +326,7 @@ func (c *Client) BatchOpenPRCount(ctx context.Context, org string, users []strin
 	}
 
 	// Extract counts for each user
+	fetchedAt := time.Now()
 	for i, user := range usersToFetch {
 		assignedKey := fmt.Sprintf("assigned%d", i)
 		reviewKey := fmt.Sprintf("review%d", i)
@@ -343,11 +347,10 @@ func (c *Client) BatchOpenPRCount(ctx context.Context, org string, users []strin
 		}
 
 		total := assignedCount + reviewCount
-		result[user] = total
+		result[user] = types.OpenPRCount{FetchedAt: fetchedAt, Count: total}
 
 		// Cache the result
-		cacheKey := makeCacheKey("pr-count", org, user)
-		c.cache.SetWithTTL(cacheKey, total, cacheTTL)
+		c.cache.SetWithTTL(makeCacheKey("open-prs", org, user), result[user], cacheTTL)
 
 		slog.Debug("Fetched PR count", "user", user, "total", total, "assigned", assignedCount, "review", reviewCount)
 	}
@@ -355,10 +358,174 @@ func (c *Client) BatchOpenPRCount(ctx context.Context, org string, users []strin
 	return result, nil
 }
 
+// ReviewResponseTimes returns how quickly each user has responded to review requests on
+// recent PRs in the org: the median time from a review request to their first review.
+// Users with no requested reviews in the sample get a zero ResponseTime. Results are
+// cached for cache.TTLRecentActivity.
+func (c *Client) ReviewResponseTimes(ctx context.Context, org string, users []string) (map[string]types.ResponseTime, error) {
+	result := make(map[string]types.ResponseTime, len(users))
+	var usersToFetch []string
+	for _, user := range users {
+		if rt, ok := cachedAs[types.ResponseTime](c.cache, makeCacheKey("response-time", org, user)); ok {
+			result[user] = rt
+			continue
+		}
+		usersToFetch = append(usersToFetch, user)
+	}
+	if len(usersToFetch) == 0 {
+		return result, nil
+	}
+
+	slog.Info("Batch fetching review response times", "org", org, "users", len(usersToFetch))
+
+	for start := 0; start < len(usersToFetch); start += maxResponseTimeBatch {
+		batch := usersToFetch[start:min(start+maxResponseTimeBatch, len(usersToFetch))]
+		if err := c.fetchResponseTimes(ctx, org, batch, result); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// fetchResponseTimes fetches the response times of up to maxResponseTimeBatch users in one
+// request, adding them to result and the cache. Each user's sample combines the PRs they
+// reviewed with the PRs where their review is still requested, so requests they ignored
+// count against them rather than dropping out of the sample.
+func (c *Client) fetchResponseTimes(ctx context.Context, org string, users []string, result map[string]types.ResponseTime) error {
+	now := time.Now()
+	cutoffDate := now.AddDate(0, 0, -prStaleDaysThreshold).Format("2006-01-02")
+	var params, fields strings.Builder
+	variables := make(map[string]any, 2*len(users))
+	for i, user := range users {
+		if i > 0 {
+			params.WriteString(", ")
+		}
+		fmt.Fprintf(&params, "$r%d: String!, $p%d: String!", i, i)
+		fmt.Fprintf(&fields, `
+  r%d: search(query: $r%d, type: ISSUE, first: %d) { nodes { ...ResponseTimePR } }
+  p%d: search(query: $p%d, type: ISSUE, first: %d) { nodes { ...ResponseTimePR } }`,
+			i, i, responseTimePRs, i, i, responseTimePRs)
+		variables[fmt.Sprintf("r%d", i)] = fmt.Sprintf("is:pr org:%s reviewed-by:%s -author:%s updated:>=%s", org, user, user, cutoffDate)
+		variables[fmt.Sprintf("p%d", i)] = fmt.Sprintf("is:pr org:%s review-requested:%s -author:%s updated:>=%s", org, user, user, cutoffDate)
+	}
+	query := fmt.Sprintf(`query(%s) {
+  rateLimit { limit cost remaining resetAt }%s
+}
+fragment ResponseTimePR on PullRequest {
+  id
+  timelineItems(first: %d, itemTypes: [REVIEW_REQUESTED_EVENT, PULL_REQUEST_REVIEW]) {
+    nodes {
+      __typename
+      ... on ReviewRequestedEvent { createdAt requestedReviewer { ... on User { login } } }
+      ... on PullRequestReview { submittedAt author { login } }
+    }
+  }
+}`, params.String(), fields.String(), responseTimeEvents)
+
+	resp, err := c.MakeGraphQLRequest(ctx, query, variables)
+	if err != nil {
+		return fmt.Errorf("GraphQL review response time query failed: %w", err)
+	}
+	data, ok := resp["data"].(map[string]any)
+	if !ok {
+		return errors.New("invalid GraphQL response structure")
+	}
+
+	for i, user := range users {
+		var latencies []time.Duration
+		seen := make(map[string]bool)
+		for _, alias := range []string{fmt.Sprintf("r%d", i), fmt.Sprintf("p%d", i)} {
+			search, ok := data[alias].(map[string]any)
+			if !ok {
+				continue
+			}
+			nodes, _ := search["nodes"].([]any)
+			for _, node := range nodes {
+				pr, ok := node.(map[string]any)
+				if !ok {
+					continue
+				}
+				// A re-requested reviewer's PR matches both searches
+				if id, ok := pr["id"].(string); ok {
+					if seen[id] {
+						continue
+					}
+					seen[id] = true
+				}
+				if d, ok := requestLatency(pr, user, now); ok {
+					latencies = append(latencies, d)
+				}
+			}
+		}
+
+		rt := types.ResponseTime{Samples: len(latencies)}
+		if len(latencies) > 0 {
+			slices.Sort(latencies)
+			rt.Median = latencies[len(latencies)/2]
+			if len(latencies)%2 == 0 {
+				rt.Median = (latencies[len(latencies)/2-1] + latencies[len(latencies)/2]) / 2
+			}
+		}
+		result[user] = rt
+		c.cache.SetWithTTL(makeCacheKey("response-time", org, user), rt, cache.TTLRecentActivity)
+		slog.Debug("Fetched review response time", "user", user, "median", rt.Median, "samples", rt.Samples)
+	}
+	return nil
+}
+
+// requestLatency returns the time from user's first review request on a PR's timeline
+// to their first review after it. A request they never answered counts as a miss lasting
+// the whole sample window, once it is older than responseTimeGrace. Returns false if they
+// were never requested or the unanswered request is still within the grace period.
+func requestLatency(pr map[string]any, user string, now time.Time) (time.Duration, bool) {
+	timeline, ok := pr["timelineItems"].(map[string]any)
+	if !ok {
+		return 0, false
+	}
+	events, _ := timeline["nodes"].([]any)
+
+	login := func(event map[string]any, field string) string {
+		actor, _ := event[field].(map[string]any)
+		l, _ := actor["login"].(string)
+		return l
+	}
+	timestamp := func(event map[string]any, field string) (time.Time, bool) {
+		s, _ := event[field].(string)
+		t, err := time.Parse(time.RFC3339, s)
+		return t, err == nil
+	}
+
+	var requested time.Time
+	for _, e := range events {
+		event, ok := e.(map[string]any)
+		if !ok {
+			continue
+		}
+		switch event["__typename"] {
+		case "ReviewRequestedEvent":
+			if requested.IsZero() && strings.EqualFold(login(event, "requestedReviewer"), user) {
+				requested, _ = timestamp(event, "createdAt")
+			}
+		case "PullRequestReview":
+			if requested.IsZero() || !strings.EqualFold(login(event, "author"), user) {
+				continue
+			}
+			if submitted, ok := timestamp(event, "submittedAt"); ok && !submitted.Before(requested) {
+				return submitted.Sub(requested), true
+			}
+		default:
+		}
+	}
+	if requested.IsZero() || now.Sub(requested) < responseTimeGrace {
+		return 0, false
+	}
+	return prStaleDaysThreshold * 24 * time.Hour, true
+}
+
 // searchPRCount searches for PRs matching a query and returns the count.
 func (c *Client) searchPRCount(ctx context.Context, query string) (int, error) {
 	encodedQuery := url.QueryEscape(query)
-	apiURL := fmt.Sprintf("https://api.github.com/search/issues?q=%s&per_page=1", encodedQuery)
+	apiURL := c.api("/search/issues?q=%s&per_page=1", encodedQuery)
 	slog.Debug("Search query", "query", query)
 	slog.Debug("Full URL", "url", apiURL)
 	resp, err := c.doRequest(ctx, "GET", apiURL, nil)
@@ -394,6 +561,27 @@ func makeCacheKey(parts ...string) string {
 	return strings.Join(parts, ":")
 }
 
+// cachedAs returns the value cached under key as T. Values reloaded from the disk cache
+// come back as generic JSON (maps, slices and float64s), so they are decoded into T.
+func cachedAs[T any](c *cache.DiskCache, key string) (T, bool) {
+	var v T
+	cached, found := c.Get(key)
+	if !found {
+		return v, false
+	}
+	if typed, ok := cached.(T); ok {
+		return typed, true
+	}
+	data, err := json.Marshal(cached)
+	if err != nil {
+		return v, false
+	}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return v, false
+	}
+	return v, true
+}
+
 // cachedPR retrieves a PR from cache if valid.
 func (c *Client) cachedPR(owner, repo string, prNumber int, expectedUpdatedAt *time.Time) (*types.PullRequest, bool) {
 	cacheKey := makeCacheKey("pr", owner, repo, strconv.Itoa(prNumber))
@@ -438,7 +626,7 @@ func (c *Client) Collaborators(ctx context.Context, owner, repo string) ([]strin
 
 	// Use affiliation=all to include both direct collaborators and org members
 	// permission=push ensures we only get users with write access or higher
-	apiURL := fmt.Sprintf("https://api.github.com/repos/%s/%s/collaborators?affiliation=all&permission=push", owner, repo)
+	apiURL := c.api("/repos/%s/%s/collaborators?affiliation=all&permission=push", owner, repo)
 	resp, err := c.doRequest(ctx, "GET", apiURL, nil)
 	if err != nil {
 		return nil, fmt.Errorf("failed to fetch collaborators: %w", err)
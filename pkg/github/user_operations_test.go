@@ -271,10 +271,11 @@ func TestClient_BatchOpenPRCount_AllCached(t *testing.T) {
 		cache: mustNewDiskCache(t),
 	}
 
-	// Pre-cache PR counts for all users
-	c.cache.Set(makeCacheKey("pr-count", "myorg", "alice"), 3)
-	c.cache.Set(makeCacheKey("pr-count", "myorg", "bob"), 5)
-	c.cache.Set(makeCacheKey("pr-count", "myorg", "charlie"), 1)
+	// Pre-cache PR counts for all users; bob's was reloaded from disk as generic JSON
+	fetchedAt := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	c.cache.Set(makeCacheKey("open-prs", "myorg", "alice"), types.OpenPRCount{FetchedAt: fetchedAt, Count: 3})
+	c.cache.Set(makeCacheKey("open-prs", "myorg", "bob"), map[string]any{"FetchedAt": "2024-01-01T12:00:00Z", "Count": float64(5)})
+	c.cache.Set(makeCacheKey("open-prs", "myorg", "charlie"), types.OpenPRCount{FetchedAt: fetchedAt, Count: 1})
 
 	result, err := c.BatchOpenPRCount(context.Background(), "myorg", []string{"alice", "bob", "charlie"}, time.Hour)
 	if err != nil {
@@ -288,8 +289,8 @@ func TestClient_BatchOpenPRCount_AllCached(t *testing.T) {
 	}
 
 	for user, count := range expected {
-		if result[user] != count {
-			t.Errorf("expected count %d for %s, got %d", count, user, result[user])
+		if result[user].Count != count || !result[user].FetchedAt.Equal(fetchedAt) {
+			t.Errorf("expected count %d fetched at %v for %s, got %+v", count, fetchedAt, user, result[user])
 		}
 	}
 }
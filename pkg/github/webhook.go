@@ -0,0 +1,116 @@
+package github
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// WebhookEvent is the part of a webhook delivery needed to find the pull requests it concerns.
+type WebhookEvent struct {
+	Type   string // X-GitHub-Event header, e.g. "pull_request"
+	Action string // e.g. "opened"; empty for status events
+	Owner  string // Repository owner, or the account of an installation event
+	Repo   string // Empty for installation events
+	SHA    string // Commit of a status or check suite event
+	State  string // Status event state: "pending", "success", "failure" or "error"
+	PRs    []int  // Pull requests named by the payload; status events name none
+}
+
+// webhookPayload holds the webhook fields used by ParseWebhookEvent, across event types.
+type webhookPayload struct {
+	Repository struct {
+		Owner struct {
+			Login string `json:"login"`
+		} `json:"owner"`
+		Name string `json:"name"`
+	} `json:"repository"`
+	Installation struct {
+		Account struct {
+			Login string `json:"login"`
+		} `json:"account"`
+	} `json:"installation"`
+	Action     string `json:"action"`
+	SHA        string `json:"sha"`
+	State      string `json:"state"`
+	CheckSuite struct {
+		HeadSHA      string `json:"head_sha"`
+		PullRequests []struct {
+			Base struct {
+				Repo struct {
+					Name string `json:"name"`
+				} `json:"repo"`
+			} `json:"base"`
+			Number int `json:"number"`
+		} `json:"pull_requests"`
+	} `json:"check_suite"`
+	PullRequest struct {
+		Number int `json:"number"`
+	} `json:"pull_request"`
+}
+
+// VerifyWebhookSignature checks a delivery's X-Hub-Signature-256 header, the hex HMAC-SHA256
+// of the body keyed with the webhook secret.
+func VerifyWebhookSignature(secret, body []byte, signature string) error {
+	if len(secret) == 0 {
+		return errors.New("no webhook secret configured")
+	}
+	hexMAC, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return errors.New("missing sha256 signature")
+	}
+	got, err := hex.DecodeString(hexMAC)
+	if err != nil {
+		return errors.New("malformed signature")
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errors.New("signature mismatch")
+	}
+	return nil
+}
+
+// ParseWebhookEvent extracts the repository, pull requests and state from a webhook payload.
+// eventType is the X-GitHub-Event header.
+func ParseWebhookEvent(eventType string, body []byte) (*WebhookEvent, error) {
+	var p webhookPayload
+	if err := json.Unmarshal(body, &p); err != nil {
+		return nil, fmt.Errorf("failed to decode %s payload: %w", eventType, err)
+	}
+
+	e := &WebhookEvent{
+		Type:   eventType,
+		Action: p.Action,
+		Owner:  p.Repository.Owner.Login,
+		Repo:   p.Repository.Name,
+	}
+	switch eventType {
+	case "pull_request":
+		if p.PullRequest.Number > 0 {
+			e.PRs = []int{p.PullRequest.Number}
+		}
+	case "check_suite":
+		e.SHA = p.CheckSuite.HeadSHA
+		for _, pr := range p.CheckSuite.PullRequests {
+			// Suites on a fork can list PRs into other repositories
+			if pr.Base.Repo.Name == "" || pr.Base.Repo.Name == e.Repo {
+				e.PRs = append(e.PRs, pr.Number)
+			}
+		}
+	case "status":
+		e.SHA, e.State = p.SHA, p.State
+	case "installation", "installation_repositories":
+		e.Owner = p.Installation.Account.Login
+	default:
+	}
+
+	if e.Owner == "" {
+		return nil, fmt.Errorf("%s payload names no repository or installation account", eventType)
+	}
+	return e, nil
+}
@@ -0,0 +1,134 @@
+package github
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func sign(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifyWebhookSignature(t *testing.T) {
+	const body = `{"action": "opened"}`
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		wantErr   bool
+	}{
+		{name: "valid", secret: "s3cret", signature: sign("s3cret", body)},
+		{name: "wrong secret", secret: "s3cret", signature: sign("other", body), wantErr: true},
+		{name: "tampered body", secret: "s3cret", signature: sign("s3cret", body+" "), wantErr: true},
+		{name: "sha1 only", secret: "s3cret", signature: "sha1=abc", wantErr: true},
+		{name: "not hex", secret: "s3cret", signature: "sha256=zz", wantErr: true},
+		{name: "missing", secret: "s3cret", wantErr: true},
+		{name: "no secret", signature: sign("", body), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhookSignature([]byte(tt.secret), []byte(body), tt.signature)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("VerifyWebhookSignature() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseWebhookEvent(t *testing.T) {
+	const repository = `"repository": {"name": "repo", "owner": {"login": "org"}}`
+
+	tests := []struct {
+		name      string
+		eventType string
+		body      string
+		want      WebhookEvent
+		wantErr   bool
+	}{
+		{
+			name:      "pull request",
+			eventType: "pull_request",
+			body:      `{"action": "ready_for_review", "pull_request": {"number": 12}, ` + repository + `}`,
+			want:      WebhookEvent{Type: "pull_request", Action: "ready_for_review", Owner: "org", Repo: "repo", PRs: []int{12}},
+		},
+		{
+			name:      "check suite",
+			eventType: "check_suite",
+			body: `{"action": "completed", "check_suite": {"head_sha": "abc", "pull_requests": [
+				{"number": 3, "base": {"repo": {"name": "repo"}}},
+				{"number": 4, "base": {"repo": {"name": "elsewhere"}}}]}, ` + repository + `}`,
+			want: WebhookEvent{Type: "check_suite", Action: "completed", Owner: "org", Repo: "repo", SHA: "abc", PRs: []int{3}},
+		},
+		{
+			name:      "status",
+			eventType: "status",
+			body:      `{"sha": "def", "state": "success", ` + repository + `}`,
+			want:      WebhookEvent{Type: "status", Owner: "org", Repo: "repo", SHA: "def", State: "success"},
+		},
+		{
+			name:      "installation",
+			eventType: "installation",
+			body:      `{"action": "created", "installation": {"account": {"login": "neworg"}}}`,
+			want:      WebhookEvent{Type: "installation", Action: "created", Owner: "neworg"},
+		},
+		{name: "no repository", eventType: "pull_request", body: `{"action": "opened"}`, wantErr: true},
+		{name: "malformed", eventType: "pull_request", body: `{`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseWebhookEvent(tt.eventType, []byte(tt.body))
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseWebhookEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if got.Type != tt.want.Type || got.Action != tt.want.Action || got.Owner != tt.want.Owner || got.Repo != tt.want.Repo ||
+				got.SHA != tt.want.SHA || got.State != tt.want.State || !slices.Equal(got.PRs, tt.want.PRs) {
+				t.Errorf("ParseWebhookEvent() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestClient_OpenPullRequestsForCommit(t *testing.T) {
+	var gotPath string
+	mockTransport := &mockRoundTripperFunc{
+		roundTripFunc: func(req *http.Request) (*http.Response, error) {
+			gotPath = req.URL.Path
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(strings.NewReader(`[{"number": 5, "state": "open"}, {"number": 2, "state": "closed"}]`)),
+				Header:     make(http.Header),
+			}, nil
+		},
+	}
+
+	c := &Client{
+		cache:      mustNewDiskCache(t),
+		httpClient: &http.Client{Transport: mockTransport},
+		token:      "test-token",
+	}
+	prs, err := c.OpenPullRequestsForCommit(context.Background(), "owner", "repo", "abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if gotPath != "/repos/owner/repo/commits/abc123/pulls" {
+		t.Errorf("requested %s", gotPath)
+	}
+	if !slices.Equal(prs, []int{5}) {
+		t.Errorf("OpenPullRequestsForCommit() = %v, want [5]", prs)
+	}
+}
@@ -26,17 +26,24 @@ type MockGitHubClient struct {
 	isUserAccount     map[string]bool
 	graphQLResponses  map[string]map[string]any
 	batchPRCounts     map[string]map[string]int
+	prCountsFetchedAt map[string]time.Time
+	responseTimes     map[string]map[string]types.ResponseTime
+	fileContents      map[string]string
+	teamMembers       map[string][]string
+	mergeBases        map[string]string
+	comments          map[string]string
 	currentOrg        string
 	addReviewersCalls []AddReviewersCall
 	installations     []string
 	mu                sync.RWMutex
 }
 
-// AddReviewersCall records a call to AddReviewers.
+// AddReviewersCall records a call to AddReviewers or AddReviewRequests.
 type AddReviewersCall struct {
 	Owner     string
 	Repo      string
 	Reviewers []string
+	Teams     []string
 	PRNumber  int
 }
 
@@ -51,8 +58,14 @@ func NewMockGitHubClient() *MockGitHubClient {
 		writeAccess:       make(map[string]bool),
 		openPRCounts:      make(map[string]int),
 		batchPRCounts:     make(map[string]map[string]int),
+		prCountsFetchedAt: make(map[string]time.Time),
+		responseTimes:     make(map[string]map[string]types.ResponseTime),
 		graphQLResponses:  make(map[string]map[string]any),
 		isUserAccount:     make(map[string]bool),
+		fileContents:      make(map[string]string),
+		teamMembers:       make(map[string][]string),
+		mergeBases:        make(map[string]string),
+		comments:          make(map[string]string),
 		addReviewersCalls: []AddReviewersCall{},
 		errors:            make(map[string]error),
 	}
@@ -173,6 +186,11 @@ func (m *MockGitHubClient) FilePatch(ctx context.Context, owner, repo string, pr
 
 // AddReviewers records the call and returns success.
 func (m *MockGitHubClient) AddReviewers(ctx context.Context, owner, repo string, prNumber int, reviewers []string) error {
+	return m.AddReviewRequests(ctx, owner, repo, prNumber, reviewers, nil)
+}
+
+// AddReviewRequests records the call and returns success.
+func (m *MockGitHubClient) AddReviewRequests(ctx context.Context, owner, repo string, prNumber int, reviewers, teams []string) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
 
@@ -186,10 +204,31 @@ func (m *MockGitHubClient) AddReviewers(ctx context.Context, owner, repo string,
 		Repo:      repo,
 		PRNumber:  prNumber,
 		Reviewers: reviewers,
+		Teams:     teams,
 	})
 	return nil
 }
 
+// UpsertComment records the comment body posted with marker on a PR, replacing any earlier one.
+func (m *MockGitHubClient) UpsertComment(ctx context.Context, owner, repo string, prNumber int, marker, body string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := fmt.Sprintf("%s/%s/%d", owner, repo, prNumber)
+	if err := m.errors[fmt.Sprintf("UpsertComment:%s", key)]; err != nil {
+		return err
+	}
+	m.comments[key+":"+marker] = body
+	return nil
+}
+
+// Comment returns the body of the comment posted with marker on a PR, or "" if none was.
+func (m *MockGitHubClient) Comment(owner, repo string, prNumber int, marker string) string {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.comments[fmt.Sprintf("%s/%s/%d:%s", owner, repo, prNumber, marker)]
+}
+
 // IsUserBot checks if a user is configured as a bot.
 func (m *MockGitHubClient) IsUserBot(ctx context.Context, username string) bool {
 	m.mu.RLock()
@@ -223,8 +262,9 @@ func (m *MockGitHubClient) OpenPRCount(ctx context.Context, org, user string, _
 	return count, nil
 }
 
-// BatchOpenPRCount returns configured PR counts for multiple users in an org.
-func (m *MockGitHubClient) BatchOpenPRCount(ctx context.Context, org string, users []string, _ time.Duration) (map[string]int, error) {
+// BatchOpenPRCount returns configured PR counts for multiple users in an org, fetched at
+// the time set with SetOpenPRCountsFetchedAt (zero by default).
+func (m *MockGitHubClient) BatchOpenPRCount(ctx context.Context, org string, users []string, _ time.Duration) (map[string]types.OpenPRCount, error) {
 	m.mu.RLock()
 	defer m.mu.RUnlock()
 
@@ -232,28 +272,31 @@ func (m *MockGitHubClient) BatchOpenPRCount(ctx context.Context, org string, use
 		return nil, err
 	}
 
-	// If we have batch counts configured for this org, use them
-	if orgCounts, ok := m.batchPRCounts[org]; ok {
-		result := make(map[string]int)
-		for _, user := range users {
-			if count, exists := orgCounts[user]; exists {
-				result[user] = count
-			} else {
-				result[user] = 0
-			}
+	// If we have batch counts configured for this org, use them, else fall back to individual counts
+	result := make(map[string]types.OpenPRCount)
+	orgCounts, batch := m.batchPRCounts[org]
+	for _, user := range users {
+		count := m.openPRCounts[fmt.Sprintf("%s:%s", org, user)]
+		if batch {
+			count = orgCounts[user]
 		}
-		return result, nil
+		result[user] = types.OpenPRCount{FetchedAt: m.prCountsFetchedAt[org], Count: count}
 	}
+	return result, nil
+}
 
-	// Fall back to individual counts
-	result := make(map[string]int)
+// ReviewResponseTimes returns configured response times for users in an org.
+// Users without one get a zero ResponseTime.
+func (m *MockGitHubClient) ReviewResponseTimes(ctx context.Context, org string, users []string) (map[string]types.ResponseTime, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if err := m.errors[fmt.Sprintf("ReviewResponseTimes:%s", org)]; err != nil {
+		return nil, err
+	}
+	result := make(map[string]types.ResponseTime, len(users))
 	for _, user := range users {
-		key := fmt.Sprintf("%s:%s", org, user)
-		if count, ok := m.openPRCounts[key]; ok {
-			result[user] = count
-		} else {
-			result[user] = 0
-		}
+		result[user] = m.responseTimes[org][user]
 	}
 	return result, nil
 }
@@ -275,6 +318,61 @@ func (m *MockGitHubClient) Collaborators(ctx context.Context, owner, repo string
 	return collabs, nil
 }
 
+// FileContent returns configured file contents, or github.ErrNotFound. Contents set for
+// ref with SetFileContentAt take precedence over those set with SetFileContent.
+func (m *MockGitHubClient) FileContent(ctx context.Context, owner, repo, path, ref string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	key := fmt.Sprintf("%s/%s/%s", owner, repo, path)
+	if err := m.errors[fmt.Sprintf("FileContent:%s", key)]; err != nil {
+		return "", err
+	}
+
+	if content, ok := m.fileContents[key+"@"+ref]; ok && ref != "" {
+		return content, nil
+	}
+	content, ok := m.fileContents[key]
+	if !ok {
+		return "", github.ErrNotFound
+	}
+	return content, nil
+}
+
+// TeamMembers returns configured team members.
+func (m *MockGitHubClient) TeamMembers(ctx context.Context, org, teamSlug string) ([]string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	key := fmt.Sprintf("%s/%s", org, teamSlug)
+	if err := m.errors[fmt.Sprintf("TeamMembers:%s", key)]; err != nil {
+		return nil, err
+	}
+
+	members, ok := m.teamMembers[key]
+	if !ok {
+		return nil, github.ErrNotFound
+	}
+	return members, nil
+}
+
+// MergeBase returns a configured merge base, or github.ErrNotFound.
+func (m *MockGitHubClient) MergeBase(ctx context.Context, owner, repo, base, head string) (string, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	key := fmt.Sprintf("%s/%s/%s...%s", owner, repo, base, head)
+	if err := m.errors[fmt.Sprintf("MergeBase:%s", key)]; err != nil {
+		return "", err
+	}
+
+	sha, ok := m.mergeBases[key]
+	if !ok {
+		return "", github.ErrNotFound
+	}
+	return sha, nil
+}
+
 // MakeGraphQLRequest returns a configured GraphQL response.
 func (m *MockGitHubClient) MakeGraphQLRequest(ctx context.Context, query string, _ map[string]any) (map[string]any, error) {
 	m.mu.RLock()
@@ -381,6 +479,14 @@ func (m *MockGitHubClient) SetOpenPRCount(org, username string, count int) {
 	m.openPRCounts[key] = count
 }
 
+// SetReviewResponseTimes configures review response times for users in an org.
+func (m *MockGitHubClient) SetReviewResponseTimes(org string, times map[string]types.ResponseTime) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.responseTimes[org] = times
+}
+
 // SetBatchOpenPRCount configures PR counts for multiple users in an org.
 func (m *MockGitHubClient) SetBatchOpenPRCount(org string, counts map[string]int) {
 	m.mu.Lock()
@@ -389,6 +495,14 @@ func (m *MockGitHubClient) SetBatchOpenPRCount(org string, counts map[string]int
 	m.batchPRCounts[org] = counts
 }
 
+// SetOpenPRCountsFetchedAt configures when an org's PR counts were fetched.
+func (m *MockGitHubClient) SetOpenPRCountsFetchedAt(org string, at time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.prCountsFetchedAt[org] = at
+}
+
 // SetGraphQLResponse configures a GraphQL response.
 func (m *MockGitHubClient) SetGraphQLResponse(query string, response map[string]any) {
 	m.mu.Lock()
@@ -397,6 +511,42 @@ func (m *MockGitHubClient) SetGraphQLResponse(query string, response map[string]
 	m.graphQLResponses[query] = response
 }
 
+// SetFileContent configures the contents of a repository file.
+func (m *MockGitHubClient) SetFileContent(owner, repo, path, content string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := fmt.Sprintf("%s/%s/%s", owner, repo, path)
+	m.fileContents[key] = content
+}
+
+// SetFileContentAt configures the contents of a file at a specific ref.
+func (m *MockGitHubClient) SetFileContentAt(owner, repo, path, ref, content string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := fmt.Sprintf("%s/%s/%s@%s", owner, repo, path, ref)
+	m.fileContents[key] = content
+}
+
+// SetTeamMembers configures the members of an organization team.
+func (m *MockGitHubClient) SetTeamMembers(org, teamSlug string, members []string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := fmt.Sprintf("%s/%s", org, teamSlug)
+	m.teamMembers[key] = members
+}
+
+// SetMergeBase configures the merge base of base and head.
+func (m *MockGitHubClient) SetMergeBase(owner, repo, base, head, sha string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := fmt.Sprintf("%s/%s/%s...%s", owner, repo, base, head)
+	m.mergeBases[key] = sha
+}
+
 // SetError configures an error for a specific method and parameters.
 func (m *MockGitHubClient) SetError(methodWithParams string, err error) {
 	m.mu.Lock()
@@ -0,0 +1,137 @@
+package reviewer
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+// authorPRsQuery fetches an author's most recent merged PRs in a repository with their approvers.
+const authorPRsQuery = `
+	query($query: String!, $limit: Int!) {
+		search(query: $query, type: ISSUE, first: $limit) {
+			nodes {
+				... on PullRequest {
+					number
+					merged
+					mergedAt
+					author {
+						login
+					}
+					reviews(first: 10, states: APPROVED) {
+						nodes {
+							author {
+								login
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
+
+// affinity is a user's author-affinity score and the approvals behind it.
+type affinity struct {
+	evidence []types.Evidence
+	score    int
+}
+
+// authorAffinityScores returns the author-affinity score of each user who approved the PR
+// author's recent merged PRs: AuthorAffinity per approval, decayed by age. Scores are capped
+// at maxAffinityApprovals approvals and, when anyone has line-level expertise, at half the
+// best blame score, so approving the author's work alone never outranks the strongest
+// line-level expert.
+func (f *Finder) authorAffinityScores(
+	ctx context.Context, pr *types.PullRequest, candidates map[string]*candidateWeight, w Weights,
+) map[string]affinity {
+	if w.AuthorAffinity == 0 || pr.Author == "" {
+		return nil
+	}
+
+	authorPRs, err := f.authorMergedPRs(ctx, pr.Owner, pr.Repository, pr.Author)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to fetch author's recent PRs, continuing without author affinity", "author", pr.Author, "error", err)
+		return nil
+	}
+
+	limit := maxAffinityApprovals * w.AuthorAffinity
+	if best := bestBlameScore(candidates); best > 0 {
+		limit = min(limit, best/2)
+	}
+
+	now := f.now()
+	scores := make(map[string]affinity)
+	for _, authorPR := range authorPRs {
+		if authorPR.Number == pr.Number {
+			continue
+		}
+		perApproval := w.decay(w.AuthorAffinity, authorPR.MergedAt, now)
+		for _, reviewer := range authorPR.Reviewers {
+			if reviewer == "" || reviewer == pr.Author || f.client.IsUserBot(ctx, reviewer) {
+				continue
+			}
+			a := scores[reviewer]
+			if a.score >= limit {
+				continue
+			}
+			points := min(perApproval, limit-a.score)
+			a.score += points
+			a.evidence = append(a.evidence, types.Evidence{
+				Source: "author-affinity", PR: authorPR.Number, MergedAt: authorPR.MergedAt, Score: points,
+			})
+			scores[reviewer] = a
+		}
+	}
+	return scores
+}
+
+// bestBlameScore returns the highest line-level (blame) score of any candidate.
+func bestBlameScore(candidates map[string]*candidateWeight) int {
+	best := 0
+	for _, c := range candidates {
+		best = max(best, c.sourceScores["blame-author"]+c.sourceScores["blame-merger"]+c.sourceScores["blame-reviewer"])
+	}
+	return best
+}
+
+// authorMergedPRs returns the author's most recent merged PRs in the repository, with approvers as Reviewers.
+func (f *Finder) authorMergedPRs(ctx context.Context, owner, repo, author string) ([]types.PRInfo, error) {
+	cacheKey := makeCacheKey("author-prs", owner, repo, author)
+	if cached, found := f.cache.Get(cacheKey); found {
+		if prs, ok := cached.([]types.PRInfo); ok {
+			return prs, nil
+		}
+	}
+
+	variables := map[string]any{
+		"query": fmt.Sprintf("repo:%s/%s is:pr is:merged author:%s sort:updated-desc", owner, repo, author),
+		"limit": authorAffinityPRs,
+	}
+	result, err := f.client.MakeGraphQLRequest(ctx, authorPRsQuery, variables)
+	if err != nil {
+		return nil, fmt.Errorf("GraphQL request failed: %w", err)
+	}
+
+	var prs []types.PRInfo
+	if data, ok := mapValue(result, "data"); ok {
+		if search, ok := mapValue(data, "search"); ok {
+			nodes, _ := sliceNodes(search)
+			for _, node := range nodes {
+				prNode, ok := node.(map[string]any)
+				if !ok {
+					continue
+				}
+				if prInfo := parsePRNode(prNode); prInfo != nil {
+					prs = append(prs, *prInfo)
+				}
+			}
+		}
+	}
+
+	slog.InfoContext(ctx, "Fetched author's recent merged PRs", "author", author, "count", len(prs))
+	f.cache.SetWithTTL(cacheKey, prs, 6*time.Hour)
+	return prs, nil
+}
@@ -0,0 +1,82 @@
+package reviewer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/internal/testutil"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+// authorPRNode builds a merged PR search result approved by reviewers.
+func authorPRNode(number int, mergedAt time.Time, reviewers ...string) map[string]any {
+	reviews := make([]any, len(reviewers))
+	for i, r := range reviewers {
+		reviews[i] = map[string]any{"author": map[string]any{"login": r}}
+	}
+	return map[string]any{
+		"number":   float64(number),
+		"merged":   true,
+		"mergedAt": mergedAt.Format(time.RFC3339),
+		"author":   map[string]any{"login": "alice"},
+		"reviews":  map[string]any{"nodes": reviews},
+	}
+}
+
+func TestFinder_authorAffinityScores(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	client := testutil.NewMockGitHubClient()
+	client.SetBotUser("ci-bot", true)
+
+	var nodes []any
+	for i := range 8 {
+		nodes = append(nodes, authorPRNode(100+i, now.AddDate(0, 0, -i), "mentor", "ci-bot"))
+	}
+	nodes = append(nodes, authorPRNode(90, now, "peer", "alice"))
+	client.SetGraphQLResponse(authorPRsQuery, map[string]any{
+		"data": map[string]any{"search": map[string]any{"nodes": nodes}},
+	})
+
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+	finder.now = func() time.Time { return now }
+	w := DefaultWeights()
+	pr := &types.PullRequest{Owner: "owner", Repository: "repo", Number: 200, Author: "alice"}
+
+	t.Run("capped at max approvals", func(t *testing.T) {
+		scores := finder.authorAffinityScores(context.Background(), pr, map[string]*candidateWeight{}, w)
+		if got := scores["mentor"].score; got != maxAffinityApprovals*w.AuthorAffinity {
+			t.Errorf("mentor score = %d, want %d", got, maxAffinityApprovals*w.AuthorAffinity)
+		}
+		if got := scores["peer"].score; got != w.AuthorAffinity {
+			t.Errorf("peer score = %d, want %d", got, w.AuthorAffinity)
+		}
+		if _, ok := scores["ci-bot"]; ok {
+			t.Error("expected bots to get no affinity")
+		}
+		if _, ok := scores["alice"]; ok {
+			t.Error("expected the author to get no affinity")
+		}
+		if e := scores["peer"].evidence; len(e) != 1 || e[0].PR != 90 || e[0].Source != "author-affinity" {
+			t.Errorf("unexpected evidence %+v", e)
+		}
+	})
+
+	t.Run("capped below line-level expertise", func(t *testing.T) {
+		candidates := map[string]*candidateWeight{
+			"expert": {username: "expert", sourceScores: map[string]int{"blame-author": 8, "blame-merger": 4}},
+		}
+		scores := finder.authorAffinityScores(context.Background(), pr, candidates, w)
+		if got := scores["mentor"].score; got != 6 {
+			t.Errorf("mentor score = %d, want half the best blame score (6)", got)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		w := w
+		w.AuthorAffinity = 0
+		if scores := finder.authorAffinityScores(context.Background(), pr, nil, w); scores != nil {
+			t.Errorf("expected no affinity with author_affinity=0, got %v", scores)
+		}
+	})
+}
@@ -0,0 +1,17 @@
+package reviewer
+
+// apiBudget caps the GraphQL requests spent on one PR's file and directory history.
+type apiBudget struct {
+	limit int
+	used  int
+}
+
+// spend uses one request from the budget if doing so leaves at least reserve requests
+// for later stages. Returns false if the request would exceed the budget.
+func (b *apiBudget) spend(reserve int) bool {
+	if b.used+reserve >= b.limit {
+		return false
+	}
+	b.used++
+	return true
+}
@@ -0,0 +1,227 @@
+package reviewer
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+// codeownersPaths lists the locations GitHub searches for a CODEOWNERS file, in precedence order.
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// codeownersRule is a single pattern line from a CODEOWNERS file.
+type codeownersRule struct {
+	re      *regexp.Regexp
+	pattern string
+	owners  []string // "@user", "@org/team", or email addresses
+}
+
+// codeowners holds the parsed rules of a CODEOWNERS file.
+type codeowners struct {
+	rules []codeownersRule
+}
+
+// parseCodeowners parses CODEOWNERS content. Invalid patterns are skipped.
+func parseCodeowners(content string) *codeowners {
+	co := &codeowners{}
+	for _, line := range strings.Split(content, "\n") {
+		line = strings.TrimSpace(line)
+		if strings.HasPrefix(line, "[") {
+			continue
+		}
+		fields := codeownersFields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		re, err := codeownersPatternToRegexp(fields[0])
+		if err != nil {
+			slog.Warn("Skipping invalid CODEOWNERS pattern", "pattern", fields[0], "error", err)
+			continue
+		}
+		co.rules = append(co.rules, codeownersRule{
+			pattern: fields[0],
+			re:      re,
+			owners:  fields[1:],
+		})
+	}
+	return co
+}
+
+// codeownersFields splits a CODEOWNERS line into its pattern and owners, dropping any
+// comment. A backslash escapes the next character, so "\#" and "\ " stay part of a field;
+// escapes are kept for codeownersPatternToRegexp to interpret.
+func codeownersFields(line string) []string {
+	var fields []string
+	var field strings.Builder
+	inField := false
+	for i := 0; i < len(line); i++ {
+		ch := line[i]
+		switch {
+		case ch == '\\' && i+1 < len(line):
+			field.WriteByte(ch)
+			field.WriteByte(line[i+1])
+			i++
+			inField = true
+		case ch == ' ' || ch == '\t':
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		case ch == '#' && !inField:
+			// A comment runs to the end of the line
+			return fields
+		default:
+			field.WriteByte(ch)
+			inField = true
+		}
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields
+}
+
+// ownersFor returns the owners of a path. The last matching rule wins, and a matching
+// rule without owners leaves the path unowned.
+func (co *codeowners) ownersFor(path string) []string {
+	for i := len(co.rules) - 1; i >= 0; i-- {
+		if co.rules[i].re.MatchString(path) {
+			return co.rules[i].owners
+		}
+	}
+	return nil
+}
+
+// codeownersPatternToRegexp converts a gitignore-style CODEOWNERS pattern to a regular expression.
+func codeownersPatternToRegexp(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	p := strings.TrimSuffix(pattern, "/")
+	// Patterns with a leading or inner slash are relative to the repository root
+	anchored := strings.Contains(p, "/")
+	p = strings.TrimPrefix(p, "/")
+	if p == "" {
+		return nil, errors.New("empty pattern")
+	}
+
+	var sb strings.Builder
+	if anchored {
+		sb.WriteString("^")
+	} else {
+		sb.WriteString("^(?:.*/)?")
+	}
+
+	for i := 0; i < len(p); i++ {
+		ch := p[i]
+		switch {
+		case strings.HasPrefix(p[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(p[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case ch == '*':
+			sb.WriteString("[^/]*")
+		case ch == '?':
+			sb.WriteString("[^/]")
+		case ch == '\\' && i+1 < len(p):
+			// An escaped character matches literally
+			i++
+			sb.WriteString(regexp.QuoteMeta(string(p[i])))
+		default:
+			sb.WriteString(regexp.QuoteMeta(string(ch)))
+		}
+	}
+
+	lastSegment := p[strings.LastIndex(p, "/")+1:]
+	switch {
+	case dirOnly:
+		sb.WriteString("/.*$")
+	case strings.Contains(lastSegment, "*") && !strings.Contains(lastSegment, "**"):
+		// "docs/*" matches files directly in docs/, not nested ones
+		sb.WriteString("$")
+	default:
+		// A pattern naming a directory also matches everything beneath it
+		sb.WriteString("(?:/.*)?$")
+	}
+
+	return regexp.Compile(sb.String())
+}
+
+// repoCodeowners fetches and parses the repository's CODEOWNERS file at ref, the branch a
+// PR merges into; an empty ref reads the default branch. Returns nil if there is none.
+func (f *Finder) repoCodeowners(ctx context.Context, owner, repo, ref string) *codeowners {
+	cacheKey := makeCacheKey("codeowners", owner, repo, ref)
+	if cached, found := f.cache.Get(cacheKey); found {
+		if co, ok := cached.(*codeowners); ok {
+			return co
+		}
+	}
+
+	var co *codeowners
+	for _, path := range codeownersPaths {
+		content, err := f.client.FileContent(ctx, owner, repo, path, ref)
+		if errors.Is(err, github.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			slog.WarnContext(ctx, "Failed to fetch CODEOWNERS, continuing without", "path", path, "error", err)
+			return nil
+		}
+		co = parseCodeowners(content)
+		slog.InfoContext(ctx, "Loaded CODEOWNERS", "owner", owner, "repo", repo, "ref", ref, "path", path, "rules", len(co.rules))
+		break
+	}
+
+	f.cache.SetWithTTL(cacheKey, co, 6*time.Hour)
+	return co
+}
+
+// codeownerFiles returns the PR's changed files owned by each code owner.
+// Team owners (@org/team) are expanded to their members; email owners are ignored.
+func (f *Finder) codeownerFiles(ctx context.Context, pr *types.PullRequest) map[string][]string {
+	co := f.repoCodeowners(ctx, pr.Owner, pr.Repository, pr.BaseRef)
+	if co == nil || len(co.rules) == 0 {
+		return nil
+	}
+
+	files := make(map[string][]string)
+	for _, file := range pr.ChangedFiles {
+		seen := make(map[string]bool)
+		for _, owner := range co.ownersFor(file.Filename) {
+			for _, login := range f.expandCodeowner(ctx, owner) {
+				if !seen[login] {
+					seen[login] = true
+					files[login] = append(files[login], file.Filename)
+				}
+			}
+		}
+	}
+	return files
+}
+
+// expandCodeowner resolves a CODEOWNERS owner entry to user logins.
+func (f *Finder) expandCodeowner(ctx context.Context, owner string) []string {
+	if !strings.HasPrefix(owner, "@") {
+		return nil // Email owners cannot be mapped to logins
+	}
+	name := strings.TrimPrefix(owner, "@")
+
+	org, team, isTeam := strings.Cut(name, "/")
+	if !isTeam {
+		return []string{name}
+	}
+
+	members, err := f.client.TeamMembers(ctx, org, team)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to expand CODEOWNERS team", "team", name, "error", err)
+		return nil
+	}
+	return members
+}
@@ -0,0 +1,180 @@
+package reviewer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/internal/testutil"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+func TestCodeowners_ownersFor(t *testing.T) {
+	co := parseCodeowners(`# Default owners
+*       @global-owner
+
+# Language globs
+*.js    @js-owner # trailing comment
+**/logs @log-owner
+/build/logs/ @build-owner
+docs/*  @docs-owner
+apps/   @apps-owner
+/scripts/ @scripts-owner @org/infra
+/scripts/generated
+`)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "main.go", want: []string{"@global-owner"}},
+		{path: "web/app.js", want: []string{"@js-owner"}},
+		{path: "build/logs/out.txt", want: []string{"@build-owner"}},
+		{path: "docs/getting-started.md", want: []string{"@docs-owner"}},
+		{path: "docs/build-app/troubleshooting.md", want: []string{"@global-owner"}},
+		{path: "src/apps/server/main.go", want: []string{"@apps-owner"}},
+		{path: "deep/nested/logs/today.txt", want: []string{"@log-owner"}},
+		{path: "scripts/deploy.sh", want: []string{"@scripts-owner", "@org/infra"}},
+		{path: "scripts/generated/stub.go", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			got := co.ownersFor(tt.path)
+			if len(got) == 0 && len(tt.want) == 0 {
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ownersFor(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCodeowners_NoMatch(t *testing.T) {
+	co := parseCodeowners("/pkg/ @alice\n")
+	if owners := co.ownersFor("cmd/main.go"); owners != nil {
+		t.Errorf("expected no owners, got %v", owners)
+	}
+}
+
+func TestCodeowners_Escapes(t *testing.T) {
+	co := parseCodeowners(`\#notes.md @hash-owner # not part of the pattern
+docs/release\ notes.md @space-owner
+\*.txt @star-owner
+`)
+
+	tests := []struct {
+		path string
+		want []string
+	}{
+		{path: "#notes.md", want: []string{"@hash-owner"}},
+		{path: "docs/release notes.md", want: []string{"@space-owner"}},
+		{path: "*.txt", want: []string{"@star-owner"}},
+		{path: "readme.txt", want: nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := co.ownersFor(tt.path); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ownersFor(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinder_codeownerFiles(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("owner", "repo", "CODEOWNERS", "/pkg/ @alice @acme/core\n/docs/ @bob\n")
+	client.SetTeamMembers("acme", "core", []string{"carol", "alice"})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	pr := &types.PullRequest{
+		Owner:      "owner",
+		Repository: "repo",
+		ChangedFiles: []types.ChangedFile{
+			{Filename: "pkg/a.go"},
+			{Filename: "pkg/b.go"},
+			{Filename: "docs/readme.md"},
+			{Filename: "Makefile"},
+		},
+	}
+
+	got := finder.codeownerFiles(ctx, pr)
+	want := map[string][]string{
+		"alice": {"pkg/a.go", "pkg/b.go"},
+		"carol": {"pkg/a.go", "pkg/b.go"},
+		"bob":   {"docs/readme.md"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("codeownerFiles() = %v, want %v", got, want)
+	}
+}
+
+func TestFinder_codeownerFiles_NoFile(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	pr := &types.PullRequest{Owner: "owner", Repository: "repo", ChangedFiles: []types.ChangedFile{{Filename: "a.go"}}}
+	if got := finder.codeownerFiles(context.Background(), pr); got != nil {
+		t.Errorf("expected nil files without CODEOWNERS, got %v", got)
+	}
+}
+
+func TestFinder_repoCodeowners_Precedence(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("owner", "repo", ".github/CODEOWNERS", "* @from-github-dir\n")
+	client.SetFileContent("owner", "repo", "CODEOWNERS", "* @from-root\n")
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	co := finder.repoCodeowners(context.Background(), "owner", "repo", "")
+	if co == nil {
+		t.Fatal("expected CODEOWNERS to be loaded")
+	}
+	if owners := co.ownersFor("main.go"); !reflect.DeepEqual(owners, []string{"@from-github-dir"}) {
+		t.Errorf("expected .github/CODEOWNERS to take precedence, got %v", owners)
+	}
+}
+
+func TestFinder_repoCodeowners_BaseRef(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("owner", "repo", "CODEOWNERS", "* @main-owner\n")
+	client.SetFileContentAt("owner", "repo", "CODEOWNERS", "release-1.2", "* @release-owner\n")
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	pr := &types.PullRequest{Owner: "owner", Repository: "repo", BaseRef: "release-1.2", ChangedFiles: []types.ChangedFile{{Filename: "main.go"}}}
+	if got, want := finder.codeownerFiles(context.Background(), pr), map[string][]string{"release-owner": {"main.go"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("codeownerFiles() = %v, want %v", got, want)
+	}
+
+	pr.BaseRef = "main"
+	if got, want := finder.codeownerFiles(context.Background(), pr), map[string][]string{"main-owner": {"main.go"}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("codeownerFiles() = %v, want %v", got, want)
+	}
+}
+
+func TestFindReviewersOptimized_CodeownerSource(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("owner", "repo", ".github/CODEOWNERS", "*.go @owner-dev\n")
+	client.SetWriteAccess("owner", "repo", "owner-dev", true)
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	pr := &types.PullRequest{
+		Owner:        "owner",
+		Repository:   "repo",
+		Author:       "author",
+		ChangedFiles: []types.ChangedFile{{Filename: "main.go", Additions: 1}},
+	}
+
+	candidates, _ := finder.findReviewersOptimized(ctx, pr, config.Default())
+	if len(candidates) != 1 || candidates[0].Username != "owner-dev" {
+		t.Fatalf("expected owner-dev as the only candidate, got %+v", candidates)
+	}
+	if candidates[0].ContextScore != 10 {
+		t.Errorf("expected codeowner score 10, got %d", candidates[0].ContextScore)
+	}
+}
@@ -0,0 +1,116 @@
+package reviewer
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+// CommentMarker is a hidden HTML comment identifying the bot's explanatory PR comment,
+// so later runs update it instead of posting another.
+const CommentMarker = "<!-- best-reviewer:explanation -->"
+
+// Explanatory comment limits.
+const (
+	commentEvidence  = 5 // Evidence entries listed per requested reviewer
+	commentRunnersUp = 3 // Next-best candidates listed
+)
+
+// Explanation is what an explanatory PR comment describes.
+type Explanation struct {
+	Rule      string                    // Reason of the route rule that chose the reviewers ("" when they were scored)
+	Org       string                    // Organization the requested teams belong to
+	Teams     []string                  // Requested team slugs
+	Chosen    []types.ReviewerCandidate // Requested reviewers, best first
+	RunnersUp []types.ReviewerCandidate // Candidates not requested, best first
+}
+
+// Markdown renders the explanation as a comment body starting with CommentMarker.
+// Runners-up are named without an @ so they are not notified.
+func (e *Explanation) Markdown() string {
+	var b strings.Builder
+	b.WriteString(CommentMarker + "\n")
+	b.WriteString("### Why these reviewers?\n\n")
+
+	requested := make([]string, 0, len(e.Chosen)+len(e.Teams))
+	for _, c := range e.Chosen {
+		requested = append(requested, "@"+c.Username)
+	}
+	for _, team := range e.Teams {
+		requested = append(requested, "@"+e.Org+"/"+team)
+	}
+	fmt.Fprintf(&b, "Requested review from %s", strings.Join(requested, ", "))
+	if e.Rule != "" {
+		fmt.Fprintf(&b, " because of a routing rule (%s).\n", e.Rule)
+		return b.String()
+	}
+	b.WriteString(" based on who knows the changed code.\n")
+
+	for _, c := range e.Chosen {
+		fmt.Fprintf(&b, "\n**@%s** (score %d)", c.Username, c.ContextScore)
+		if c.Breakdown == nil {
+			fmt.Fprintf(&b, ": %s\n", c.SelectionMethod)
+			continue
+		}
+		if c.Breakdown.OpenPRs > 0 {
+			fmt.Fprintf(&b, ", %d open PRs", c.Breakdown.OpenPRs)
+		}
+		b.WriteString("\n")
+		for _, ev := range topEvidence(c.Breakdown.Evidence, commentEvidence) {
+			fmt.Fprintf(&b, "- %s\n", explainEvidence(ev))
+		}
+		if len(c.Breakdown.Evidence) == 0 {
+			fmt.Fprintf(&b, "- %s\n", c.SelectionMethod)
+		}
+	}
+
+	if len(e.RunnersUp) > 0 {
+		runnersUp := make([]string, 0, commentRunnersUp)
+		for _, c := range e.RunnersUp[:min(commentRunnersUp, len(e.RunnersUp))] {
+			runnersUp = append(runnersUp, fmt.Sprintf("%s (score %d)", c.Username, c.ContextScore))
+		}
+		fmt.Fprintf(&b, "\nRunners-up: %s\n", strings.Join(runnersUp, ", "))
+	}
+	return b.String()
+}
+
+// topEvidence returns up to n evidence entries with the highest scores, keeping ties in order.
+func topEvidence(evidence []types.Evidence, n int) []types.Evidence {
+	sorted := make([]types.Evidence, len(evidence))
+	copy(sorted, evidence)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Score > sorted[j].Score })
+	return sorted[:min(n, len(sorted))]
+}
+
+// explainEvidence describes an evidence entry in a sentence fragment, e.g.
+// "wrote 12 changed lines in `main.go` (#45)".
+func explainEvidence(e types.Evidence) string {
+	var s string
+	scope, role, _ := strings.Cut(e.Source, "-")
+	verb := map[string]string{"author": "wrote", "merger": "merged", "reviewer": "reviewed"}[role]
+	switch {
+	case e.Source == "codeowner":
+		s = fmt.Sprintf("owns `%s` in CODEOWNERS", e.Path)
+	case e.Source == "author-affinity":
+		s = "approved the author's recent work"
+	case verb == "":
+		s = e.Source
+		if e.Path != "" {
+			s += " `" + e.Path + "`"
+		}
+	case scope == "blame":
+		s = fmt.Sprintf("%s the last change to %d changed lines in `%s`", verb, e.Lines, e.Path)
+	case scope == "dir" && (e.Path == "." || e.Path == ""):
+		s = verb + " recent changes in the repository root"
+	case scope == "dir":
+		s = fmt.Sprintf("%s recent changes in `%s/`", verb, strings.TrimSuffix(e.Path, "/"))
+	default:
+		s = fmt.Sprintf("%s recent changes to `%s`", verb, e.Path)
+	}
+	if e.PR > 0 {
+		s += fmt.Sprintf(" (#%d)", e.PR)
+	}
+	return s
+}
@@ -0,0 +1,64 @@
+package reviewer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+func TestExplanation_Markdown(t *testing.T) {
+	e := &Explanation{
+		Org:   "org",
+		Teams: []string{"core"},
+		Chosen: []types.ReviewerCandidate{
+			{
+				Username:     "bob",
+				ContextScore: 30,
+				Breakdown: &types.ScoreBreakdown{
+					OpenPRs: 2,
+					Evidence: []types.Evidence{
+						{Source: "dir-reviewer", Path: "pkg", PR: 12, Score: 3},
+						{Source: "blame-author", Path: "pkg/main.go", Lines: 12, PR: 45, Score: 12},
+						{Source: "codeowner", Path: "pkg/main.go", Score: 10},
+					},
+				},
+			},
+		},
+		RunnersUp: []types.ReviewerCandidate{{Username: "carol", ContextScore: 20}, {Username: "dave", ContextScore: 5}},
+	}
+
+	got := e.Markdown()
+	if !strings.HasPrefix(got, CommentMarker+"\n") {
+		t.Errorf("expected comment to start with the marker, got %q", got)
+	}
+	for _, want := range []string{
+		"Requested review from @bob, @org/core based on who knows the changed code.",
+		"**@bob** (score 30), 2 open PRs\n" +
+			"- wrote the last change to 12 changed lines in `pkg/main.go` (#45)\n" +
+			"- owns `pkg/main.go` in CODEOWNERS\n" +
+			"- reviewed recent changes in `pkg/` (#12)\n",
+		"Runners-up: carol (score 20), dave (score 5)",
+	} {
+		if !strings.Contains(got, want) {
+			t.Errorf("expected comment to contain %q, got:\n%s", want, got)
+		}
+	}
+	if strings.Contains(got, "@carol") {
+		t.Error("runners-up should not be mentioned")
+	}
+}
+
+func TestExplanation_Markdown_Rule(t *testing.T) {
+	e := &Explanation{
+		Org:    "org",
+		Rule:   `author "dependabot[bot]"`,
+		Teams:  []string{"deps"},
+		Chosen: []types.ReviewerCandidate{{Username: "bob"}},
+	}
+	want := CommentMarker + "\n### Why these reviewers?\n\n" +
+		"Requested review from @bob, @org/deps because of a routing rule (author \"dependabot[bot]\").\n"
+	if got := e.Markdown(); got != want {
+		t.Errorf("Markdown() = %q, want %q", got, want)
+	}
+}
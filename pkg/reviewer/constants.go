@@ -7,4 +7,12 @@ const (
 	cacheTTL           = 24 * time.Hour // Default cache TTL for in-memory cache
 	topCandidatesToLog = 5              // Number of top candidates to log
 	maxContextScore    = 100            // Maximum context score for candidates
+
+	maxEvidencePerCandidate = 20 // Evidence entries kept in each candidate's score breakdown
+	availabilityCheckLimit  = 20 // Top candidates whose GitHub status is checked
+	coverageCandidatePool   = 10 // Candidates considered when selecting reviewers for file coverage
+	authorAffinityPRs       = 30 // Author's recent merged PRs checked for regular reviewers
+	maxAffinityApprovals    = 5  // Approvals of the author's PRs credited per reviewer
+	maxCodeownerFiles       = 5  // Owned files credited to a single code owner
+	maxBlameBatch           = 5  // Files blamed per GraphQL request; blame is slow, so larger batches risk timeouts
 )
@@ -8,7 +8,9 @@ import (
 	"log/slog"
 	"time"
 
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/availability"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/cache"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
 )
@@ -17,42 +19,111 @@ import (
 type Finder struct {
 	client       github.API
 	cache        *cache.Cache
+	configs      *config.Loader
+	availability *availability.Checker
+	load         *LoadTracker
+	now          func() time.Time
+	weights      Weights
 	prCountCache time.Duration
 }
 
 // Config holds configuration for the reviewer finder.
 type Config struct {
-	PRCountCache time.Duration // Cache duration for PR counts
+	ConfigLoader *config.Loader        // Loads per-repository config files (optional; defaults to a loader sharing the client's cache)
+	Weights      Weights               // Scoring weights (zero value uses DefaultWeights); repo config files may override
+	Load         *LoadTracker          // Review requests made by this process, counted as workload (optional)
+	Availability *availability.Checker // Reviewer availability (optional; defaults to GitHub status and inactivity checks)
+	PRCountCache time.Duration         // Cache duration for PR counts
 }
 
 // New creates a new Finder with the given GitHub client and configuration.
-func New(client github.API, cfg Config) *Finder {
+// Returns an error if cfg.Weights are invalid.
+func New(client github.API, cfg Config) (*Finder, error) {
+	configs := cfg.ConfigLoader
+	if configs == nil {
+		configs = config.NewLoader(client, configCache(client))
+	}
+	avail := cfg.Availability
+	if avail == nil {
+		avail = availability.New(client, availability.Config{InactiveAfter: availability.DefaultInactiveAfter})
+	}
+	weights := cfg.Weights
+	if weights == (Weights{}) {
+		weights = DefaultWeights()
+	} else if err := weights.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid scoring weights: %w", err)
+	}
 	return &Finder{
 		client:       client,
 		cache:        cache.New(cacheTTL),
+		configs:      configs,
+		availability: avail,
+		load:         cfg.Load,
+		now:          time.Now,
+		weights:      weights,
 		prCountCache: cfg.PRCountCache,
+	}, nil
+}
+
+// configCache returns the client's cache for config files when it has one, and a
+// memory-only cache otherwise.
+func configCache(client github.API) *cache.DiskCache {
+	if c, ok := client.(interface{ Cache() *cache.DiskCache }); ok && c.Cache() != nil {
+		return c.Cache()
 	}
+	// Without a directory there is nothing to create, so this cannot fail
+	dc, _ := cache.NewDiskCache(cacheTTL, "")
+	return dc
+}
+
+// RepoConfig returns the reviewer configuration for a repository.
+// Returns an error if the repository's config file is invalid.
+func (f *Finder) RepoConfig(ctx context.Context, owner, repo string) (*config.Config, error) {
+	return f.configs.Load(ctx, owner, repo)
+}
+
+// Result is the outcome of a reviewer search.
+type Result struct {
+	Coverage   *types.Coverage // How much of the PR's changes history analysis covered (nil when history was not analyzed)
+	Candidates []types.ReviewerCandidate
+	Selection  []Assignment // Reviewers picked for file coverage, also first in Candidates (nil unless the repo selects by coverage)
 }
 
 // Find finds the best reviewers for a pull request.
 // Returns a list of reviewer candidates sorted by relevance.
 func (f *Finder) Find(ctx context.Context, pr *types.PullRequest) ([]types.ReviewerCandidate, error) {
+	result, err := f.Analyze(ctx, pr)
+	if err != nil {
+		return nil, err
+	}
+	return result.Candidates, nil
+}
+
+// Analyze finds the best reviewers for a pull request, like Find, and also reports how
+// much of the PR's changes the search was able to attribute.
+func (f *Finder) Analyze(ctx context.Context, pr *types.PullRequest) (*Result, error) {
 	if pr == nil {
 		return nil, errors.New("pr cannot be nil")
 	}
 
 	slog.Info("Finding reviewers for PR", "pr", pr.Number, "owner", pr.Owner, "repo", pr.Repository)
 
+	cfg, err := f.RepoConfig(ctx, pr.Owner, pr.Repository)
+	if err != nil {
+		return nil, err
+	}
+
 	// Check if project has only 0-2 members with write access for early short-circuit
 	smallTeamMembers, totalMembers, err := f.checkSmallTeamProject(ctx, pr)
 	if err != nil {
 		slog.Warn("Failed to check small team project (continuing)", "error", err)
 	} else if totalMembers >= 0 && totalMembers <= 2 {
 		// Short-circuit for small teams (0-2 valid members excluding PR author)
+		smallTeamMembers = f.availableUsers(ctx, f.underDailyCap(excludeUsers(smallTeamMembers, cfg)))
 		switch len(smallTeamMembers) {
 		case 0:
 			slog.Info("Project has no valid reviewers (single-person project or PR author is only member)")
-			return nil, nil
+			return &Result{}, nil
 		case 1:
 			slog.Info("Project has single member, assigning to them", "member", smallTeamMembers[0])
 		default:
@@ -64,33 +135,92 @@ func (f *Finder) Find(ctx context.Context, pr *types.PullRequest) ([]types.Revie
 				Username:        member,
 				SelectionMethod: "small-team",
 				ContextScore:    maxContextScore,
+				Breakdown: &types.ScoreBreakdown{
+					Sources:   map[string]int{"small-team": maxContextScore},
+					Expertise: maxContextScore,
+				},
 			}
 		}
-		return candidates, nil
+		return &Result{Candidates: candidates}, nil
 	}
 
 	// Find reviewers using scoring algorithm
-	candidates := f.findReviewersOptimized(ctx, pr)
+	candidates, coverage := f.findReviewersOptimized(ctx, pr, cfg)
 	slog.Info("Reviewer search complete", "count", len(candidates))
-	return candidates, nil
+	result := &Result{Candidates: candidates, Coverage: coverage}
+	if cfg.Selection == config.SelectionCoverage && len(candidates) > 0 {
+		result.Selection = SelectCovering(candidates, cfg.Reviewers)
+		result.Candidates = orderBySelection(candidates, result.Selection)
+		for _, a := range result.Selection {
+			slog.Info("Selected reviewer for coverage", "username", a.Username, "files", a.Files)
+		}
+	}
+	return result, nil
 }
 
 // isValidReviewer checks if a user is a valid reviewer (only hard filters).
-func (f *Finder) isValidReviewer(ctx context.Context, pr *types.PullRequest, username string) bool {
+// Returns why the user was filtered out if not.
+func (f *Finder) isValidReviewer(ctx context.Context, pr *types.PullRequest, cfg *config.Config, username string) (reason string, valid bool) {
+	// Check exclusion lists and opt-outs
+	if reason := cfg.ExclusionReason(username); reason != "" {
+		slog.Info("Filtered (excluded)", "username", username, "reason", reason)
+		return reason, false
+	}
+
 	// Check if user is a bot
 	if f.client.IsUserBot(ctx, username) {
 		slog.Info("Filtered (is bot)", "username", username)
-		return false
+		return "is bot", false
 	}
 
 	// Check write access - this is the only hard filter since they can't approve without it
 	hasAccess := f.client.HasWriteAccess(ctx, pr.Owner, pr.Repository, username)
 	if !hasAccess {
 		slog.Info("Filtered (no write access)", "username", username)
-		return false
+		return "no write access", false
 	}
 
-	return true
+	return "", true
+}
+
+// excludeUsers returns the users not excluded by config or opt-out.
+func excludeUsers(users []string, cfg *config.Config) []string {
+	var kept []string
+	for _, u := range users {
+		if reason := cfg.ExclusionReason(u); reason != "" {
+			slog.Info("Filtered (excluded)", "username", u, "reason", reason)
+			continue
+		}
+		kept = append(kept, u)
+	}
+	return kept
+}
+
+// underDailyCap returns the users who have not reached the daily assignment cap.
+func (f *Finder) underDailyCap(users []string) []string {
+	var kept []string
+	for _, u := range users {
+		if f.load.AtDailyCap(u) {
+			slog.Info("Filtered (daily assignment cap reached)", "username", u)
+			continue
+		}
+		kept = append(kept, u)
+	}
+	return kept
+}
+
+// availableUsers returns the users who are not unavailable (e.g. out of office).
+func (f *Finder) availableUsers(ctx context.Context, users []string) []string {
+	statuses := f.availability.Check(ctx, users, nil)
+	var kept []string
+	for _, u := range users {
+		if s := statuses[u]; s.Level == availability.Unavailable {
+			slog.Info("Filtered (unavailable)", "username", u, "reason", s.Reason)
+			continue
+		}
+		kept = append(kept, u)
+	}
+	return kept
 }
 
 // checkSmallTeamProject checks if the project has only 0-2 members with write access.
@@ -5,17 +5,33 @@ import (
 	"testing"
 	"time"
 
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/cache"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/internal/testutil"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
 )
 
+// newTestFinder creates a Finder, failing the test if cfg is invalid.
+func newTestFinder(t *testing.T, client github.API, cfg Config) *Finder {
+	t.Helper()
+	finder, err := New(client, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+	return finder
+}
+
 func TestNew(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
 	cfg := Config{
 		PRCountCache: time.Hour,
 	}
 
-	finder := New(client, cfg)
+	finder, err := New(client, cfg)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
 
 	if finder == nil {
 		t.Fatal("expected non-nil Finder")
@@ -34,9 +50,31 @@ func TestNew(t *testing.T) {
 	}
 }
 
+// cachingClient is a GitHub client with a cache to share.
+type cachingClient struct {
+	*testutil.MockGitHubClient
+	cache *cache.DiskCache
+}
+
+func (c *cachingClient) Cache() *cache.DiskCache { return c.cache }
+
+func TestConfigCache(t *testing.T) {
+	if dc := configCache(testutil.NewMockGitHubClient()); dc == nil {
+		t.Error("expected a memory-only cache for clients without one")
+	}
+
+	shared, err := cache.NewDiskCache(time.Hour, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dc := configCache(&cachingClient{MockGitHubClient: testutil.NewMockGitHubClient(), cache: shared}); dc != shared {
+		t.Error("expected the client's cache to be shared")
+	}
+}
+
 func TestFinder_Find_NilPR(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	_, err := finder.Find(context.Background(), nil)
 	if err == nil {
@@ -47,7 +85,7 @@ func TestFinder_Find_NilPR(t *testing.T) {
 func TestFinder_Find_SinglePersonProject(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		Owner:      "test-owner",
@@ -73,7 +111,7 @@ func TestFinder_Find_SinglePersonProject(t *testing.T) {
 func TestFinder_Find_SmallTeam_OneMember(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		Owner:      "test-owner",
@@ -112,7 +150,7 @@ func TestFinder_Find_SmallTeam_OneMember(t *testing.T) {
 func TestFinder_Find_SmallTeam_TwoMembers(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		Owner:      "test-owner",
@@ -162,7 +200,7 @@ func TestFinder_Find_SmallTeam_TwoMembers(t *testing.T) {
 func TestFinder_Find_SmallTeam_ExcludeBots(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		Owner:      "test-owner",
@@ -191,10 +229,44 @@ func TestFinder_Find_SmallTeam_ExcludeBots(t *testing.T) {
 	}
 }
 
+func TestFinder_Find_SmallTeam_ExcludedByConfig(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("test-owner", "test-repo", ".github/best-reviewer.yml", "exclude_users: [charlie]\n")
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	pr := &types.PullRequest{
+		Owner:      "test-owner",
+		Repository: "test-repo",
+		Number:     1,
+		Author:     "alice",
+	}
+	client.SetCollaborators("test-owner", "test-repo", []string{"alice", "bob", "charlie"})
+
+	candidates, err := finder.Find(ctx, pr)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(candidates) != 1 || candidates[0].Username != "bob" {
+		t.Fatalf("expected only bob after config exclusion, got %+v", candidates)
+	}
+}
+
+func TestFinder_Find_InvalidConfig(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("test-owner", "test-repo", ".github/best-reviewer.yml", "reviewers: lots\n")
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	pr := &types.PullRequest{Owner: "test-owner", Repository: "test-repo", Number: 1, Author: "alice"}
+	if _, err := finder.Find(context.Background(), pr); err == nil {
+		t.Fatal("expected invalid config to be reported as an error")
+	}
+}
+
 func TestFinder_checkSmallTeamProject_Cached(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		Owner:      "test-owner",
@@ -239,7 +311,7 @@ func TestFinder_checkSmallTeamProject_Cached(t *testing.T) {
 func TestFinder_checkSmallTeamProject_LargeTeam(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		Owner:      "test-owner",
@@ -273,7 +345,7 @@ func TestFinder_checkSmallTeamProject_LargeTeam(t *testing.T) {
 func TestFinder_isValidReviewer_Bot(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		Owner:      "test-owner",
@@ -282,7 +354,7 @@ func TestFinder_isValidReviewer_Bot(t *testing.T) {
 
 	client.SetBotUser("dependabot", true)
 
-	valid := finder.isValidReviewer(ctx, pr, "dependabot")
+	_, valid := finder.isValidReviewer(ctx, pr, config.Default(), "dependabot")
 	if valid {
 		t.Error("expected bot to be invalid reviewer")
 	}
@@ -291,7 +363,7 @@ func TestFinder_isValidReviewer_Bot(t *testing.T) {
 func TestFinder_isValidReviewer_NoWriteAccess(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		Owner:      "test-owner",
@@ -301,7 +373,7 @@ func TestFinder_isValidReviewer_NoWriteAccess(t *testing.T) {
 	client.SetBotUser("user1", false)
 	client.SetWriteAccess("test-owner", "test-repo", "user1", false)
 
-	valid := finder.isValidReviewer(ctx, pr, "user1")
+	_, valid := finder.isValidReviewer(ctx, pr, config.Default(), "user1")
 	if valid {
 		t.Error("expected user without write access to be invalid reviewer")
 	}
@@ -310,7 +382,7 @@ func TestFinder_isValidReviewer_NoWriteAccess(t *testing.T) {
 func TestFinder_isValidReviewer_Valid(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		Owner:      "test-owner",
@@ -320,11 +392,36 @@ func TestFinder_isValidReviewer_Valid(t *testing.T) {
 	client.SetBotUser("user1", false)
 	client.SetWriteAccess("test-owner", "test-repo", "user1", true)
 
-	valid := finder.isValidReviewer(ctx, pr, "user1")
+	_, valid := finder.isValidReviewer(ctx, pr, config.Default(), "user1")
 	if !valid {
 		t.Error("expected valid user to be valid reviewer")
 	}
 }
 
+func TestFinder_isValidReviewer_Excluded(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	pr := &types.PullRequest{
+		Owner:      "test-owner",
+		Repository: "test-repo",
+	}
+
+	client.SetBotUser("ceo", false)
+	client.SetWriteAccess("test-owner", "test-repo", "ceo", true)
+
+	cfg := config.Default()
+	cfg.Exclude("CEO", "opted out in test-owner/.github/best-reviewer-optout.yml")
+
+	reason, valid := finder.isValidReviewer(ctx, pr, cfg, "ceo")
+	if valid {
+		t.Error("expected opted-out user to be invalid reviewer")
+	}
+	if reason != "opted out in test-owner/.github/best-reviewer-optout.yml" {
+		t.Errorf("unexpected filter reason %q", reason)
+	}
+}
+
 // Note: Full integration tests with assignees and changed files require
 // complex GraphQL mocking and are covered by integration tests
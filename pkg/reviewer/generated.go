@@ -0,0 +1,198 @@
+package reviewer
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+// gitattributesPath is where linguist attributes are read from.
+const gitattributesPath = ".gitattributes"
+
+// generatedHeaderLines is how many lines at the top of a file are checked for a generated-code marker.
+const generatedHeaderLines = 10
+
+// generatedPathPatterns are gitignore-style globs for lock files, vendored dependencies and
+// generator output. Whoever last touched these ran a tool, so they say little about expertise.
+var generatedPathPatterns = []string{
+	// Lock files
+	"go.sum", "package-lock.json", "npm-shrinkwrap.json", "yarn.lock", "pnpm-lock.yaml",
+	"Gemfile.lock", "Cargo.lock", "poetry.lock", "Pipfile.lock", "composer.lock", "uv.lock",
+	// Vendored dependencies
+	"vendor/", "node_modules/", "third_party/", "bower_components/",
+	// Generator output
+	"*.pb.go", "*.pb.gw.go", "*_grpc.pb.go", "*.pb.h", "*.pb.cc", "*_pb2.py", "*_pb2_grpc.py", "*_pb.js", "*_pb.d.ts",
+	"zz_generated*", "*_generated.go", "*.gen.go", "mock_*.go", "*_mock.go",
+	"*.min.js", "*.min.css", "*.min.js.map", "*.min.css.map",
+}
+
+// generatedHeaderRE matches the conventional markers tools put at the top of generated files,
+// such as Go's "Code generated ... DO NOT EDIT." and the @generated tag.
+var generatedHeaderRE = regexp.MustCompile(`(?i)(code generated .*do not edit|auto-?generated .*do not (edit|modify)|@generated\b)`)
+
+// builtinGeneratedPatterns are generatedPathPatterns compiled once.
+var builtinGeneratedPatterns = compileGeneratedPatterns(generatedPathPatterns)
+
+// generatedClassifier recognizes generated and vendored files.
+type generatedClassifier struct {
+	attributes []linguistAttribute // .gitattributes rules in file order; later rules win
+}
+
+// linguistAttributes are the .gitattributes attributes that mark files as generated or vendored.
+var linguistAttributes = []string{"linguist-generated", "linguist-vendored"}
+
+// linguistAttribute is a .gitattributes rule setting or unsetting linguist-generated or linguist-vendored.
+type linguistAttribute struct {
+	re      *regexp.Regexp
+	pattern string
+	name    string // "linguist-generated" or "linguist-vendored"
+	set     bool
+}
+
+// compileGeneratedPatterns compiles gitignore-style patterns, skipping invalid ones.
+func compileGeneratedPatterns(patterns []string) []*regexp.Regexp {
+	res := make([]*regexp.Regexp, 0, len(patterns))
+	for _, p := range patterns {
+		if re, err := codeownersPatternToRegexp(p); err == nil {
+			res = append(res, re)
+		}
+	}
+	return res
+}
+
+// generatedReason returns why file looks generated or vendored, or "" if it does not.
+// linguist-generated and linguist-vendored are resolved separately, each by its last
+// matching .gitattributes rule, and a file setting either is generated. A rule unsetting
+// them overrides the built-in path patterns, so repositories can mark files as generated
+// or un-mark ones that only look generated. A nil classifier applies the built-in checks only.
+func (g *generatedClassifier) generatedReason(file types.ChangedFile) string {
+	if g != nil {
+		unset := false
+		for _, name := range linguistAttributes {
+			a, ok := g.lastMatch(name, file.Filename)
+			if !ok {
+				continue
+			}
+			if a.set {
+				return gitattributesPath + " " + a.name + " (" + a.pattern + ")"
+			}
+			unset = true
+		}
+		if unset {
+			return ""
+		}
+	}
+
+	for i, re := range builtinGeneratedPatterns {
+		if re.MatchString(file.Filename) {
+			return "path pattern " + generatedPathPatterns[i]
+		}
+	}
+	if hasGeneratedHeader(file.Patch) {
+		return "generated-code header"
+	}
+	return ""
+}
+
+// lastMatch returns the last .gitattributes rule for the named attribute matching path.
+func (g *generatedClassifier) lastMatch(name, path string) (linguistAttribute, bool) {
+	for i := len(g.attributes) - 1; i >= 0; i-- {
+		if a := g.attributes[i]; a.name == name && a.re.MatchString(path) {
+			return a, true
+		}
+	}
+	return linguistAttribute{}, false
+}
+
+// hasGeneratedHeader reports whether a patch shows a generated-code marker within the
+// first lines of the file. Only hunks starting at the top of the file are checked.
+func hasGeneratedHeader(patch string) bool {
+	lines := 0
+	inHeader := false
+	for line := range strings.SplitSeq(patch, "\n") {
+		if strings.HasPrefix(line, "@@") {
+			// The new side of "@@ -a,b +c,d @@" must start at line 1 (or 0 for an empty old file)
+			_, newSide, _ := strings.Cut(line, "+")
+			start, _, _ := strings.Cut(newSide, ",")
+			start, _, _ = strings.Cut(start, " ")
+			inHeader = start == "1" || start == "0"
+			if !inHeader {
+				return false
+			}
+			continue
+		}
+		if !inHeader || strings.HasPrefix(line, "-") {
+			continue
+		}
+		if generatedHeaderRE.MatchString(line) {
+			return true
+		}
+		lines++
+		if lines >= generatedHeaderLines {
+			return false
+		}
+	}
+	return false
+}
+
+// parseGitattributes extracts linguist-generated and linguist-vendored rules from a
+// .gitattributes file. "attr", "attr=true" set the attribute; "-attr", "attr=false" unset it.
+func parseGitattributes(content string) []linguistAttribute {
+	var attrs []linguistAttribute
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		for _, field := range fields[1:] {
+			set := !strings.HasPrefix(field, "-") && !strings.HasPrefix(field, "!")
+			name, value, hasValue := strings.Cut(strings.TrimLeft(field, "-!"), "=")
+			if !slices.Contains(linguistAttributes, name) {
+				continue
+			}
+			if hasValue {
+				set = set && value != "false"
+			}
+			re, err := codeownersPatternToRegexp(fields[0])
+			if err != nil {
+				continue
+			}
+			attrs = append(attrs, linguistAttribute{re: re, pattern: fields[0], name: name, set: set})
+		}
+	}
+	return attrs
+}
+
+// generatedClassifier returns a classifier using the repository's .gitattributes, if any.
+func (f *Finder) generatedClassifier(ctx context.Context, owner, repo string) *generatedClassifier {
+	cacheKey := makeCacheKey("gitattributes", owner, repo)
+	if cached, found := f.cache.Get(cacheKey); found {
+		if g, ok := cached.(*generatedClassifier); ok {
+			return g
+		}
+	}
+
+	g := &generatedClassifier{}
+	content, err := f.client.FileContent(ctx, owner, repo, gitattributesPath, "")
+	switch {
+	case errors.Is(err, github.ErrNotFound):
+	case err != nil:
+		slog.WarnContext(ctx, "Failed to fetch .gitattributes, using built-in generated file patterns", "error", err)
+		return g // Not cached so the next PR retries
+	default:
+		g.attributes = parseGitattributes(content)
+		slog.InfoContext(ctx, "Loaded .gitattributes", "owner", owner, "repo", repo, "linguist_rules", len(g.attributes))
+	}
+
+	f.cache.SetWithTTL(cacheKey, g, 6*time.Hour)
+	return g
+}
@@ -0,0 +1,100 @@
+package reviewer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/internal/testutil"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+func TestGeneratedClassifier_generatedReason(t *testing.T) {
+	gen := &generatedClassifier{attributes: parseGitattributes(`
+# Generated API clients
+api/client/** linguist-generated=true
+*.pb.go -linguist-generated
+assets/lib/* linguist-vendored
+third_party/** linguist-vendored
+third_party/** -linguist-generated
+`)}
+
+	tests := []struct {
+		name string
+		file types.ChangedFile
+		want bool
+	}{
+		{name: "source file", file: types.ChangedFile{Filename: "pkg/server/handler.go"}, want: false},
+		{name: "lock file", file: types.ChangedFile{Filename: "web/yarn.lock"}, want: true},
+		{name: "vendor directory", file: types.ChangedFile{Filename: "vendor/github.com/x/y/z.go"}, want: true},
+		{name: "node_modules", file: types.ChangedFile{Filename: "web/node_modules/left-pad/index.js"}, want: true},
+		{name: "kubernetes deepcopy", file: types.ChangedFile{Filename: "apis/v1/zz_generated.deepcopy.go"}, want: true},
+		{name: "minified js", file: types.ChangedFile{Filename: "static/app.min.js"}, want: true},
+		{name: "gitattributes generated", file: types.ChangedFile{Filename: "api/client/models/user.go"}, want: true},
+		{name: "gitattributes vendored", file: types.ChangedFile{Filename: "assets/lib/jquery.js"}, want: true},
+		{name: "gitattributes overrides built-in pattern", file: types.ChangedFile{Filename: "proto/hand_written.pb.go"}, want: false},
+		{name: "unsetting one attribute keeps the other", file: types.ChangedFile{Filename: "third_party/lib/lib.go"}, want: true},
+		{
+			name: "generated header in patch",
+			file: types.ChangedFile{
+				Filename: "internal/enum_string.go",
+				Patch:    "@@ -1,5 +1,5 @@\n-// Code generated by \"stringer -type=Kind\"; DO NOT EDIT.\n+// Code generated by \"stringer -type=Kind\"; DO NOT EDIT.\n \n package internal",
+			},
+			want: true,
+		},
+		{
+			name: "marker below the header is ignored",
+			file: types.ChangedFile{
+				Filename: "internal/docs.go",
+				Patch:    "@@ -40,3 +40,4 @@ func f() {\n+\t// Code generated output below; DO NOT EDIT by hand.\n }",
+			},
+			want: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reason := gen.generatedReason(tt.file)
+			if got := reason != ""; got != tt.want {
+				t.Errorf("generatedReason(%s) = %q, want generated=%v", tt.file.Filename, reason, tt.want)
+			}
+		})
+	}
+
+	var builtin *generatedClassifier
+	if builtin.generatedReason(types.ChangedFile{Filename: "gen/api.pb.go"}) == "" {
+		t.Error("expected a nil classifier to apply the built-in patterns")
+	}
+}
+
+func TestFinder_topChangedFilesFiltered_Generated(t *testing.T) {
+	finder := newTestFinder(t, testutil.NewMockGitHubClient(), Config{PRCountCache: time.Hour})
+	pr := &types.PullRequest{ChangedFiles: []types.ChangedFile{
+		{Filename: "api/v1/service.proto", Additions: 10},
+		{Filename: "api/v1/service.pb.go", Additions: 900},
+		{Filename: "vendor/golang.org/x/net/http2/frame.go", Additions: 400},
+		{Filename: "server/handler.go", Additions: 20},
+	}}
+
+	got := finder.topChangedFilesFiltered(pr, 10, nil, nil)
+	if want := []string{"server/handler.go", "api/v1/service.proto"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("topChangedFilesFiltered() = %v, want %v", got, want)
+	}
+}
+
+func TestFinder_generatedClassifier(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("owner", "repo", ".gitattributes", "docs/api/** linguist-generated\n")
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	gen := finder.generatedClassifier(ctx, "owner", "repo")
+	if gen.generatedReason(types.ChangedFile{Filename: "docs/api/index.md"}) == "" {
+		t.Error("expected docs/api/index.md to be generated per .gitattributes")
+	}
+
+	if gen := finder.generatedClassifier(ctx, "owner", "other"); len(gen.attributes) != 0 {
+		t.Errorf("expected no rules for a repository without .gitattributes, got %d", len(gen.attributes))
+	}
+}
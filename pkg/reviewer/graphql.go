@@ -6,134 +6,184 @@ import (
 	"errors"
 	"fmt"
 	"log/slog"
+	"strings"
 	"time"
 
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
 )
 
-// blameForLines uses GitHub's blame API to find who last touched specific lines in a file.
-// Returns two lists: overlappingPRs (touched exact lines), and filePRs (touched file within last year).
-func (f *Finder) blameForLines(ctx context.Context, owner, repo, filepath string, lineRanges [][2]int) (overlappingPRs, filePRs []types.PRInfo, err error) {
-	slog.InfoContext(ctx, "Using blame API to find line authors", "file", filepath, "line_ranges", len(lineRanges))
+// blameRangesFragment selects the blame fields used for scoring. Each file in a batch spreads it.
+const blameRangesFragment = `
+	fragment BlameRanges on Blame {
+		ranges {
+			startingLine
+			endingLine
+			commit {
+				oid
+				committedDate
+				author {
+					user {
+						login
+					}
+				}
+				associatedPullRequests(first: 1) {
+					nodes {
+						number
+						merged
+						mergedAt
+						author {
+							login
+						}
+						mergedBy {
+							login
+						}
+						reviews(first: 10, states: APPROVED) {
+							nodes {
+								author {
+									login
+								}
+							}
+						}
+					}
+				}
+			}
+		}
+	}`
 
-	if len(lineRanges) == 0 {
-		return nil, nil, nil
+// blameBatchQuery builds a query that blames n files in one request, with paths $p0, $p1, ...
+// returned under aliases f0, f1, .... The files are blamed at the revision expression $rev,
+// or on the default branch if atRevision is false.
+func blameBatchQuery(atRevision bool, n int) string {
+	var params, fields strings.Builder
+	params.WriteString("$owner: String!, $repo: String!")
+	indent := strings.Repeat("\t", 6) // Fields sit one level deeper under defaultBranchRef.target
+	if atRevision {
+		params.WriteString(", $rev: String!")
+		indent = strings.Repeat("\t", 5)
+	}
+	for i := range n {
+		fmt.Fprintf(&params, ", $p%d: String!", i)
+		fmt.Fprintf(&fields, "%sf%d: blame(path: $p%d) { ...BlameRanges }\n", indent, i, i)
+	}
+
+	if atRevision {
+		return fmt.Sprintf(`
+	query(%s) {
+		rateLimit { limit cost remaining resetAt }
+		repository(owner: $owner, name: $repo) {
+			object(expression: $rev) {
+				... on Commit {
+%s				}
+			}
+		}
+	}`, params.String(), fields.String()) + blameRangesFragment
 	}
-
-	// GitHub blame API gives us commits - we need to map commits to PRs
-	query := `
-	query($owner: String!, $repo: String!, $path: String!) {
+	return fmt.Sprintf(`
+	query(%s) {
+		rateLimit { limit cost remaining resetAt }
 		repository(owner: $owner, name: $repo) {
 			defaultBranchRef {
 				target {
 					... on Commit {
-						blame(path: $path) {
-							ranges {
-								startingLine
-								endingLine
-								commit {
-									oid
-									author {
-										user {
-											login
-										}
-									}
-									associatedPullRequests(first: 1) {
-										nodes {
-											number
-											merged
-											mergedAt
-											author {
-												login
-											}
-											mergedBy {
-												login
-											}
-											reviews(first: 10, states: APPROVED) {
-												nodes {
-													author {
-														login
-													}
-												}
-											}
-										}
-									}
-								}
-							}
-						}
-					}
+%s					}
 				}
 			}
 		}
-	}`
+	}`, params.String(), fields.String()) + blameRangesFragment
+}
+
+// blameFiles uses GitHub's blame API to find who last touched each line of up to
+// maxBlameBatch files in a single request. rev is the revision to blame, e.g. the PR's
+// merge base; empty blames the default branch. Each file's blame is returned under the
+// alias fN, where N is its index in paths; pass the alias to parseBlameResults.
+func (f *Finder) blameFiles(ctx context.Context, owner, repo, rev string, paths []string) (map[string]any, error) {
+	if len(paths) == 0 {
+		return map[string]any{}, nil
+	}
+	slog.InfoContext(ctx, "Using blame API to find line authors", "files", paths, "rev", rev)
 
 	variables := map[string]any{
 		"owner": owner,
 		"repo":  repo,
-		"path":  filepath,
 	}
-
-	result, err := f.client.MakeGraphQLRequest(ctx, query, variables)
-	if err != nil {
-		return nil, nil, fmt.Errorf("GraphQL blame request failed: %w", err)
+	if rev != "" {
+		variables["rev"] = rev
 	}
-
-	// Check for GraphQL errors in response
-	if gqlErrors, ok := result["errors"]; ok {
-		slog.WarnContext(ctx, "GraphQL blame query returned errors", "errors", gqlErrors)
+	for i, path := range paths {
+		variables[fmt.Sprintf("p%d", i)] = path
 	}
 
-	// Parse blame results - get both overlapping and all PRs
-	overlappingPRs, filePRs = f.parseBlameResults(result, lineRanges)
-	slog.InfoContext(ctx, "Blame API found PRs", "file", filepath, "overlapping_count", len(overlappingPRs), "file_contributors_count", len(filePRs))
-
-	return overlappingPRs, filePRs, nil
+	result, err := f.client.MakeGraphQLRequest(ctx, blameBatchQuery(rev != "", len(paths)), variables)
+	if err != nil {
+		// A missing or oversized file fails only its own alias, so keep the others
+		var gqlErr *github.GraphQLErrors
+		if !errors.As(err, &gqlErr) || gqlErr.Response["data"] == nil {
+			return nil, fmt.Errorf("GraphQL blame request failed: %w", err)
+		}
+		slog.WarnContext(ctx, "GraphQL blame query returned errors for some files", "files", paths, "errors", gqlErr.Errors)
+		result = gqlErr.Response
+	}
+	return result, nil
 }
 
-// parseBlameResults extracts PR info from blame GraphQL response for specific line ranges.
-// Returns two lists: PRs that overlap with changed lines, and all PRs in the file.
-//
-//nolint:gocognit,maintidx // High complexity inherent to parsing GraphQL blame data with line range matching
-func (f *Finder) parseBlameResults(result map[string]any, lineRanges [][2]int) (overlappingPRs, allPRs []types.PRInfo) {
-	seenOverlapping := make(map[int]bool)
-	seenAll := make(map[int]bool)
-	seenOverlappingCommits := make(map[string]bool)
-	seenAllCommits := make(map[string]bool)
-
+// blameField returns the blame under alias in a blame GraphQL response. Returns false if
+// the file could not be blamed, e.g. because it does not exist at the blamed revision.
+func blameField(result map[string]any, alias string) (map[string]any, bool) {
 	data, ok := mapValue(result, "data")
 	if !ok {
 		slog.Debug("No data field in blame response")
-		return overlappingPRs, allPRs
+		return nil, false
 	}
 
 	repository, ok := mapValue(data, "repository")
 	if !ok {
 		slog.Debug("No repository field in blame response")
-		return overlappingPRs, allPRs
+		return nil, false
 	}
 
-	defaultBranchRef, ok := mapValue(repository, "defaultBranchRef")
+	// Blame at a revision comes back under object, default branch blame under defaultBranchRef.target
+	target, ok := mapValue(repository, "object")
 	if !ok {
-		slog.Debug("No defaultBranchRef field in blame response")
-		return overlappingPRs, allPRs
+		defaultBranchRef, ok := mapValue(repository, "defaultBranchRef")
+		if !ok {
+			slog.Debug("No defaultBranchRef field in blame response")
+			return nil, false
+		}
+		if target, ok = mapValue(defaultBranchRef, "target"); !ok {
+			slog.Debug("No target field in blame response")
+			return nil, false
+		}
 	}
 
-	target, ok := mapValue(defaultBranchRef, "target")
+	blame, ok := mapValue(target, alias)
 	if !ok {
-		slog.Debug("No target field in blame response")
-		return overlappingPRs, allPRs
+		slog.Debug("No blame field in blame response", "alias", alias)
+		return nil, false
 	}
+	return blame, true
+}
 
-	blame, ok := mapValue(target, "blame")
+// parseBlameResults extracts PR info for specific line ranges from the blame field named
+// alias in a blame GraphQL response. Returns two lists: PRs that overlap with changed lines,
+// and all PRs in the file; and how many of the changed lines are attributed to someone.
+//
+//nolint:gocognit,maintidx // High complexity inherent to parsing GraphQL blame data with line range matching
+func (f *Finder) parseBlameResults(result map[string]any, alias string, lineRanges [][2]int) (overlappingPRs, allPRs []types.PRInfo, blamedLines int) {
+	seenOverlapping := make(map[int]bool)
+	seenAll := make(map[int]bool)
+	seenOverlappingCommits := make(map[string]bool)
+	seenAllCommits := make(map[string]bool)
+
+	blame, ok := blameField(result, alias)
 	if !ok {
-		slog.Debug("No blame field in blame response")
-		return overlappingPRs, allPRs
+		return overlappingPRs, allPRs, blamedLines
 	}
 
 	ranges, ok := blame["ranges"].([]any)
 	if !ok {
 		slog.Debug("No ranges field in blame response")
-		return overlappingPRs, allPRs
+		return overlappingPRs, allPRs, blamedLines
 	}
 
 	slog.Debug("Parsing blame ranges", "range_count", len(ranges), "looking_for_lines", lineRanges)
@@ -198,8 +248,14 @@ func (f *Finder) parseBlameResults(result map[string]any, lineRanges [][2]int) (
 				Author:    commitAuthor,
 				LineCount: int(endLine) - int(startLine) + 1,
 			}
+			if committed, ok := commit["committedDate"].(string); ok {
+				if t, err := time.Parse(time.RFC3339, committed); err == nil {
+					pr.MergedAt = t
+				}
+			}
 
 			if overlaps {
+				blamedLines += overlapLines(int(startLine), int(endLine), lineRanges)
 				// Overlapping lines - always include
 				if !seenOverlappingCommits[commitAuthor] {
 					seenOverlappingCommits[commitAuthor] = true
@@ -234,6 +290,9 @@ func (f *Finder) parseBlameResults(result map[string]any, lineRanges [][2]int) (
 		if !merged {
 			continue // Only consider merged PRs
 		}
+		if overlaps {
+			blamedLines += overlapLines(int(startLine), int(endLine), lineRanges)
+		}
 
 		// Extract mergedAt for recency check
 		var mergedAt time.Time
@@ -305,27 +364,57 @@ func (f *Finder) parseBlameResults(result map[string]any, lineRanges [][2]int) (
 		}
 	}
 
-	return overlappingPRs, allPRs
+	return overlappingPRs, allPRs, blamedLines
+}
+
+// overlapLines returns how many lines of [start, end] fall within any of lineRanges.
+func overlapLines(start, end int, lineRanges [][2]int) int {
+	n := 0
+	for line := start; line <= end; line++ {
+		for _, r := range lineRanges {
+			if line >= r[0] && line <= r[1] {
+				n++
+				break
+			}
+		}
+	}
+	return n
+}
+
+// directoryCommitLimit is the number of recent commits read per directory.
+const directoryCommitLimit = 10
+
+// directoryCommitsKey is the cache key for a directory's recent PRs.
+func directoryCommitsKey(owner, repo, dirPath string) string {
+	return fmt.Sprintf("commits-dir:%s/%s:%s:%d", owner, repo, dirPath, directoryCommitLimit)
+}
+
+// cachedCommitsInDirectory returns a directory's recent PRs if they are already cached,
+// so callers can tell whether recentCommitsInDirectory will make a request.
+func (f *Finder) cachedCommitsInDirectory(owner, repo, dirPath string) ([]types.PRInfo, bool) {
+	cached, found := f.cache.Get(directoryCommitsKey(owner, repo, dirPath))
+	if !found {
+		return nil, false
+	}
+	prs, ok := cached.([]types.PRInfo)
+	return prs, ok
 }
 
 // recentCommitsInDirectory finds recent commits in a directory and their associated PRs.
 func (f *Finder) recentCommitsInDirectory(ctx context.Context, owner, repo, dirPath string) ([]types.PRInfo, error) {
-	limit := 10
-	slog.InfoContext(ctx, "Querying recent commits in directory", "owner", owner, "repo", repo, "dir", dirPath, "limit", limit)
-
-	cacheKey := fmt.Sprintf("commits-dir:%s/%s:%s:%d", owner, repo, dirPath, limit)
-	if cached, found := f.cache.Get(cacheKey); found {
-		slog.DebugContext(ctx, "Cache hit", "key", cacheKey)
-		if prs, ok := cached.([]types.PRInfo); ok {
-			return prs, nil
-		}
-		slog.WarnContext(ctx, "Cache type assertion failed", "key", cacheKey)
+	limit := directoryCommitLimit
+	if prs, ok := f.cachedCommitsInDirectory(owner, repo, dirPath); ok {
+		slog.DebugContext(ctx, "Cache hit", "key", directoryCommitsKey(owner, repo, dirPath))
+		return prs, nil
 	}
+	slog.InfoContext(ctx, "Querying recent commits in directory", "owner", owner, "repo", repo, "dir", dirPath, "limit", limit)
+	cacheKey := directoryCommitsKey(owner, repo, dirPath)
 
 	// GraphQL query to get recent commits in a directory
 	// Try both main and master branches
 	query := `
 	query($owner: String!, $repo: String!, $path: String!, $limit: Int!) {
+		rateLimit { limit cost remaining resetAt }
 		repository(owner: $owner, name: $repo) {
 			defaultBranchRef {
 				name
@@ -10,7 +10,7 @@ import (
 
 func TestFinder_parseBlameResults_HappyPath(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Simulate a GraphQL blame response (matches actual structure from graphql.go)
 	result := map[string]any{
@@ -60,7 +60,7 @@ func TestFinder_parseBlameResults_HappyPath(t *testing.T) {
 	}
 
 	lineRanges := [][2]int{{10, 20}}
-	overlappingPRs, allPRs := finder.parseBlameResults(result, lineRanges)
+	overlappingPRs, allPRs, _ := finder.parseBlameResults(result, "blame", lineRanges)
 
 	// Should find PRs that overlap with the line range
 	if len(overlappingPRs) != 1 {
@@ -83,7 +83,7 @@ func TestFinder_parseBlameResults_HappyPath(t *testing.T) {
 
 func TestFinder_parseBlameResults_NoOverlap(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	result := map[string]any{
 		"data": map[string]any{
@@ -120,7 +120,7 @@ func TestFinder_parseBlameResults_NoOverlap(t *testing.T) {
 	}
 
 	lineRanges := [][2]int{{10, 20}} // No overlap with 100-110
-	overlappingPRs, allPRs := finder.parseBlameResults(result, lineRanges)
+	overlappingPRs, allPRs, _ := finder.parseBlameResults(result, "blame", lineRanges)
 
 	if len(overlappingPRs) != 0 {
 		t.Errorf("expected 0 overlapping PRs, got %d", len(overlappingPRs))
@@ -133,11 +133,11 @@ func TestFinder_parseBlameResults_NoOverlap(t *testing.T) {
 
 func TestFinder_parseBlameResults_NoDataField(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Empty result - no data field
 	result := map[string]any{}
-	overlapping, all := finder.parseBlameResults(result, [][2]int{{10, 15}})
+	overlapping, all, _ := finder.parseBlameResults(result, "blame", [][2]int{{10, 15}})
 
 	if len(overlapping) != 0 {
 		t.Errorf("expected 0 overlapping PRs, got %d", len(overlapping))
@@ -149,13 +149,13 @@ func TestFinder_parseBlameResults_NoDataField(t *testing.T) {
 
 func TestFinder_parseBlameResults_NoRepositoryField(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Missing repository field
 	result := map[string]any{
 		"data": map[string]any{},
 	}
-	overlapping, all := finder.parseBlameResults(result, [][2]int{{10, 15}})
+	overlapping, all, _ := finder.parseBlameResults(result, "blame", [][2]int{{10, 15}})
 
 	if len(overlapping) != 0 || len(all) != 0 {
 		t.Error("expected empty results for missing repository field")
@@ -164,7 +164,7 @@ func TestFinder_parseBlameResults_NoRepositoryField(t *testing.T) {
 
 func TestFinder_parseBlameResults_NoBlameField(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Missing blame field
 	result := map[string]any{
@@ -176,7 +176,7 @@ func TestFinder_parseBlameResults_NoBlameField(t *testing.T) {
 			},
 		},
 	}
-	overlapping, all := finder.parseBlameResults(result, [][2]int{{10, 15}})
+	overlapping, all, _ := finder.parseBlameResults(result, "blame", [][2]int{{10, 15}})
 
 	if len(overlapping) != 0 || len(all) != 0 {
 		t.Error("expected empty results for missing blame field")
@@ -262,7 +262,7 @@ func TestExtractReviewers_DuplicateReviewers(t *testing.T) {
 
 func TestFinder_parseBlameResults_NoRanges(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Missing ranges field
 	result := map[string]any{
@@ -276,7 +276,7 @@ func TestFinder_parseBlameResults_NoRanges(t *testing.T) {
 			},
 		},
 	}
-	overlapping, all := finder.parseBlameResults(result, [][2]int{{10, 15}})
+	overlapping, all, _ := finder.parseBlameResults(result, "blame", [][2]int{{10, 15}})
 
 	if len(overlapping) != 0 || len(all) != 0 {
 		t.Error("expected empty results for missing ranges field")
@@ -285,7 +285,7 @@ func TestFinder_parseBlameResults_NoRanges(t *testing.T) {
 
 func TestFinder_parseBlameResults_InvalidRange(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Invalid range (missing startingLine)
 	result := map[string]any{
@@ -307,7 +307,7 @@ func TestFinder_parseBlameResults_InvalidRange(t *testing.T) {
 			},
 		},
 	}
-	overlapping, all := finder.parseBlameResults(result, [][2]int{{10, 15}})
+	overlapping, all, _ := finder.parseBlameResults(result, "blame", [][2]int{{10, 15}})
 
 	if len(overlapping) != 0 || len(all) != 0 {
 		t.Error("expected empty results for invalid range")
@@ -316,7 +316,7 @@ func TestFinder_parseBlameResults_InvalidRange(t *testing.T) {
 
 func TestFinder_parseBlameResults_InvalidPRNode(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Invalid prNode (not a map)
 	result := map[string]any{
@@ -344,7 +344,7 @@ func TestFinder_parseBlameResults_InvalidPRNode(t *testing.T) {
 			},
 		},
 	}
-	overlapping, all := finder.parseBlameResults(result, [][2]int{{10, 15}})
+	overlapping, all, _ := finder.parseBlameResults(result, "blame", [][2]int{{10, 15}})
 
 	if len(overlapping) != 0 || len(all) != 0 {
 		t.Error("expected empty results for invalid PR node")
@@ -353,7 +353,7 @@ func TestFinder_parseBlameResults_InvalidPRNode(t *testing.T) {
 
 func TestFinder_parseBlameResults_NotMergedPR(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// PR that is not merged
 	result := map[string]any{
@@ -384,7 +384,7 @@ func TestFinder_parseBlameResults_NotMergedPR(t *testing.T) {
 			},
 		},
 	}
-	overlapping, all := finder.parseBlameResults(result, [][2]int{{10, 15}})
+	overlapping, all, _ := finder.parseBlameResults(result, "blame", [][2]int{{10, 15}})
 
 	if len(overlapping) != 0 || len(all) != 0 {
 		t.Error("expected empty results for non-merged PR")
@@ -393,7 +393,7 @@ func TestFinder_parseBlameResults_NotMergedPR(t *testing.T) {
 
 func TestFinder_parseBlameResults_DirectCommitWithNoAuthor(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Direct commit with no PR and no author
 	result := map[string]any{
@@ -420,7 +420,7 @@ func TestFinder_parseBlameResults_DirectCommitWithNoAuthor(t *testing.T) {
 			},
 		},
 	}
-	overlapping, all := finder.parseBlameResults(result, [][2]int{{10, 15}})
+	overlapping, all, _ := finder.parseBlameResults(result, "blame", [][2]int{{10, 15}})
 
 	if len(overlapping) != 0 || len(all) != 0 {
 		t.Error("expected empty results for commit with no PR and no author")
@@ -430,7 +430,7 @@ func TestFinder_parseBlameResults_DirectCommitWithNoAuthor(t *testing.T) {
 func TestFinder_recentCommitsInDirectory_CacheTypeAssertionFails(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Set invalid cached value (wrong type)
 	cacheKey := "commits-dir:owner/repo:src:10"
@@ -465,6 +465,7 @@ func TestFinder_recentCommitsInDirectory_CacheTypeAssertionFails(t *testing.T) {
 
 	query := `
 	query($owner: String!, $repo: String!, $path: String!, $limit: Int!) {
+		rateLimit { limit cost remaining resetAt }
 		repository(owner: $owner, name: $repo) {
 			defaultBranchRef {
 				name
@@ -523,7 +524,7 @@ func TestFinder_recentCommitsInDirectory_CacheTypeAssertionFails(t *testing.T) {
 func TestFinder_recentPRsInProject_CacheTypeAssertionFails(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Set invalid cached value (wrong type)
 	cacheKey := "prs-project:owner/repo"
@@ -607,7 +608,7 @@ func TestFinder_recentPRsInProject_CacheTypeAssertionFails(t *testing.T) {
 }
 
 func TestFinder_parseDirectoryCommitsFromGraphQL_NoNodes(t *testing.T) {
-	finder := New(testutil.NewMockGitHubClient(), Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, testutil.NewMockGitHubClient(), Config{PRCountCache: time.Hour})
 
 	result := map[string]any{
 		"data": map[string]any{
@@ -2,18 +2,20 @@ package reviewer
 
 import (
 	"context"
+	"errors"
 	"testing"
 	"time"
 
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/internal/testutil"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
 )
 
-// TestIntegration_blameForLines tests the complete blame API flow
-func TestIntegration_blameForLines(t *testing.T) {
+// TestIntegration_blameFiles tests the complete blame API flow
+func TestIntegration_blameFiles(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Setup comprehensive GraphQL blame response
 	blameResponse := map[string]any{
@@ -21,7 +23,7 @@ func TestIntegration_blameForLines(t *testing.T) {
 			"repository": map[string]any{
 				"defaultBranchRef": map[string]any{
 					"target": map[string]any{
-						"blame": map[string]any{
+						"f0": map[string]any{
 							"ranges": []any{
 								// Range that overlaps with changed lines (10-20)
 								map[string]any{
@@ -137,59 +139,21 @@ func TestIntegration_blameForLines(t *testing.T) {
 	}
 
 	// Set the GraphQL response - using the exact query structure
-	client.SetGraphQLResponse(`
-	query($owner: String!, $repo: String!, $path: String!) {
-		repository(owner: $owner, name: $repo) {
-			defaultBranchRef {
-				target {
-					... on Commit {
-						blame(path: $path) {
-							ranges {
-								startingLine
-								endingLine
-								commit {
-									oid
-									author {
-										user {
-											login
-										}
-									}
-									associatedPullRequests(first: 1) {
-										nodes {
-											number
-											merged
-											mergedAt
-											author {
-												login
-											}
-											mergedBy {
-												login
-											}
-											reviews(first: 10, states: APPROVED) {
-												nodes {
-													author {
-														login
-													}
-												}
-											}
-										}
-									}
-								}
-							}
-						}
-					}
-				}
-			}
-		}
-	}`, blameResponse)
+	client.SetGraphQLResponse(blameBatchQuery(false, 1), blameResponse)
 
 	// Test with line ranges that should match
 	lineRanges := [][2]int{{10, 20}, {15, 22}}
 
-	overlappingPRs, allPRs, err := finder.blameForLines(ctx, "owner", "repo", "main.go", lineRanges)
+	result, err := finder.blameFiles(ctx, "owner", "repo", "", []string{"main.go"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
+	overlappingPRs, allPRs, blamedLines := finder.parseBlameResults(result, "f0", lineRanges)
+
+	// Lines 10-22 are changed; blame covers 10-15 (PR 100) and 18-25 (PR 300)
+	if blamedLines != 11 {
+		t.Errorf("expected 11 blamed lines, got %d", blamedLines)
+	}
 
 	// Should find 2 overlapping PRs (PR 100 and PR 300)
 	if len(overlappingPRs) != 2 {
@@ -213,7 +177,7 @@ func TestIntegration_blameForLines(t *testing.T) {
 func TestIntegration_collectWeightedCandidates(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Setup blame response for file analysis
 	blameResponse := map[string]any{
@@ -221,7 +185,7 @@ func TestIntegration_collectWeightedCandidates(t *testing.T) {
 			"repository": map[string]any{
 				"defaultBranchRef": map[string]any{
 					"target": map[string]any{
-						"blame": map[string]any{
+						"f0": map[string]any{
 							"ranges": []any{
 								map[string]any{
 									"startingLine": float64(10),
@@ -267,52 +231,7 @@ func TestIntegration_collectWeightedCandidates(t *testing.T) {
 		},
 	}
 
-	// Set the exact query from graphql.go (line 24)
-	blameQuery := `
-	query($owner: String!, $repo: String!, $path: String!) {
-		repository(owner: $owner, name: $repo) {
-			defaultBranchRef {
-				target {
-					... on Commit {
-						blame(path: $path) {
-							ranges {
-								startingLine
-								endingLine
-								commit {
-									oid
-									author {
-										user {
-											login
-										}
-									}
-									associatedPullRequests(first: 1) {
-										nodes {
-											number
-											merged
-											mergedAt
-											author {
-												login
-											}
-											mergedBy {
-												login
-											}
-											reviews(first: 10, states: APPROVED) {
-												nodes {
-													author {
-														login
-													}
-												}
-											}
-										}
-									}
-								}
-							}
-						}
-					}
-				}
-			}
-		}
-	}`
+	blameQuery := blameBatchQuery(false, 1)
 
 	client.SetGraphQLResponse(blameQuery, blameResponse)
 
@@ -333,7 +252,7 @@ func TestIntegration_collectWeightedCandidates(t *testing.T) {
 
 	files := []string{"main.go"}
 
-	candidates := finder.collectWeightedCandidates(ctx, pr, files)
+	candidates := finder.collectWeightedCandidates(ctx, pr, files, DefaultWeights(), &apiBudget{limit: 10}, 0, &types.Coverage{})
 
 	// Should have candidates from blame analysis
 	if len(candidates) == 0 {
@@ -366,7 +285,7 @@ func TestIntegration_collectWeightedCandidates(t *testing.T) {
 func TestIntegration_collectWeightedCandidates_FileLevelContributions(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Setup blame response with both overlapping and non-overlapping PR contributions
 	blameResponse := map[string]any{
@@ -374,7 +293,7 @@ func TestIntegration_collectWeightedCandidates_FileLevelContributions(t *testing
 			"repository": map[string]any{
 				"defaultBranchRef": map[string]any{
 					"target": map[string]any{
-						"blame": map[string]any{
+						"f0": map[string]any{
 							"ranges": []any{
 								// Overlapping range (lines 10-15, changed lines are 10-12)
 								map[string]any{
@@ -452,51 +371,7 @@ func TestIntegration_collectWeightedCandidates_FileLevelContributions(t *testing
 		},
 	}
 
-	blameQuery := `
-	query($owner: String!, $repo: String!, $path: String!) {
-		repository(owner: $owner, name: $repo) {
-			defaultBranchRef {
-				target {
-					... on Commit {
-						blame(path: $path) {
-							ranges {
-								startingLine
-								endingLine
-								commit {
-									oid
-									author {
-										user {
-											login
-										}
-									}
-									associatedPullRequests(first: 1) {
-										nodes {
-											number
-											merged
-											mergedAt
-											author {
-												login
-											}
-											mergedBy {
-												login
-											}
-											reviews(first: 10, states: APPROVED) {
-												nodes {
-													author {
-														login
-													}
-												}
-											}
-										}
-									}
-								}
-							}
-						}
-					}
-				}
-			}
-		}
-	}`
+	blameQuery := blameBatchQuery(false, 1)
 
 	client.SetGraphQLResponse(blameQuery, blameResponse)
 
@@ -517,7 +392,7 @@ func TestIntegration_collectWeightedCandidates_FileLevelContributions(t *testing
 
 	files := []string{"main.go"}
 
-	candidates := finder.collectWeightedCandidates(ctx, pr, files)
+	candidates := finder.collectWeightedCandidates(ctx, pr, files, DefaultWeights(), &apiBudget{limit: 10}, 0, &types.Coverage{})
 
 	if len(candidates) == 0 {
 		t.Fatal("expected candidates from blame analysis, got none")
@@ -587,7 +462,7 @@ func TestIntegration_collectWeightedCandidates_FileLevelContributions(t *testing
 func TestIntegration_findReviewersOptimized_WithBlame(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Setup comprehensive responses for all API calls
 
@@ -597,7 +472,7 @@ func TestIntegration_findReviewersOptimized_WithBlame(t *testing.T) {
 			"repository": map[string]any{
 				"defaultBranchRef": map[string]any{
 					"target": map[string]any{
-						"blame": map[string]any{
+						"f0": map[string]any{
 							"ranges": []any{
 								map[string]any{
 									"startingLine": float64(10),
@@ -643,52 +518,7 @@ func TestIntegration_findReviewersOptimized_WithBlame(t *testing.T) {
 		},
 	}
 
-	// Set the exact query from graphql.go (line 24)
-	blameQuery := `
-	query($owner: String!, $repo: String!, $path: String!) {
-		repository(owner: $owner, name: $repo) {
-			defaultBranchRef {
-				target {
-					... on Commit {
-						blame(path: $path) {
-							ranges {
-								startingLine
-								endingLine
-								commit {
-									oid
-									author {
-										user {
-											login
-										}
-									}
-									associatedPullRequests(first: 1) {
-										nodes {
-											number
-											merged
-											mergedAt
-											author {
-												login
-											}
-											mergedBy {
-												login
-											}
-											reviews(first: 10, states: APPROVED) {
-												nodes {
-													author {
-														login
-													}
-												}
-											}
-										}
-									}
-								}
-							}
-						}
-					}
-				}
-			}
-		}
-	}`
+	blameQuery := blameBatchQuery(false, 1)
 
 	client.SetGraphQLResponse(blameQuery, blameResponse)
 
@@ -727,7 +557,7 @@ func TestIntegration_findReviewersOptimized_WithBlame(t *testing.T) {
 	client.SetOpenPRCount("test-owner", "charlie", 2)
 	client.SetOpenPRCount("test-owner", "dave", 0)
 
-	reviewers := finder.findReviewersOptimized(ctx, pr)
+	reviewers, _ := finder.findReviewersOptimized(ctx, pr, config.Default())
 
 	if len(reviewers) == 0 {
 		t.Error("expected reviewers from optimized search, got none")
@@ -749,46 +579,113 @@ func TestIntegration_findReviewersOptimized_WithBlame(t *testing.T) {
 	}
 }
 
-// TestIntegration_blameForLines_EmptyRanges tests edge case
-func TestIntegration_blameForLines_EmptyRanges(t *testing.T) {
+// TestIntegration_blameFiles_AtRevision tests blaming several files at a non-default revision
+func TestIntegration_blameFiles_AtRevision(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	client.SetGraphQLResponse(blameBatchQuery(true, 2), map[string]any{
+		"data": map[string]any{
+			"repository": map[string]any{
+				"object": map[string]any{
+					"f1": map[string]any{
+						"ranges": []any{
+							map[string]any{
+								"startingLine": float64(1),
+								"endingLine":   float64(5),
+								"commit": map[string]any{
+									"oid": "abc123",
+									"associatedPullRequests": map[string]any{
+										"nodes": []any{
+											map[string]any{
+												"number":   float64(42),
+												"merged":   true,
+												"mergedAt": time.Now().Add(-24 * time.Hour).Format(time.RFC3339),
+												"author":   map[string]any{"login": "alice"},
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	})
 
-	overlapping, all, err := finder.blameForLines(ctx, "owner", "repo", "file.go", [][2]int{})
+	result, err := finder.blameFiles(ctx, "owner", "repo", "release-1.2", []string{"new.go", "main.go"})
 	if err != nil {
 		t.Fatalf("unexpected error: %v", err)
 	}
-
-	if len(overlapping) != 0 || len(all) != 0 {
-		t.Error("expected empty results for empty line ranges")
+	overlapping, _, blamedLines := finder.parseBlameResults(result, "f1", [][2]int{{3, 4}})
+	if len(overlapping) != 1 || overlapping[0].Number != 42 || overlapping[0].Author != "alice" {
+		t.Errorf("overlapping = %+v, want PR 42 by alice", overlapping)
+	}
+	if blamedLines != 2 {
+		t.Errorf("expected 2 blamed lines, got %d", blamedLines)
+	}
+	if overlapping, _, _ := finder.parseBlameResults(result, "f0", [][2]int{{3, 4}}); len(overlapping) != 0 {
+		t.Errorf("expected no blame for f0, got %+v", overlapping)
 	}
 }
 
-// TestIntegration_blameForLines_GraphQLError tests error handling
-func TestIntegration_blameForLines_GraphQLError(t *testing.T) {
+func TestFinder_blameRevision(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	client.SetMergeBase("owner", "repo", "release-1.2", "head123", "base456")
+	client.SetError("MergeBase:owner/repo/main...broken", errors.New("compare failed"))
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	tests := []struct {
+		name    string
+		baseRef string
+		headSHA string
+		want    string
+	}{
+		{name: "unknown base blames default branch", headSHA: "head123", want: ""},
+		{name: "unknown head blames base branch", baseRef: "release-1.2", want: "release-1.2"},
+		{name: "merge base", baseRef: "release-1.2", headSHA: "head123", want: "base456"},
+		{name: "merge base lookup fails", baseRef: "main", headSHA: "broken", want: "main"},
+	}
 
-	// Setup error response
-	errorResponse := map[string]any{
-		"errors": []any{
-			map[string]any{
-				"message": "Resource not accessible by integration",
-			},
-		},
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pr := &types.PullRequest{Owner: "owner", Repository: "repo", BaseRef: tt.baseRef, HeadSHA: tt.headSHA}
+			if got := finder.blameRevision(ctx, pr); got != tt.want {
+				t.Errorf("blameRevision() = %q, want %q", got, tt.want)
+			}
+		})
 	}
+}
 
-	client.SetGraphQLResponse("query", errorResponse)
+// TestIntegration_blameFiles_NoFiles tests edge case
+func TestIntegration_blameFiles_NoFiles(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
-	// Should handle GraphQL errors gracefully
-	overlapping, all, err := finder.blameForLines(ctx, "owner", "repo", "file.go", [][2]int{{1, 10}})
+	result, err := finder.blameFiles(ctx, "owner", "repo", "", nil)
 	if err != nil {
-		t.Fatalf("unexpected error (should handle GraphQL errors gracefully): %v", err)
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	overlapping, all, _ := finder.parseBlameResults(result, "f0", [][2]int{{1, 10}})
+	if len(overlapping) != 0 || len(all) != 0 {
+		t.Error("expected empty results for no files")
 	}
+}
 
-	// May return empty results due to GraphQL error
-	_ = overlapping
-	_ = all
+// TestIntegration_blameFiles_GraphQLError tests error handling
+func TestIntegration_blameFiles_GraphQLError(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	client.SetError("MakeGraphQLRequest:"+blameBatchQuery(false, 1), errors.New("Resource not accessible by integration"))
+
+	if _, err := finder.blameFiles(ctx, "owner", "repo", "", []string{"file.go"}); err == nil {
+		t.Fatal("expected error from failed blame request")
+	}
 }
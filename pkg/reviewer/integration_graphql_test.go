@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/internal/testutil"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
 )
@@ -13,7 +14,7 @@ import (
 func TestIntegration_recentPRsInProject(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Setup first batch response with pagination info
 	firstBatchResponse := map[string]any{
@@ -196,7 +197,7 @@ func TestIntegration_recentPRsInProject(t *testing.T) {
 func TestIntegration_recentCommitsInDirectory(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Setup directory commits response
 	dirCommitsResponse := map[string]any{
@@ -263,6 +264,7 @@ func TestIntegration_recentCommitsInDirectory(t *testing.T) {
 	// Set the exact query from graphql.go (line 326)
 	dirQuery := `
 	query($owner: String!, $repo: String!, $path: String!, $limit: Int!) {
+		rateLimit { limit cost remaining resetAt }
 		repository(owner: $owner, name: $repo) {
 			defaultBranchRef {
 				name
@@ -343,7 +345,7 @@ func TestIntegration_recentCommitsInDirectory(t *testing.T) {
 func TestIntegration_findReviewersOptimized_FullWorkflow(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Setup all necessary GraphQL responses
 
@@ -353,7 +355,7 @@ func TestIntegration_findReviewersOptimized_FullWorkflow(t *testing.T) {
 			"repository": map[string]any{
 				"defaultBranchRef": map[string]any{
 					"target": map[string]any{
-						"blame": map[string]any{
+						"f0": map[string]any{
 							"ranges": []any{
 								map[string]any{
 									"startingLine": float64(10),
@@ -394,51 +396,7 @@ func TestIntegration_findReviewersOptimized_FullWorkflow(t *testing.T) {
 	}
 
 	// Set the blame query response
-	blameQuery := `
-	query($owner: String!, $repo: String!, $path: String!) {
-		repository(owner: $owner, name: $repo) {
-			defaultBranchRef {
-				target {
-					... on Commit {
-						blame(path: $path) {
-							ranges {
-								startingLine
-								endingLine
-								commit {
-									oid
-									author {
-										user {
-											login
-										}
-									}
-									associatedPullRequests(first: 1) {
-										nodes {
-											number
-											merged
-											mergedAt
-											author {
-												login
-											}
-											mergedBy {
-												login
-											}
-											reviews(first: 10, states: APPROVED) {
-												nodes {
-													author {
-														login
-													}
-												}
-											}
-										}
-									}
-								}
-							}
-						}
-					}
-				}
-			}
-		}
-	}`
+	blameQuery := blameBatchQuery(false, 1)
 
 	client.SetGraphQLResponse(blameQuery, blameResponse)
 
@@ -476,7 +434,7 @@ func TestIntegration_findReviewersOptimized_FullWorkflow(t *testing.T) {
 	client.SetOpenPRCount("test-owner", "charlie", 1)
 	client.SetOpenPRCount("test-owner", "dir-expert", 3)
 
-	reviewers := finder.findReviewersOptimized(ctx, pr)
+	reviewers, _ := finder.findReviewersOptimized(ctx, pr, config.Default())
 
 	if len(reviewers) == 0 {
 		t.Fatal("expected reviewers, got none")
@@ -507,7 +465,7 @@ func TestIntegration_findReviewersOptimized_FullWorkflow(t *testing.T) {
 func TestIntegration_recentPRsInProject_Caching(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	response := map[string]any{
 		"data": map[string]any{
@@ -591,7 +549,7 @@ func TestIntegration_recentPRsInProject_Caching(t *testing.T) {
 func TestIntegration_recentCommitsInDirectory_Caching(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	response := map[string]any{
 		"data": map[string]any{
@@ -622,6 +580,7 @@ func TestIntegration_recentCommitsInDirectory_Caching(t *testing.T) {
 	// Use the same query as in the actual code
 	dirQuery := `
 	query($owner: String!, $repo: String!, $path: String!, $limit: Int!) {
+		rateLimit { limit cost remaining resetAt }
 		repository(owner: $owner, name: $repo) {
 			defaultBranchRef {
 				name
@@ -0,0 +1,108 @@
+package reviewer
+
+import (
+	"strings"
+	"sync"
+	"time"
+)
+
+// dailyCapWindow is the period over which the per-reviewer assignment cap applies.
+const dailyCapWindow = 24 * time.Hour
+
+// LoadTracker records review requests made by this process. Open PR counts are cached,
+// so without it every PR in a run would see the same workload for a reviewer no matter
+// how many reviews they had just been asked for. It is safe for concurrent use.
+type LoadTracker struct {
+	now         func() time.Time
+	assignments map[string][]time.Time // Lowercased username -> review request times
+	window      time.Duration          // How long a request counts as workload not yet in cached counts
+	dailyCap    int                    // Maximum requests per reviewer per day (0 for no cap)
+	mu          sync.Mutex
+}
+
+// NewLoadTracker creates a tracker. Recorded requests count toward a reviewer's workload
+// for window, which should match the open PR count cache duration. A dailyCap of 0 disables
+// the per-reviewer cap.
+func NewLoadTracker(window time.Duration, dailyCap int) *LoadTracker {
+	return &LoadTracker{
+		now:         time.Now,
+		assignments: make(map[string][]time.Time),
+		window:      window,
+		dailyCap:    dailyCap,
+	}
+}
+
+// Record notes that review was requested from usernames.
+func (t *LoadTracker) Record(usernames ...string) {
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	for _, username := range usernames {
+		key := strings.ToLower(username)
+		t.assignments[key] = append(t.prune(key, now), now)
+	}
+}
+
+// Pending returns how many recorded requests for username are not reflected in an open
+// PR count fetched at fetchedAt: those recorded after it, within the window. A zero
+// fetchedAt counts every request in the window.
+func (t *LoadTracker) Pending(username string, fetchedAt time.Time) int {
+	if t == nil {
+		return 0
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	count := 0
+	for _, at := range t.prune(strings.ToLower(username), now) {
+		if now.Sub(at) < t.window && at.After(fetchedAt) {
+			count++
+		}
+	}
+	return count
+}
+
+// AtDailyCap reports whether username has reached the daily assignment cap.
+func (t *LoadTracker) AtDailyCap(username string) bool {
+	if t == nil || t.dailyCap <= 0 {
+		return false
+	}
+	return t.countSince(username, dailyCapWindow) >= t.dailyCap
+}
+
+// countSince returns how many requests were recorded for username within d.
+func (t *LoadTracker) countSince(username string, d time.Duration) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	now := t.now()
+	times := t.prune(strings.ToLower(username), now)
+	count := 0
+	for _, at := range times {
+		if now.Sub(at) < d {
+			count++
+		}
+	}
+	return count
+}
+
+// prune drops requests older than both windows and returns those left. Callers hold mu.
+func (t *LoadTracker) prune(key string, now time.Time) []time.Time {
+	keep := max(t.window, dailyCapWindow)
+	times := t.assignments[key]
+	i := 0
+	for i < len(times) && now.Sub(times[i]) >= keep {
+		i++
+	}
+	if i == len(times) {
+		delete(t.assignments, key)
+		return nil
+	}
+	t.assignments[key] = times[i:]
+	return times[i:]
+}
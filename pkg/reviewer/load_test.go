@@ -0,0 +1,87 @@
+package reviewer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/internal/testutil"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+func TestLoadTracker(t *testing.T) {
+	now := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	tracker := NewLoadTracker(6*time.Hour, 2)
+	tracker.now = func() time.Time { return now }
+
+	tracker.Record("Bob")
+	if got := tracker.Pending("bob", time.Time{}); got != 1 {
+		t.Errorf("expected 1 pending assignment (case-insensitive), got %d", got)
+	}
+	if got := tracker.Pending("bob", now); got != 0 {
+		t.Errorf("expected no pending assignments for a count fetched after them, got %d", got)
+	}
+	if tracker.AtDailyCap("bob") {
+		t.Error("expected bob to be under the daily cap after one assignment")
+	}
+
+	now = now.Add(7 * time.Hour)
+	tracker.Record("bob")
+	if got := tracker.Pending("bob", time.Time{}); got != 1 {
+		t.Errorf("expected assignment older than the window to no longer be pending, got %d", got)
+	}
+	if !tracker.AtDailyCap("bob") {
+		t.Error("expected bob to reach the daily cap with two assignments in 24h")
+	}
+
+	now = now.Add(20 * time.Hour)
+	if tracker.AtDailyCap("bob") || tracker.Pending("bob", time.Time{}) != 0 {
+		t.Error("expected assignments older than 24h to be forgotten")
+	}
+}
+
+func TestLoadTracker_Nil(t *testing.T) {
+	var tracker *LoadTracker
+	tracker.Record("bob")
+	if tracker.Pending("bob", time.Time{}) != 0 || tracker.AtDailyCap("bob") {
+		t.Error("nil tracker should record nothing")
+	}
+}
+
+func TestFindReviewersOptimized_LoadTracker(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	client.SetWriteAccess("owner", "repo", "bob", true)
+	client.SetBatchOpenPRCount("owner", map[string]int{"bob": 1})
+
+	load := NewLoadTracker(time.Hour, 3)
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour, Load: load})
+	pr := &types.PullRequest{Owner: "owner", Repository: "repo", Author: "alice", Assignees: []string{"bob"}}
+
+	load.Record("bob", "bob")
+	candidates, _ := finder.findReviewersOptimized(ctx, pr, config.Default())
+	if len(candidates) != 1 {
+		t.Fatalf("expected 1 candidate, got %+v", candidates)
+	}
+	bd := candidates[0].Breakdown
+	if bd.OpenPRs != 3 || bd.RecentAssignments != 2 {
+		t.Errorf("expected 3 open PRs including 2 recent assignments, got %d/%d", bd.OpenPRs, bd.RecentAssignments)
+	}
+	// 200 assignee - 3 open PRs * 10
+	if candidates[0].ContextScore != 170 {
+		t.Errorf("expected score 170, got %d", candidates[0].ContextScore)
+	}
+
+	// Once the count is refetched it includes those requests, so they aren't added again
+	client.SetOpenPRCountsFetchedAt("owner", time.Now().Add(time.Second))
+	candidates, _ = finder.findReviewersOptimized(ctx, pr, config.Default())
+	if bd := candidates[0].Breakdown; bd.OpenPRs != 1 || bd.RecentAssignments != 0 {
+		t.Errorf("expected requests before the count was fetched not to count twice, got %d/%d", bd.OpenPRs, bd.RecentAssignments)
+	}
+
+	load.Record("bob")
+	if candidates, _ := finder.findReviewersOptimized(ctx, pr, config.Default()); len(candidates) != 0 {
+		t.Errorf("expected reviewer at daily cap to be filtered, got %+v", candidates)
+	}
+}
@@ -5,26 +5,53 @@ import (
 	"fmt"
 	"log/slog"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"sort"
 	"strings"
+	"time"
 
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/availability"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
 )
 
 // candidateWeight represents a reviewer candidate with their weight.
 type candidateWeight struct {
-	sourceScores    map[string]int // Score breakdown by source: "file-author" -> 10, "recent-merger" -> 50, etc.
-	username        string
-	weight          int
-	workloadPenalty int
-	finalScore      int
+	sourceScores        map[string]int // Score breakdown by source: "file-author" -> 10, "recent-merger" -> 50, etc.
+	username            string
+	evidence            []types.Evidence // History backing the source scores
+	weight              int
+	workloadPenalty     int
+	openPRs             int
+	recentAssignments   int    // Review requests by this process included in openPRs
+	availability        string // Why the candidate has limited availability ("" if available)
+	availabilityPenalty int
+	responseTime        time.Duration // Median time to first review (0 if unknown)
+	responsiveness      int           // Bonus (positive) or penalty (negative) for response time
+	finalScore          int
+}
+
+// addScore credits a candidate with a source's score, creating the candidate if needed.
+func addScore(candidates map[string]*candidateWeight, username, source string, score int, evidence ...types.Evidence) {
+	c, exists := candidates[username]
+	if !exists {
+		c = &candidateWeight{username: username, sourceScores: make(map[string]int)}
+		candidates[username] = c
+	}
+	c.weight += score
+	c.sourceScores[source] += score
+	c.evidence = append(c.evidence, evidence...)
 }
 
 // findReviewersOptimized finds reviewers using scoring with workload penalties.
+// Also returns how much of the PR's changes the file history analysis covered.
 //
 //nolint:gocognit,revive,maintidx // High complexity and length inherent to multi-source reviewer scoring algorithm
-func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullRequest) []types.ReviewerCandidate {
-	// Get the 3 files with the largest delta, excluding lock files
+func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullRequest, cfg *config.Config) ([]types.ReviewerCandidate, *types.Coverage) {
+	w := f.weights.withOverrides(cfg.Weights)
+	now := f.now()
+
 	// Build candidate map to accumulate scores from all sources
 	candidateMap := make(map[string]*candidateWeight)
 
@@ -34,19 +61,41 @@ func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullReque
 			slog.Info("Skipping assignee (is PR author)", "assignee", assignee)
 			continue
 		}
-		slog.Info("Adding candidate from PR assignee", "username", assignee, "weight", 200)
-		candidateMap[assignee] = &candidateWeight{
-			username:     assignee,
-			weight:       200,
-			sourceScores: map[string]int{"assignee": 200},
+		slog.Info("Adding candidate from PR assignee", "username", assignee, "weight", w.Assignee)
+		addScore(candidateMap, assignee, "assignee", w.Assignee)
+	}
+
+	// Source 2: CODEOWNERS (explicit ownership of the changed paths)
+	// Weighted per owned file, capped so ownership can't drown out line-level expertise
+	for username, files := range f.codeownerFiles(ctx, pr) {
+		if username == pr.Author || f.client.IsUserBot(ctx, username) {
+			continue
+		}
+		ownerWeight := min(len(files), maxCodeownerFiles) * w.Codeowner
+		if _, exists := candidateMap[username]; !exists {
+			slog.Info("Adding candidate from CODEOWNERS", "username", username, "owned_files", len(files), "weight", ownerWeight)
+		}
+		evidence := make([]types.Evidence, len(files))
+		for i, file := range files {
+			evidence[i] = types.Evidence{Source: "codeowner", Path: file}
+			if i < maxCodeownerFiles {
+				evidence[i].Score = w.Codeowner
+			}
 		}
+		addScore(candidateMap, username, "codeowner", ownerWeight, evidence...)
 	}
 
-	// Source 2: File history via blame (if we have changed files)
-	topFiles := f.topChangedFilesFiltered(pr, 3)
-	if len(topFiles) > 0 {
-		slog.Info("Analyzing top files with largest changes", "count", len(topFiles))
-		fileCandidates := f.collectWeightedCandidates(ctx, pr, topFiles)
+	// Source 3: File history via blame, batched and bounded by the API budget
+	files := f.topChangedFilesFiltered(pr, cfg.MaxFiles, cfg.IgnorePaths, f.generatedClassifier(ctx, pr.Owner, pr.Repository))
+	dirs := changedDirectories(files)
+	budget := &apiBudget{limit: cfg.APIBudget}
+	coverage := &types.Coverage{APIBudget: cfg.APIBudget}
+	if len(files) > 0 {
+		// Keep part of the budget for directory history, so files left unblamed on large PRs
+		// still contribute through their directory
+		reserve := min(len(dirs), cfg.APIBudget/4)
+		slog.Info("Analyzing changed files", "count", len(files), "directories", len(dirs), "api_budget", cfg.APIBudget)
+		fileCandidates := f.collectWeightedCandidates(ctx, pr, files, w, budget, reserve, coverage)
 		slog.Info("Found weighted candidates from file history", "count", len(fileCandidates))
 
 		// Merge file candidates into map
@@ -57,6 +106,7 @@ func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullReque
 				for source, score := range fc.sourceScores {
 					existing.sourceScores[source] = score
 				}
+				existing.evidence = append(existing.evidence, fc.evidence...)
 			} else {
 				candidateMap[fc.username] = &fc
 			}
@@ -65,95 +115,74 @@ func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullReque
 		slog.Info("No changed files to analyze, relying on other signals")
 	}
 
-	// Source 3: Directory-level contributions (last 10 commits to each directory)
-	//nolint:nestif // Nested logic required for multi-directory contributor analysis
-	if len(topFiles) > 0 {
-		// Get unique directories from top changed files
-		seenDirs := make(map[string]bool)
-		var dirs []string
-		for _, file := range topFiles {
-			dir := filepath.Dir(file)
-			if !seenDirs[dir] {
-				seenDirs[dir] = true
-				dirs = append(dirs, dir)
+	// Source 4: Directory-level contributions (last 10 commits to each directory), with the remaining budget
+	skippedDirs := 0
+	for _, dir := range dirs {
+		// Only requests count against the budget, not history cached from an earlier PR
+		dirPRs, cached := f.cachedCommitsInDirectory(pr.Owner, pr.Repository, dir)
+		if !cached {
+			if !budget.spend(0) {
+				skippedDirs++
+				continue
 			}
-		}
-
-		// Query each directory for recent commits
-		for _, dir := range dirs {
-			dirPRs, err := f.recentCommitsInDirectory(ctx, pr.Owner, pr.Repository, dir)
-			if err != nil {
+			var err error
+			if dirPRs, err = f.recentCommitsInDirectory(ctx, pr.Owner, pr.Repository, dir); err != nil {
 				slog.Warn("Failed to fetch directory commits, continuing without", "dir", dir, "error", err)
 				continue
 			}
+		}
+		coverage.DirectoriesAnalyzed++
 
-			if len(dirPRs) == 0 {
-				continue
-			}
+		if len(dirPRs) == 0 {
+			continue
+		}
 
-			slog.Info("Found recent commits/PRs in directory", "dir", dir, "count", len(dirPRs))
-			// Add directory contributors with moderate weight (+3 per PR involvement)
-			for _, dirPR := range dirPRs {
-				dirWeight := 3
-
-				if dirPR.Author != "" && !f.client.IsUserBot(ctx, dirPR.Author) {
-					if existing, exists := candidateMap[dirPR.Author]; exists {
-						existing.weight += dirWeight
-						if existing.sourceScores["dir-author"] == 0 {
-							existing.sourceScores["dir-author"] = dirWeight
-						} else {
-							existing.sourceScores["dir-author"] += dirWeight
-						}
-					} else {
-						candidateMap[dirPR.Author] = &candidateWeight{
-							username:     dirPR.Author,
-							weight:       dirWeight,
-							sourceScores: map[string]int{"dir-author": dirWeight},
-						}
-					}
-				}
+		slog.Info("Found recent commits/PRs in directory", "dir", dir, "count", len(dirPRs))
+		// Add directory contributors with moderate weight per PR involvement
+		for _, dirPR := range dirPRs {
+			dirWeight := w.decay(w.Directory, dirPR.MergedAt, now)
+			mergerWeight := w.decay(w.Directory*w.MergerMultiplier, dirPR.MergedAt, now)
 
-				if dirPR.MergedBy != "" && !f.client.IsUserBot(ctx, dirPR.MergedBy) {
-					mergeWeight := dirWeight * 2
-					if existing, exists := candidateMap[dirPR.MergedBy]; exists {
-						existing.weight += mergeWeight
-						if existing.sourceScores["dir-merger"] == 0 {
-							existing.sourceScores["dir-merger"] = mergeWeight
-						} else {
-							existing.sourceScores["dir-merger"] += mergeWeight
-						}
-					} else {
-						candidateMap[dirPR.MergedBy] = &candidateWeight{
-							username:     dirPR.MergedBy,
-							weight:       mergeWeight,
-							sourceScores: map[string]int{"dir-merger": mergeWeight},
-						}
-					}
-				}
+			if dirPR.Author != "" && !f.client.IsUserBot(ctx, dirPR.Author) {
+				addScore(candidateMap, dirPR.Author, "dir-author", dirWeight,
+					types.Evidence{Source: "dir-author", Path: dir, PR: dirPR.Number, MergedAt: dirPR.MergedAt, Score: dirWeight})
+			}
 
-				for _, reviewer := range dirPR.Reviewers {
-					if reviewer != "" && !f.client.IsUserBot(ctx, reviewer) {
-						if existing, exists := candidateMap[reviewer]; exists {
-							existing.weight += dirWeight
-							if existing.sourceScores["dir-reviewer"] == 0 {
-								existing.sourceScores["dir-reviewer"] = dirWeight
-							} else {
-								existing.sourceScores["dir-reviewer"] += dirWeight
-							}
-						} else {
-							candidateMap[reviewer] = &candidateWeight{
-								username:     reviewer,
-								weight:       dirWeight,
-								sourceScores: map[string]int{"dir-reviewer": dirWeight},
-							}
-						}
-					}
+			if dirPR.MergedBy != "" && !f.client.IsUserBot(ctx, dirPR.MergedBy) {
+				addScore(candidateMap, dirPR.MergedBy, "dir-merger", mergerWeight,
+					types.Evidence{Source: "dir-merger", Path: dir, PR: dirPR.Number, MergedAt: dirPR.MergedAt, Score: mergerWeight})
+			}
+
+			for _, reviewer := range dirPR.Reviewers {
+				if reviewer != "" && !f.client.IsUserBot(ctx, reviewer) {
+					addScore(candidateMap, reviewer, "dir-reviewer", dirWeight,
+						types.Evidence{Source: "dir-reviewer", Path: dir, PR: dirPR.Number, MergedAt: dirPR.MergedAt, Score: dirWeight})
 				}
 			}
 		}
 	}
 
-	// Source 4: Recent project activity (last 200 PRs)
+	if skippedDirs > 0 {
+		slog.Info("API budget exhausted, skipped directory history", "skipped", skippedDirs, "budget", budget.limit)
+	}
+
+	coverage.APIRequests = budget.used
+	slog.Info("History analysis coverage",
+		"files_analyzed", coverage.FilesAnalyzed, "files_skipped", coverage.FilesSkipped,
+		"directories_analyzed", coverage.DirectoriesAnalyzed,
+		"blamed_lines", coverage.BlamedLines, "changed_lines", coverage.ChangedLines,
+		"ratio", fmt.Sprintf("%.0f%%", coverage.Ratio()*100),
+		"api_requests", coverage.APIRequests, "api_budget", coverage.APIBudget)
+
+	// Source 5: Author affinity (who regularly approves the author's PRs), capped below line-level expertise
+	for username, a := range f.authorAffinityScores(ctx, pr, candidateMap, w) {
+		if _, exists := candidateMap[username]; !exists {
+			slog.Info("Adding candidate from author affinity", "username", username, "weight", a.score)
+		}
+		addScore(candidateMap, username, "author-affinity", a.score, a.evidence...)
+	}
+
+	// Source 6: Recent project activity (last 200 PRs)
 	recentPRs, err := f.recentPRsInProject(ctx, pr.Owner, pr.Repository)
 	if err != nil {
 		slog.Warn("Failed to fetch recent PRs, continuing without recent activity signal", "error", err)
@@ -161,45 +190,46 @@ func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullReque
 	}
 
 	recentActivityScores := make(map[string]int)
+	decayedActivity := make(map[string]float64) // Activity counts with older PRs decayed
+	lastActive := make(map[string]time.Time)    // Latest merge of a recent PR each user took part in
 	if len(recentPRs) > 0 {
+		seen := func(username string, at time.Time) {
+			recentActivityScores[username]++
+			decayedActivity[username] += w.decayFactor(at, now)
+			if at.After(lastActive[username]) {
+				lastActive[username] = at
+			}
+		}
 		for _, recentPR := range recentPRs {
 			if recentPR.Author != "" && !f.client.IsUserBot(ctx, recentPR.Author) {
-				recentActivityScores[recentPR.Author]++ // +1 for authoring
+				seen(recentPR.Author, recentPR.MergedAt) // +1 for authoring
 			}
 			if recentPR.MergedBy != "" && !f.client.IsUserBot(ctx, recentPR.MergedBy) {
-				recentActivityScores[recentPR.MergedBy]++ // +1 for merging
+				seen(recentPR.MergedBy, recentPR.MergedAt) // +1 for merging
 			}
 			for _, reviewer := range recentPR.Reviewers {
 				if reviewer != "" && !f.client.IsUserBot(ctx, reviewer) {
-					recentActivityScores[reviewer]++ // +1 for reviewing
+					seen(reviewer, recentPR.MergedAt) // +1 for reviewing
 				}
 			}
 		}
 		slog.Info("Built recent activity scores", "contributors", len(recentActivityScores), "from_prs", len(recentPRs))
 	}
 
-	// Merge recent activity scores into candidate map (scaled down by 10x to avoid overwhelming other signals)
+	// Merge recent activity scores into candidate map (scaled down to avoid overwhelming other signals)
 	for username, activityScore := range recentActivityScores {
-		// Scale down by 10x - recent activity is a weak signal compared to file/line expertise
-		scaledScore := activityScore / 10
+		// Scale down - recent activity is a weak signal compared to file/line expertise
+		scaledScore := int(decayedActivity[username] / float64(w.RecentActivityDivisor))
 		if scaledScore == 0 && activityScore > 0 {
 			scaledScore = 1 // Ensure at least 1 point if they have any activity
 		}
 
-		if existing, exists := candidateMap[username]; exists {
-			// Add to existing candidate
-			existing.weight += scaledScore
-			existing.sourceScores["recent-activity"] = scaledScore
+		if _, exists := candidateMap[username]; exists {
 			slog.Debug("Added recent activity to existing candidate", "username", username, "activity_score", scaledScore)
 		} else {
-			// Create new candidate from recent activity alone
 			slog.Info("Adding candidate from recent activity only", "username", username, "activity_score", scaledScore)
-			candidateMap[username] = &candidateWeight{
-				username:     username,
-				weight:       scaledScore,
-				sourceScores: map[string]int{"recent-activity": scaledScore},
-			}
 		}
+		addScore(candidateMap, username, "recent-activity", scaledScore)
 	}
 
 	slog.Info("Total candidates after all sources", "count", len(candidateMap))
@@ -212,8 +242,12 @@ func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullReque
 			slog.Info("Filtered out candidate", "username", c.username, "reason", "is PR author", "weight", c.weight)
 			continue
 		}
-		if !f.isValidReviewer(ctx, pr, c.username) {
-			slog.Info("Filtered out candidate", "username", c.username, "reason", "failed validation")
+		if f.load.AtDailyCap(c.username) {
+			slog.Info("Filtered out candidate", "username", c.username, "reason", "daily assignment cap reached")
+			continue
+		}
+		if reason, ok := f.isValidReviewer(ctx, pr, cfg, c.username); !ok {
+			slog.Info("Filtered out candidate", "username", c.username, "reason", reason)
 			continue
 		}
 		// Filter by recent activity (must be in last 200 PRs)
@@ -228,7 +262,7 @@ func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullReque
 
 	if len(validCandidates) == 0 {
 		slog.Info("No valid candidates after filtering")
-		return nil
+		return nil, coverage
 	}
 
 	slog.Info("Valid candidates after filtering", "count", len(validCandidates))
@@ -238,6 +272,12 @@ func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullReque
 		return validCandidates[i].weight > validCandidates[j].weight
 	})
 
+	validCandidates = f.applyAvailability(ctx, validCandidates, lastActive, w)
+	if len(validCandidates) == 0 {
+		slog.Info("No available candidates")
+		return nil, coverage
+	}
+
 	// Log top candidates before workload check
 	for i, c := range validCandidates {
 		if i >= 10 {
@@ -246,12 +286,15 @@ func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullReque
 		slog.Info("Valid candidate before workload", "rank", i+1, "username", c.username, "weight", c.weight)
 	}
 
-	// Only check workload for top 5 candidates (optimization to reduce API calls)
-	workloadCheckLimit := 5
-	if len(validCandidates) < workloadCheckLimit {
-		workloadCheckLimit = len(validCandidates)
+	// Coverage selection may pick lower-ranked specialists, so it considers a wider pool
+	poolSize := 5
+	if cfg.Selection == config.SelectionCoverage {
+		poolSize = coverageCandidatePool
 	}
 
+	// Only check workload for the candidate pool (optimization to reduce API calls)
+	workloadCheckLimit := min(poolSize, len(validCandidates))
+
 	// Batch fetch workload for top candidates
 	topUsernames := make([]string, workloadCheckLimit)
 	for i := range workloadCheckLimit {
@@ -261,17 +304,19 @@ func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullReque
 	workloadCounts, err := f.client.BatchOpenPRCount(ctx, pr.Owner, topUsernames, f.prCountCache)
 	if err != nil {
 		slog.Warn("Failed to batch fetch workload, continuing without penalties", "error", err)
-		workloadCounts = make(map[string]int)
+		workloadCounts = make(map[string]types.OpenPRCount)
 	}
 
-	// Apply workload penalties to top candidates (10 points per PR, capped at 50% of score)
+	// Apply workload penalties to top candidates (per open PR, capped at a share of the score)
 	for i := range workloadCheckLimit {
 		username := validCandidates[i].username
-		prCount := workloadCounts[username]
-		rawPenalty := prCount * 10
+		// Review requests made since the count was fetched are not in it yet
+		recent := f.load.Pending(username, workloadCounts[username].FetchedAt)
+		prCount := workloadCounts[username].Count + recent
+		rawPenalty := prCount * w.WorkloadPerPR
 
-		// Cap penalty at 50% of expertise score to avoid driving highly contexted people negative
-		maxPenalty := validCandidates[i].weight / 2
+		// Cap penalty at a share of expertise score to avoid driving highly contexted people negative
+		maxPenalty := validCandidates[i].weight * w.WorkloadMaxPercent / 100
 		penalty := rawPenalty
 		if penalty > maxPenalty {
 			penalty = maxPenalty
@@ -282,13 +327,18 @@ func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullReque
 		}
 
 		validCandidates[i].workloadPenalty = penalty
-		validCandidates[i].finalScore = validCandidates[i].weight - penalty
+		validCandidates[i].openPRs = prCount
+		validCandidates[i].recentAssignments = recent
+		validCandidates[i].finalScore -= penalty
 		slog.Info("Applied workload penalty",
-			"username", username, "pr_count", prCount, "penalty", penalty,
+			"username", username, "pr_count", prCount, "recent_assignments", recent, "penalty", penalty,
 			"weight", validCandidates[i].weight, "final_score", validCandidates[i].finalScore)
 	}
 
-	// Re-sort by final score (with workload penalties applied to top 10)
+	// Reward reviewers who historically respond quickly, penalize slow ones
+	f.applyResponsiveness(ctx, pr.Owner, validCandidates[:workloadCheckLimit], w)
+
+	// Re-sort by final score (with workload and responsiveness applied to the pool)
 	sort.Slice(validCandidates, func(i, j int) bool {
 		return validCandidates[i].finalScore > validCandidates[j].finalScore
 	})
@@ -308,14 +358,14 @@ func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullReque
 			"sources", strings.Join(sourceList, ","))
 	}
 
-	// Convert top 5 to ReviewerCandidates
+	// Convert the candidate pool to ReviewerCandidates
 	var reviewers []types.ReviewerCandidate
 	for i, c := range validCandidates {
-		if i >= 5 {
+		if i >= poolSize {
 			break
 		}
 
-		// Build score breakdown string with workload penalty
+		// Build score breakdown string with workload and availability penalties
 		var scoreBreakdown []string
 		for source, score := range c.sourceScores {
 			scoreBreakdown = append(scoreBreakdown, fmt.Sprintf("%s:+%d", source, score))
@@ -323,6 +373,12 @@ func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullReque
 		if c.workloadPenalty > 0 {
 			scoreBreakdown = append(scoreBreakdown, fmt.Sprintf("workload:-%d", c.workloadPenalty))
 		}
+		if c.availabilityPenalty > 0 {
+			scoreBreakdown = append(scoreBreakdown, fmt.Sprintf("availability:-%d", c.availabilityPenalty))
+		}
+		if c.responsiveness != 0 {
+			scoreBreakdown = append(scoreBreakdown, fmt.Sprintf("responsiveness:%+d", c.responsiveness))
+		}
 		sort.Strings(scoreBreakdown) // Sort for consistent display
 		method := strings.Join(scoreBreakdown, ", ")
 
@@ -330,27 +386,117 @@ func (f *Finder) findReviewersOptimized(ctx context.Context, pr *types.PullReque
 			Username:        c.username,
 			SelectionMethod: method,
 			ContextScore:    c.finalScore,
+			Breakdown:       c.breakdown(),
+			FileScores:      c.fileScores(files, w),
 		})
 	}
 
-	return reviewers
+	return reviewers, coverage
+}
+
+// changedDirectories returns the unique directories of files, in order of first appearance.
+func changedDirectories(files []string) []string {
+	seen := make(map[string]bool)
+	var dirs []string
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		if !seen[dir] {
+			seen[dir] = true
+			dirs = append(dirs, dir)
+		}
+	}
+	return dirs
+}
+
+// applyAvailability drops unavailable candidates and penalizes those with limited
+// availability, then re-sorts by score. Candidates must be sorted by expertise; only the
+// top availabilityCheckLimit have their GitHub status looked up.
+func (f *Finder) applyAvailability(ctx context.Context, candidates []candidateWeight, lastActive map[string]time.Time, w Weights) []candidateWeight {
+	usernames := make([]string, 0, min(len(candidates), availabilityCheckLimit))
+	for _, c := range candidates[:min(len(candidates), availabilityCheckLimit)] {
+		usernames = append(usernames, c.username)
+	}
+	statuses := f.availability.Check(ctx, usernames, lastActive)
+
+	available := candidates[:0]
+	for _, c := range candidates {
+		status := statuses[c.username]
+		switch status.Level {
+		case availability.Unavailable:
+			slog.Info("Filtered out candidate", "username", c.username, "reason", status.Reason)
+			continue
+		case availability.Limited:
+			c.availability = status.Reason
+			c.availabilityPenalty = c.weight * w.LimitedAvailability / 100
+			c.finalScore = c.weight - c.availabilityPenalty
+			slog.Info("Applied availability penalty",
+				"username", c.username, "reason", status.Reason, "penalty", c.availabilityPenalty, "weight", c.weight)
+		default:
+		}
+		available = append(available, c)
+	}
+
+	sort.SliceStable(available, func(i, j int) bool {
+		return available[i].finalScore > available[j].finalScore
+	})
+	return available
+}
+
+// breakdown returns the structured explanation of the candidate's score.
+func (c *candidateWeight) breakdown() *types.ScoreBreakdown {
+	sources := make(map[string]int, len(c.sourceScores))
+	for source, score := range c.sourceScores {
+		sources[source] = score
+	}
+	// Keep the evidence that contributed most, so codeowner entries for many files (most
+	// scoring nothing past maxCodeownerFiles) can't crowd out blame and history
+	evidence := slices.Clone(c.evidence)
+	slices.SortStableFunc(evidence, func(a, b types.Evidence) int { return b.Score - a.Score })
+	if len(evidence) > maxEvidencePerCandidate {
+		evidence = evidence[:maxEvidencePerCandidate]
+	}
+	return &types.ScoreBreakdown{
+		Sources:             sources,
+		Evidence:            evidence,
+		Expertise:           c.weight,
+		WorkloadPenalty:     c.workloadPenalty,
+		OpenPRs:             c.openPRs,
+		RecentAssignments:   c.recentAssignments,
+		Availability:        c.availability,
+		AvailabilityPenalty: c.availabilityPenalty,
+		Responsiveness:      c.responsiveness,
+		MedianResponseHours: medianResponseHours(c.responseTime),
+	}
 }
 
-// topChangedFilesFiltered returns the N files with the largest delta, excluding lock files.
-func (*Finder) topChangedFilesFiltered(pr *types.PullRequest, n int) []string {
+// topChangedFilesFiltered returns the N files with the largest delta, excluding generated,
+// vendored and lock files (as classified by gen) and files matching the given gitignore-style patterns.
+func (*Finder) topChangedFilesFiltered(pr *types.PullRequest, n int, ignorePaths []string, gen *generatedClassifier) []string {
 	type fileChange struct {
 		name    string
 		changes int
 	}
 
-	// Files to ignore (lock/generated files)
-	ignoredFiles := map[string]bool{
-		"go.sum":            true,
-		"package-lock.json": true,
-		"yarn.lock":         true,
-		"Gemfile.lock":      true,
-		"Cargo.lock":        true,
-		"poetry.lock":       true,
+	var ignorePatterns []*regexp.Regexp
+	for _, pattern := range ignorePaths {
+		re, err := codeownersPatternToRegexp(pattern)
+		if err != nil {
+			slog.Warn("Skipping invalid ignore pattern", "pattern", pattern, "error", err)
+			continue
+		}
+		ignorePatterns = append(ignorePatterns, re)
+	}
+	isIgnored := func(file types.ChangedFile) bool {
+		for _, re := range ignorePatterns {
+			if re.MatchString(file.Filename) {
+				return true
+			}
+		}
+		if reason := gen.generatedReason(file); reason != "" {
+			slog.Info("Skipping generated file", "filename", file.Filename, "reason", reason)
+			return true
+		}
+		return false
 	}
 
 	// First pass: collect all non-ignored files
@@ -361,7 +507,7 @@ func (*Finder) topChangedFilesFiltered(pr *types.PullRequest, n int) []string {
 			name:    file.Filename,
 			changes: file.Additions + file.Deletions,
 		}
-		if ignoredFiles[filepath.Base(file.Filename)] {
+		if isIgnored(file) {
 			ignoredFilesList = append(ignoredFilesList, fc)
 		} else {
 			nonIgnoredFiles = append(nonIgnoredFiles, fc)
@@ -373,7 +519,7 @@ func (*Finder) topChangedFilesFiltered(pr *types.PullRequest, n int) []string {
 	if len(nonIgnoredFiles) > 0 {
 		fileChanges = nonIgnoredFiles
 	} else {
-		slog.Info("Only lock/generated files changed, analyzing them", "count", len(ignoredFilesList))
+		slog.Info("Only lock/generated/ignored files changed, analyzing them", "count", len(ignoredFilesList))
 		fileChanges = ignoredFilesList
 	}
 
@@ -396,12 +542,25 @@ func (*Finder) topChangedFilesFiltered(pr *types.PullRequest, n int) []string {
 }
 
 // collectWeightedCandidates collects candidates using GitHub blame API to find line-level experts.
+// Files are blamed maxBlameBatch per request, in the given order, while the budget allows
+// leaving reserve requests for later stages. Files and lines covered are added to cov.
 //
 //nolint:gocognit // High complexity required for line-level blame analysis and scoring
-func (f *Finder) collectWeightedCandidates(ctx context.Context, pr *types.PullRequest, files []string) []candidateWeight {
+func (f *Finder) collectWeightedCandidates(
+	ctx context.Context, pr *types.PullRequest, files []string, w Weights, budget *apiBudget, reserve int, cov *types.Coverage,
+) []candidateWeight {
 	candidateMap := make(map[string]*candidateWeight)
 
-	// Use blame API to find who last touched the lines being modified
+	// Blame where the diff's old-side line numbers point, not wherever the default branch is now
+	rev := f.blameRevision(ctx, pr)
+
+	// Only lines that existed before the PR have someone to blame
+	type blameTarget struct {
+		file  string // Path in the PR
+		path  string // Path at the blamed revision
+		lines [][2]int
+	}
+	var targets []blameTarget
 	for _, file := range files {
 		// Get the changed lines for this file in the current PR
 		changedLines, err := f.getChangedLines(pr, file)
@@ -415,112 +574,45 @@ func (f *Finder) collectWeightedCandidates(ctx context.Context, pr *types.PullRe
 			continue
 		}
 
-		slog.Info("Analyzing changed lines with blame", "file", file, "line_ranges", len(changedLines))
-
-		// Use blame to find PRs that touched these lines (overlapping) and file (non-overlapping)
-		overlappingPRs, filePRs, err := f.blameForLines(ctx, pr.Owner, pr.Repository, file, changedLines)
-		if err != nil {
-			slog.Warn("Failed to get blame", "file", file, "error", err)
-			continue
+		for _, r := range changedLines {
+			cov.ChangedLines += r[1] - r[0] + 1
 		}
+		targets = append(targets, blameTarget{file: file, path: blamePath(pr, file), lines: changedLines})
+	}
 
-		// Score candidates from overlapping blame results (full weight)
-		for _, blamePR := range overlappingPRs {
-			lineCount := blamePR.LineCount
-			if lineCount == 0 {
-				lineCount = 1
-			}
-
-			// Full weight for overlapping lines
-			if blamePR.Author != "" && !f.client.IsUserBot(ctx, blamePR.Author) {
-				if existing, ok := candidateMap[blamePR.Author]; ok {
-					existing.weight += lineCount
-					existing.sourceScores["blame-author"] += lineCount
-				} else {
-					candidateMap[blamePR.Author] = &candidateWeight{
-						username:     blamePR.Author,
-						weight:       lineCount,
-						sourceScores: map[string]int{"blame-author": lineCount},
-					}
-				}
-			}
-
-			if blamePR.MergedBy != "" && !f.client.IsUserBot(ctx, blamePR.MergedBy) {
-				mergeWeight := lineCount * 2
-				if existing, ok := candidateMap[blamePR.MergedBy]; ok {
-					existing.weight += mergeWeight
-					existing.sourceScores["blame-merger"] += mergeWeight
-				} else {
-					candidateMap[blamePR.MergedBy] = &candidateWeight{
-						username:     blamePR.MergedBy,
-						weight:       mergeWeight,
-						sourceScores: map[string]int{"blame-merger": mergeWeight},
-					}
-				}
-			}
-
-			for _, reviewer := range blamePR.Reviewers {
-				if reviewer != "" && !f.client.IsUserBot(ctx, reviewer) {
-					if existing, ok := candidateMap[reviewer]; ok {
-						existing.weight += lineCount
-						existing.sourceScores["blame-reviewer"] += lineCount
-					} else {
-						candidateMap[reviewer] = &candidateWeight{
-							username:     reviewer,
-							weight:       lineCount,
-							sourceScores: map[string]int{"blame-reviewer": lineCount},
-						}
-					}
-				}
-			}
+	for start := 0; start < len(targets); start += maxBlameBatch {
+		if !budget.spend(reserve) {
+			slog.Info("API budget exhausted, skipping blame for remaining files", "skipped", len(targets)-start, "budget", budget.limit)
+			cov.FilesSkipped += len(targets) - start
+			break
 		}
+		batch := targets[start:min(start+maxBlameBatch, len(targets))]
 
-		// Score candidates from file-level contributions (lower weight - recent file editors)
-		for _, filePR := range filePRs {
-			// Give +5 points for recently touching the file (even if not exact lines)
-			fileWeight := 5
-
-			if filePR.Author != "" && !f.client.IsUserBot(ctx, filePR.Author) {
-				if existing, ok := candidateMap[filePR.Author]; ok {
-					existing.weight += fileWeight
-					existing.sourceScores["file-author"] += fileWeight
-				} else {
-					candidateMap[filePR.Author] = &candidateWeight{
-						username:     filePR.Author,
-						weight:       fileWeight,
-						sourceScores: map[string]int{"file-author": fileWeight},
-					}
-				}
-			}
+		paths := make([]string, len(batch))
+		for i, t := range batch {
+			paths[i] = t.path
+		}
+		slog.Info("Analyzing changed lines with blame", "files", paths)
 
-			if filePR.MergedBy != "" && !f.client.IsUserBot(ctx, filePR.MergedBy) {
-				mergeWeight := fileWeight * 2
-				if existing, ok := candidateMap[filePR.MergedBy]; ok {
-					existing.weight += mergeWeight
-					existing.sourceScores["file-merger"] += mergeWeight
-				} else {
-					candidateMap[filePR.MergedBy] = &candidateWeight{
-						username:     filePR.MergedBy,
-						weight:       mergeWeight,
-						sourceScores: map[string]int{"file-merger": mergeWeight},
-					}
-				}
-			}
+		// Use blame to find PRs that touched these lines (overlapping) and files (non-overlapping)
+		result, err := f.blameFiles(ctx, pr.Owner, pr.Repository, rev, paths)
+		if err != nil {
+			slog.Warn("Failed to get blame", "files", paths, "error", err)
+			cov.FilesSkipped += len(batch)
+			continue
+		}
 
-			for _, reviewer := range filePR.Reviewers {
-				if reviewer != "" && !f.client.IsUserBot(ctx, reviewer) {
-					if existing, ok := candidateMap[reviewer]; ok {
-						existing.weight += fileWeight
-						existing.sourceScores["file-reviewer"] += fileWeight
-					} else {
-						candidateMap[reviewer] = &candidateWeight{
-							username:     reviewer,
-							weight:       fileWeight,
-							sourceScores: map[string]int{"file-reviewer": fileWeight},
-						}
-					}
-				}
+		for i, t := range batch {
+			alias := fmt.Sprintf("f%d", i)
+			if _, ok := blameField(result, alias); !ok {
+				slog.Warn("No blame for file", "file", t.path)
+				cov.FilesSkipped++
+				continue
 			}
+			overlappingPRs, filePRs, blamedLines := f.parseBlameResults(result, alias, t.lines)
+			cov.FilesAnalyzed++
+			cov.BlamedLines += blamedLines
+			f.scoreBlame(ctx, candidateMap, t.file, overlappingPRs, filePRs, w)
 		}
 	}
 
@@ -550,7 +642,97 @@ func (f *Finder) collectWeightedCandidates(ctx context.Context, pr *types.PullRe
 	return candidates
 }
 
-// getChangedLines extracts the line ranges that were modified in a file for this PR.
+// scoreBlame credits the people behind a file's blame results: full line weight for PRs
+// that last touched the changed lines, file weight for other recent PRs to the file.
+// Both decay with the age of the PR.
+func (f *Finder) scoreBlame(ctx context.Context, candidateMap map[string]*candidateWeight, file string, overlappingPRs, filePRs []types.PRInfo, w Weights) {
+	now := f.now()
+
+	// Score candidates from overlapping blame results (full weight)
+	for _, blamePR := range overlappingPRs {
+		lines := blamePR.LineCount
+		if lines == 0 {
+			lines = 1
+		}
+		lineWeight := w.decay(lines*w.BlameLine, blamePR.MergedAt, now)
+		mergerWeight := w.decay(lines*w.BlameLine*w.MergerMultiplier, blamePR.MergedAt, now)
+		evidence := func(source string, score int) types.Evidence {
+			return types.Evidence{Source: source, Path: file, Lines: lines, PR: blamePR.Number, MergedAt: blamePR.MergedAt, Score: score}
+		}
+
+		// Full weight for overlapping lines, decayed by age
+		if blamePR.Author != "" && !f.client.IsUserBot(ctx, blamePR.Author) {
+			addScore(candidateMap, blamePR.Author, "blame-author", lineWeight, evidence("blame-author", lineWeight))
+		}
+
+		if blamePR.MergedBy != "" && !f.client.IsUserBot(ctx, blamePR.MergedBy) {
+			addScore(candidateMap, blamePR.MergedBy, "blame-merger", mergerWeight, evidence("blame-merger", mergerWeight))
+		}
+
+		for _, reviewer := range blamePR.Reviewers {
+			if reviewer != "" && !f.client.IsUserBot(ctx, reviewer) {
+				addScore(candidateMap, reviewer, "blame-reviewer", lineWeight, evidence("blame-reviewer", lineWeight))
+			}
+		}
+	}
+
+	// Score candidates from file-level contributions (lower weight - recent file editors)
+	for _, filePR := range filePRs {
+		// Give points for recently touching the file (even if not exact lines), decayed by age
+		fileWeight := w.decay(w.File, filePR.MergedAt, now)
+		mergerWeight := w.decay(w.File*w.MergerMultiplier, filePR.MergedAt, now)
+		evidence := func(source string, score int) types.Evidence {
+			return types.Evidence{Source: source, Path: file, PR: filePR.Number, MergedAt: filePR.MergedAt, Score: score}
+		}
+
+		if filePR.Author != "" && !f.client.IsUserBot(ctx, filePR.Author) {
+			addScore(candidateMap, filePR.Author, "file-author", fileWeight, evidence("file-author", fileWeight))
+		}
+
+		if filePR.MergedBy != "" && !f.client.IsUserBot(ctx, filePR.MergedBy) {
+			addScore(candidateMap, filePR.MergedBy, "file-merger", mergerWeight, evidence("file-merger", mergerWeight))
+		}
+
+		for _, reviewer := range filePR.Reviewers {
+			if reviewer != "" && !f.client.IsUserBot(ctx, reviewer) {
+				addScore(candidateMap, reviewer, "file-reviewer", fileWeight, evidence("file-reviewer", fileWeight))
+			}
+		}
+	}
+}
+
+// blameRevision returns the revision to blame a PR's changed lines at: the merge base of
+// its base branch and head, which the diff's old-side line numbers refer to. Falls back to
+// the base branch, and to the default branch ("") if the base branch is unknown.
+func (f *Finder) blameRevision(ctx context.Context, pr *types.PullRequest) string {
+	if pr.BaseRef == "" {
+		return ""
+	}
+	if pr.HeadSHA == "" {
+		return pr.BaseRef
+	}
+	sha, err := f.client.MergeBase(ctx, pr.Owner, pr.Repository, pr.BaseRef, pr.HeadSHA)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to find merge base, blaming base branch", "pr", pr.Number, "base", pr.BaseRef, "error", err)
+		return pr.BaseRef
+	}
+	return sha
+}
+
+// blamePath returns a changed file's path at the base revision, which differs for renames.
+func blamePath(pr *types.PullRequest, filename string) string {
+	for _, f := range pr.ChangedFiles {
+		if f.Filename == filename && f.PreviousFilename != "" {
+			return f.PreviousFilename
+		}
+	}
+	return filename
+}
+
+// getChangedLines extracts the lines a PR modifies in a file, numbered as in the base
+// revision so they line up with blame at the merge base. Only removed or replaced lines
+// count; context lines in a hunk do not. Pure insertions map to the line they follow (or
+// precede, at the top of a file), and new files have no lines.
 // Returns array of [startLine, endLine] pairs.
 //
 //nolint:unparam // Error return kept for interface consistency and future extensibility
@@ -568,45 +750,78 @@ func (*Finder) getChangedLines(pr *types.PullRequest, filename string) ([][2]int
 		return nil, nil
 	}
 
-	// Parse the patch to extract changed line numbers
 	var lineRanges [][2]int
-	lines := strings.Split(targetFile.Patch, "\n")
+	var oldLine, oldCount int // Next base line, and the hunk's base line count
+	inHunk, afterRemoval := false, false
 
-	for _, line := range lines {
-		// Look for hunk headers like @@ -10,5 +10,7 @@
+	for _, line := range strings.Split(targetFile.Patch, "\n") {
 		if strings.HasPrefix(line, "@@") {
-			// Extract the new file line range (second set of numbers)
-			parts := strings.Split(line, " ")
-			for i, part := range parts {
-				if !strings.HasPrefix(part, "+") || i == 0 {
-					continue
-				}
-				// Format is +start,count or just +start
-				numPart := strings.TrimPrefix(part, "+")
-
-				var start, count int
-				if strings.Contains(numPart, ",") {
-					if _, err := fmt.Sscanf(numPart, "%d,%d", &start, &count); err != nil {
-						continue
-					}
-				} else {
-					if _, err := fmt.Sscanf(numPart, "%d", &start); err != nil {
-						continue
-					}
-					count = 1
-				}
+			start, count, ok := parseHunkOldRange(line)
+			inHunk, afterRemoval = ok, false
+			oldLine, oldCount = start, count
+			if count == 0 {
+				// A count of 0 is a pure insertion after line start (0 for a new file)
+				oldLine = start + 1
+			}
+			continue
+		}
+		if !inHunk || line == "" {
+			continue
+		}
 
-				if start > 0 {
-					end := start + count - 1
-					if end < start {
-						end = start
-					}
-					lineRanges = append(lineRanges, [2]int{start, end})
-				}
-				break
+		switch line[0] {
+		case ' ':
+			oldLine++
+			afterRemoval = false
+		case '-':
+			lineRanges = addChangedLine(lineRanges, oldLine)
+			oldLine++
+			afterRemoval = true
+		case '+':
+			// Added lines replacing removed ones are already covered by the removal
+			if afterRemoval {
+				continue
+			}
+			switch {
+			case oldLine > 1:
+				lineRanges = addChangedLine(lineRanges, oldLine-1)
+			case oldCount > 0:
+				lineRanges = addChangedLine(lineRanges, oldLine)
 			}
+		default:
+			// "\ No newline at end of file"
 		}
 	}
 
 	return lineRanges, nil
 }
+
+// parseHunkOldRange returns the base revision range of a hunk header like
+// "@@ -10,5 +10,7 @@". A missing count means 1.
+func parseHunkOldRange(header string) (start, count int, ok bool) {
+	parts := strings.Split(header, " ")
+	if len(parts) < 2 || !strings.HasPrefix(parts[1], "-") {
+		return 0, 0, false
+	}
+	numPart := strings.TrimPrefix(parts[1], "-")
+	if strings.Contains(numPart, ",") {
+		if _, err := fmt.Sscanf(numPart, "%d,%d", &start, &count); err != nil {
+			return 0, 0, false
+		}
+		return start, count, true
+	}
+	if _, err := fmt.Sscanf(numPart, "%d", &start); err != nil {
+		return 0, 0, false
+	}
+	return start, 1, true
+}
+
+// addChangedLine adds line to ranges, extending the last range when contiguous.
+// Lines arrive in ascending order.
+func addChangedLine(ranges [][2]int, line int) [][2]int {
+	if n := len(ranges); n > 0 && line <= ranges[n-1][1]+1 {
+		ranges[n-1][1] = max(ranges[n-1][1], line)
+		return ranges
+	}
+	return append(ranges, [2]int{line, line})
+}
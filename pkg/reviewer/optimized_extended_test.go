@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/internal/testutil"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
 )
@@ -12,7 +13,7 @@ import (
 func TestFinder_findReviewersOptimized_NoFiles(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		Owner:        "test-owner",
@@ -23,7 +24,7 @@ func TestFinder_findReviewersOptimized_NoFiles(t *testing.T) {
 		ChangedFiles: []types.ChangedFile{}, // No files changed
 	}
 
-	reviewers := finder.findReviewersOptimized(ctx, pr)
+	reviewers, _ := finder.findReviewersOptimized(ctx, pr, config.Default())
 
 	// Should return empty list if no files and no assignees
 	if len(reviewers) != 0 {
@@ -39,7 +40,7 @@ func TestFinder_findReviewersOptimized_NoFiles(t *testing.T) {
 
 func TestFinder_getChangedLines_MultipleSections(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		ChangedFiles: []types.ChangedFile{
@@ -76,7 +77,7 @@ func TestFinder_getChangedLines_MultipleSections(t *testing.T) {
 
 func TestFinder_getChangedLines_NoPatch(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		ChangedFiles: []types.ChangedFile{
@@ -102,7 +103,7 @@ func TestFinder_getChangedLines_NoPatch(t *testing.T) {
 
 func TestFinder_parseDirectoryCommitsFromGraphQL_HappyPath(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	result := map[string]any{
 		"data": map[string]any{
@@ -174,7 +175,7 @@ func TestFinder_parseDirectoryCommitsFromGraphQL_HappyPath(t *testing.T) {
 
 func TestFinder_parseProjectPRsFromGraphQL_HappyPath(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	result := map[string]any{
 		"data": map[string]any{
@@ -236,7 +237,7 @@ func TestFinder_parseProjectPRsFromGraphQL_HappyPath(t *testing.T) {
 
 func TestFinder_parseProjectPRsFromGraphQL_EmptyData(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	result := map[string]any{}
 
@@ -248,7 +249,7 @@ func TestFinder_parseProjectPRsFromGraphQL_EmptyData(t *testing.T) {
 
 func TestFinder_parseProjectPRsFromGraphQL_NoNodes(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	result := map[string]any{
 		"data": map[string]any{
@@ -272,7 +273,7 @@ func TestFinder_parseProjectPRsFromGraphQL_NoNodes(t *testing.T) {
 
 func TestFinder_parseDirectoryCommitsFromGraphQL_NoData(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	result := map[string]any{}
 
@@ -284,7 +285,7 @@ func TestFinder_parseDirectoryCommitsFromGraphQL_NoData(t *testing.T) {
 
 func TestFinder_parseDirectoryCommitsFromGraphQL_NoDefaultBranchRef(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	result := map[string]any{
 		"data": map[string]any{
@@ -300,7 +301,7 @@ func TestFinder_parseDirectoryCommitsFromGraphQL_NoDefaultBranchRef(t *testing.T
 
 func TestFinder_parseDirectoryCommitsFromGraphQL_DirectCommit(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	// Simulate a direct commit without a PR
 	result := map[string]any{
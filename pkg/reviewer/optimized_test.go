@@ -2,17 +2,21 @@ package reviewer
 
 import (
 	"context"
+	"fmt"
 	"reflect"
 	"testing"
 	"time"
 
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/availability"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/github"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/internal/testutil"
 	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
 )
 
 func TestFinder_topChangedFilesFiltered(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	tests := []struct {
 		name          string
@@ -83,7 +87,7 @@ func TestFinder_topChangedFilesFiltered(t *testing.T) {
 				ChangedFiles: tt.changedFiles,
 			}
 
-			result := finder.topChangedFilesFiltered(pr, tt.n)
+			result := finder.topChangedFilesFiltered(pr, tt.n, nil, nil)
 
 			if !reflect.DeepEqual(result, tt.expectedFiles) {
 				t.Errorf("topChangedFilesFiltered() = %v, want %v", result, tt.expectedFiles)
@@ -94,7 +98,7 @@ func TestFinder_topChangedFilesFiltered(t *testing.T) {
 
 func TestFinder_getChangedLines(t *testing.T) {
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	tests := []struct {
 		name          string
@@ -104,7 +108,7 @@ func TestFinder_getChangedLines(t *testing.T) {
 		expectError   bool
 	}{
 		{
-			name: "simple hunk with single line range",
+			name: "insertion between context lines maps to the preceding line",
 			changedFiles: []types.ChangedFile{
 				{
 					Filename: "test.go",
@@ -115,7 +119,7 @@ func TestFinder_getChangedLines(t *testing.T) {
 				},
 			},
 			filename:      "test.go",
-			expectedLines: [][2]int{{10, 16}},
+			expectedLines: [][2]int{{10, 10}},
 		},
 		{
 			name: "multiple hunks",
@@ -132,7 +136,7 @@ func TestFinder_getChangedLines(t *testing.T) {
 				},
 			},
 			filename:      "test.go",
-			expectedLines: [][2]int{{10, 13}, {51, 53}},
+			expectedLines: [][2]int{{10, 10}, {50, 50}},
 		},
 		{
 			name: "hunk with single line (no count)",
@@ -140,7 +144,8 @@ func TestFinder_getChangedLines(t *testing.T) {
 				{
 					Filename: "test.go",
 					Patch: `@@ -10 +10 @@ func main() {
-+added line`,
+-old line
++new line`,
 				},
 			},
 			filename:      "test.go",
@@ -169,16 +174,86 @@ func TestFinder_getChangedLines(t *testing.T) {
 			expectedLines: nil,
 		},
 		{
-			name: "large line range",
+			name: "context lines are not changed",
+			changedFiles: []types.ChangedFile{
+				{
+					Filename: "test.go",
+					Patch: `@@ -100,10 +100,11 @@ func bigFunction() {
+ line 100
+ line 101
+ line 102
+-line 103
+-line 104
++replacement
++replacement
+ line 105
+ line 106
++added line
+ line 107
+ line 108
+ line 109`,
+				},
+			},
+			filename:      "test.go",
+			expectedLines: [][2]int{{103, 104}, {106, 106}},
+		},
+		{
+			name: "insertion at the top of a file maps to the following line",
 			changedFiles: []types.ChangedFile{
 				{
 					Filename: "test.go",
-					Patch: `@@ -100,50 +100,75 @@ func bigFunction() {
- many lines of code here`,
+					Patch: `@@ -1,2 +1,3 @@
++// Package main is a test.
+ package main
+ `,
 				},
 			},
 			filename:      "test.go",
-			expectedLines: [][2]int{{100, 174}},
+			expectedLines: [][2]int{{1, 1}},
+		},
+		{
+			name: "pure insertion maps to the preceding base line",
+			changedFiles: []types.ChangedFile{
+				{
+					Filename: "test.go",
+					Patch: `@@ -20,0 +21,3 @@ func main() {
++added line
++added line
++added line`,
+				},
+			},
+			filename:      "test.go",
+			expectedLines: [][2]int{{20, 20}},
+		},
+		{
+			name: "new file has no base lines",
+			changedFiles: []types.ChangedFile{
+				{
+					Filename: "test.go",
+					Patch: `@@ -0,0 +1,2 @@
++package main
++`,
+				},
+			},
+			filename:      "test.go",
+			expectedLines: nil,
+		},
+		{
+			name: "deletion uses base line numbers",
+			changedFiles: []types.ChangedFile{
+				{
+					Filename: "test.go",
+					Patch: `@@ -40,6 +40,2 @@ func main() {
+ line 1
+-removed line
+-removed line
+-removed line
+-removed line
+ line 2`,
+				},
+			},
+			filename:      "test.go",
+			expectedLines: [][2]int{{41, 44}},
 		},
 	}
 
@@ -211,7 +286,7 @@ func TestFinder_getChangedLines(t *testing.T) {
 func TestFinder_findReviewersOptimized_WithAssignees(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		Owner:      "test-owner",
@@ -236,7 +311,7 @@ func TestFinder_findReviewersOptimized_WithAssignees(t *testing.T) {
 	client.SetBotUser("bob", false)
 	client.SetBotUser("charlie", false)
 
-	candidates := finder.findReviewersOptimized(ctx, pr)
+	candidates, _ := finder.findReviewersOptimized(ctx, pr, config.Default())
 
 	if len(candidates) < 2 {
 		t.Fatalf("expected at least 2 candidates, got %d", len(candidates))
@@ -259,7 +334,7 @@ func TestFinder_findReviewersOptimized_WithAssignees(t *testing.T) {
 func TestFinder_findReviewersOptimized_SkipAuthorAssignee(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		Owner:      "test-owner",
@@ -283,7 +358,7 @@ func TestFinder_findReviewersOptimized_SkipAuthorAssignee(t *testing.T) {
 	client.SetBotUser("alice", false)
 	client.SetBotUser("bob", false)
 
-	candidates := finder.findReviewersOptimized(ctx, pr)
+	candidates, _ := finder.findReviewersOptimized(ctx, pr, config.Default())
 
 	// Alice should not be in candidates even though she's an assignee
 	for _, c := range candidates {
@@ -296,7 +371,7 @@ func TestFinder_findReviewersOptimized_SkipAuthorAssignee(t *testing.T) {
 func TestFinder_findReviewersOptimized_NoChangedFiles(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		Owner:        "test-owner",
@@ -306,7 +381,7 @@ func TestFinder_findReviewersOptimized_NoChangedFiles(t *testing.T) {
 		ChangedFiles: []types.ChangedFile{}, // No files changed
 	}
 
-	candidates := finder.findReviewersOptimized(ctx, pr)
+	candidates, _ := finder.findReviewersOptimized(ctx, pr, config.Default())
 
 	// Should return empty slice, not nil
 	// Empty result is expected when there are no changed files and no other signals
@@ -318,7 +393,7 @@ func TestFinder_findReviewersOptimized_NoChangedFiles(t *testing.T) {
 func TestFinder_findReviewersOptimized_WorkloadPenalty(t *testing.T) {
 	ctx := context.Background()
 	client := testutil.NewMockGitHubClient()
-	finder := New(client, Config{PRCountCache: time.Hour})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
 
 	pr := &types.PullRequest{
 		Owner:      "test-owner",
@@ -343,7 +418,7 @@ func TestFinder_findReviewersOptimized_WorkloadPenalty(t *testing.T) {
 	client.SetBotUser("bob", false)
 	client.SetBotUser("charlie", false)
 
-	candidates := finder.findReviewersOptimized(ctx, pr)
+	candidates, _ := finder.findReviewersOptimized(ctx, pr, config.Default())
 
 	if len(candidates) < 2 {
 		t.Fatalf("expected at least 2 candidates, got %d", len(candidates))
@@ -374,3 +449,312 @@ func TestFinder_findReviewersOptimized_WorkloadPenalty(t *testing.T) {
 		t.Error("charlie should have non-negative context score")
 	}
 }
+
+func TestFinder_topChangedFilesFiltered_IgnorePaths(t *testing.T) {
+	finder := newTestFinder(t, testutil.NewMockGitHubClient(), Config{PRCountCache: time.Hour})
+	pr := &types.PullRequest{
+		ChangedFiles: []types.ChangedFile{
+			{Filename: "vendor/lib/x.go", Additions: 500},
+			{Filename: "api/service.pb.go", Additions: 300},
+			{Filename: "server/handler.go", Additions: 20},
+			{Filename: "server/handler_test.go", Additions: 10},
+		},
+	}
+
+	got := finder.topChangedFilesFiltered(pr, 3, []string{"vendor/", "*.pb.go"}, nil)
+	want := []string{"server/handler.go", "server/handler_test.go"}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("topChangedFilesFiltered() = %v, want %v", got, want)
+	}
+}
+
+func TestFinder_findReviewersOptimized_Config(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	pr := &types.PullRequest{
+		Owner:        "test-owner",
+		Repository:   "test-repo",
+		Number:       1,
+		Author:       "alice",
+		Assignees:    []string{"bob", "charlie"},
+		ChangedFiles: []types.ChangedFile{{Filename: "main.go", Additions: 10}},
+	}
+	client.SetWriteAccess("test-owner", "test-repo", "bob", true)
+	client.SetWriteAccess("test-owner", "test-repo", "charlie", true)
+
+	cfg := config.Default()
+	cfg.ExcludeUsers = []string{"charlie"}
+	assigneeWeight := 40
+	cfg.Weights.Assignee = &assigneeWeight
+
+	candidates, _ := finder.findReviewersOptimized(ctx, pr, cfg)
+	if len(candidates) != 1 || candidates[0].Username != "bob" {
+		t.Fatalf("expected only bob after exclusion, got %+v", candidates)
+	}
+	if candidates[0].ContextScore != assigneeWeight {
+		t.Errorf("expected configured assignee weight %d, got %d", assigneeWeight, candidates[0].ContextScore)
+	}
+}
+
+func TestFinder_findReviewersOptimized_Breakdown(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("test-owner", "test-repo", "CODEOWNERS", "/pkg/ @bob\n")
+	client.SetWriteAccess("test-owner", "test-repo", "bob", true)
+	client.SetBatchOpenPRCount("test-owner", map[string]int{"bob": 2})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	pr := &types.PullRequest{
+		Owner:      "test-owner",
+		Repository: "test-repo",
+		Number:     1,
+		Author:     "alice",
+		Assignees:  []string{"bob"},
+		ChangedFiles: []types.ChangedFile{
+			{Filename: "pkg/a.go", Additions: 3},
+			{Filename: "pkg/b.go", Additions: 1},
+		},
+	}
+
+	candidates, _ := finder.findReviewersOptimized(ctx, pr, config.Default())
+	if len(candidates) != 1 {
+		t.Fatalf("expected 1 candidate, got %+v", candidates)
+	}
+	b := candidates[0].Breakdown
+	if b == nil {
+		t.Fatal("expected a score breakdown")
+	}
+
+	wantSources := map[string]int{"assignee": 200, "codeowner": 20}
+	if !reflect.DeepEqual(b.Sources, wantSources) {
+		t.Errorf("Sources = %v, want %v", b.Sources, wantSources)
+	}
+	if b.Expertise != 220 || b.OpenPRs != 2 || b.WorkloadPenalty != 20 {
+		t.Errorf("unexpected totals: %+v", b)
+	}
+	if candidates[0].ContextScore != b.Expertise-b.WorkloadPenalty {
+		t.Errorf("ContextScore %d should equal expertise minus penalty", candidates[0].ContextScore)
+	}
+	wantEvidence := []types.Evidence{
+		{Source: "codeowner", Path: "pkg/a.go", Score: 10},
+		{Source: "codeowner", Path: "pkg/b.go", Score: 10},
+	}
+	if !reflect.DeepEqual(b.Evidence, wantEvidence) {
+		t.Errorf("Evidence = %+v, want %+v", b.Evidence, wantEvidence)
+	}
+}
+
+func TestCandidateWeight_breakdown_KeepsTopEvidence(t *testing.T) {
+	candidates := make(map[string]*candidateWeight)
+
+	// A codeowner of 24 changed files, of which only the first maxCodeownerFiles score
+	var owned []types.Evidence
+	for i := range 24 {
+		e := types.Evidence{Source: "codeowner", Path: fmt.Sprintf("pkg/f%d.go", i)}
+		if i < maxCodeownerFiles {
+			e.Score = 10
+		}
+		owned = append(owned, e)
+	}
+	addScore(candidates, "carol", "codeowner", 50, owned...)
+	blame := types.Evidence{Source: "blame-author", Path: "pkg/f20.go", Lines: 3, Score: 3}
+	addScore(candidates, "carol", "blame-author", 3, blame)
+
+	evidence := candidates["carol"].breakdown().Evidence
+	if len(evidence) != maxEvidencePerCandidate {
+		t.Fatalf("got %d evidence entries, want %d", len(evidence), maxEvidencePerCandidate)
+	}
+	if !reflect.DeepEqual(evidence[maxCodeownerFiles], blame) {
+		t.Errorf("evidence[%d] = %+v, want the blame hit after the scoring codeowner entries", maxCodeownerFiles, evidence[maxCodeownerFiles])
+	}
+	for i, e := range evidence[:maxCodeownerFiles] {
+		if e.Score != 10 || e.Path != fmt.Sprintf("pkg/f%d.go", i) {
+			t.Errorf("evidence[%d] = %+v, want scoring codeowner entries first, in order", i, e)
+		}
+	}
+}
+
+func TestFinder_findReviewersOptimized_Availability(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("test-owner", "test-repo", "CODEOWNERS", "/pkg/ @carol\n")
+	client.SetWriteAccess("test-owner", "test-repo", "bob", true)
+	client.SetWriteAccess("test-owner", "test-repo", "carol", true)
+	client.SetGraphQLResponse("query UserStatuses($u0: String!, $u1: String!) {\n"+
+		"\tu0: user(login: $u0) { status { indicatesLimitedAvailability message emoji expiresAt } }\n"+
+		"\tu1: user(login: $u1) { status { indicatesLimitedAvailability message emoji expiresAt } }\n}",
+		map[string]any{"data": map[string]any{
+			"u0": map[string]any{"status": map[string]any{"indicatesLimitedAvailability": true}},
+			"u1": map[string]any{"status": nil},
+		}})
+
+	cal, err := availability.ParseYAML([]byte("- user: carol\n  start: 2000-01-01\n  end: 2999-12-31\n  reason: sabbatical\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	finder := newTestFinder(t, client, Config{
+		PRCountCache: time.Hour,
+		Availability: availability.New(client, availability.Config{Calendar: cal}),
+	})
+
+	pr := &types.PullRequest{
+		Owner:        "test-owner",
+		Repository:   "test-repo",
+		Author:       "alice",
+		Assignees:    []string{"bob"},
+		ChangedFiles: []types.ChangedFile{{Filename: "pkg/a.go", Additions: 3}},
+	}
+
+	candidates, _ := finder.findReviewersOptimized(ctx, pr, config.Default())
+	if len(candidates) != 1 || candidates[0].Username != "bob" {
+		t.Fatalf("expected out-of-office carol to be excluded, got %+v", candidates)
+	}
+	b := candidates[0].Breakdown
+	if b.Availability != "GitHub status: busy" || b.AvailabilityPenalty != 100 {
+		t.Errorf("expected busy penalty of half the expertise, got %q -%d", b.Availability, b.AvailabilityPenalty)
+	}
+	if candidates[0].ContextScore != 100 {
+		t.Errorf("expected score 100, got %d", candidates[0].ContextScore)
+	}
+	if got, want := candidates[0].SelectionMethod, "assignee:+200, availability:-100"; got != want {
+		t.Errorf("SelectionMethod = %q, want %q", got, want)
+	}
+}
+
+func TestFinder_findReviewersOptimized_APIBudget(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	client.SetWriteAccess("test-owner", "test-repo", "bob", true)
+
+	// Seven modified files: the first blame batch covers five, the second is over budget
+	var files []types.ChangedFile
+	for i := range 7 {
+		files = append(files, types.ChangedFile{
+			Filename:  fmt.Sprintf("pkg/f%d.go", i),
+			Status:    "modified",
+			Additions: 10 - i,
+			Patch:     "@@ -1,4 +1,5 @@\n line\n+added",
+		})
+	}
+	target := map[string]any{
+		"f0": map[string]any{
+			"ranges": []any{
+				map[string]any{
+					"startingLine": float64(1),
+					"endingLine":   float64(2),
+					"commit": map[string]any{
+						"author":                 map[string]any{"user": map[string]any{"login": "bob"}},
+						"associatedPullRequests": map[string]any{"nodes": []any{}},
+					},
+				},
+			},
+		},
+	}
+	for i := 1; i < maxBlameBatch; i++ {
+		target[fmt.Sprintf("f%d", i)] = map[string]any{"ranges": []any{}}
+	}
+	client.SetGraphQLResponse(blameBatchQuery(false, maxBlameBatch), map[string]any{
+		"data": map[string]any{"repository": map[string]any{"defaultBranchRef": map[string]any{"target": target}}},
+	})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	pr := &types.PullRequest{
+		Owner:        "test-owner",
+		Repository:   "test-repo",
+		Number:       1,
+		Author:       "alice",
+		ChangedFiles: files,
+	}
+	cfg := config.Default()
+	cfg.APIBudget = 1
+
+	candidates, coverage := finder.findReviewersOptimized(ctx, pr, cfg)
+	if len(candidates) != 1 || candidates[0].Username != "bob" {
+		t.Fatalf("expected bob from blame, got %+v", candidates)
+	}
+
+	want := types.Coverage{
+		FilesAnalyzed: 5,
+		FilesSkipped:  2,
+		ChangedLines:  7, // Each insertion maps to line 1
+		BlamedLines:   1,
+		APIRequests:   1,
+		APIBudget:     1,
+	}
+	if *coverage != want {
+		t.Errorf("coverage = %+v, want %+v", *coverage, want)
+	}
+}
+
+func TestFinder_findReviewersOptimized_PartialBlame(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	client.SetWriteAccess("test-owner", "test-repo", "bob", true)
+
+	// The second file cannot be blamed, which fails its alias but not the first file's
+	client.SetError("MakeGraphQLRequest:"+blameBatchQuery(false, 2), &github.GraphQLErrors{
+		Errors: []any{map[string]any{"path": []any{"repository", "defaultBranchRef", "target", "f1"}, "message": "timeout"}},
+		Response: map[string]any{"data": map[string]any{"repository": map[string]any{"defaultBranchRef": map[string]any{"target": map[string]any{
+			"f0": map[string]any{"ranges": []any{map[string]any{
+				"startingLine": float64(1),
+				"endingLine":   float64(2),
+				"commit": map[string]any{
+					"author":                 map[string]any{"user": map[string]any{"login": "bob"}},
+					"associatedPullRequests": map[string]any{"nodes": []any{}},
+				},
+			}}},
+			"f1": nil,
+		}}}}},
+	})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	pr := &types.PullRequest{
+		Owner:      "test-owner",
+		Repository: "test-repo",
+		Number:     1,
+		Author:     "alice",
+		ChangedFiles: []types.ChangedFile{
+			{Filename: "a.go", Status: "modified", Additions: 2, Patch: "@@ -1,1 +1,2 @@\n line\n+added"},
+			{Filename: "huge.go", Status: "modified", Additions: 1, Patch: "@@ -1,1 +1,2 @@\n line\n+added"},
+		},
+	}
+
+	candidates, coverage := finder.findReviewersOptimized(ctx, pr, config.Default())
+	if len(candidates) != 1 || candidates[0].Username != "bob" {
+		t.Fatalf("expected bob from the file that was blamed, got %+v", candidates)
+	}
+	if coverage.FilesAnalyzed != 1 || coverage.FilesSkipped != 1 {
+		t.Errorf("coverage = %+v, want 1 file analyzed and 1 skipped", *coverage)
+	}
+}
+
+func TestFinder_findReviewersOptimized_CachedDirectoryHistoryIsFree(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	client.SetWriteAccess("test-owner", "test-repo", "carol", true)
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	// Directory history cached by an earlier PR; blame uses up the whole budget
+	finder.cache.Set(directoryCommitsKey("test-owner", "test-repo", "pkg"), []types.PRInfo{{Number: 3, Author: "carol"}})
+	pr := &types.PullRequest{
+		Owner:      "test-owner",
+		Repository: "test-repo",
+		Number:     1,
+		Author:     "alice",
+		ChangedFiles: []types.ChangedFile{
+			{Filename: "pkg/a.go", Status: "modified", Additions: 1, Patch: "@@ -1,1 +1,2 @@\n line\n+added"},
+		},
+	}
+	cfg := config.Default()
+	cfg.APIBudget = 1
+
+	candidates, coverage := finder.findReviewersOptimized(ctx, pr, cfg)
+	if coverage.DirectoriesAnalyzed != 1 || coverage.APIRequests != 1 {
+		t.Errorf("coverage = %+v, want the cached directory analyzed within 1 request", *coverage)
+	}
+	if len(candidates) != 1 || candidates[0].Username != "carol" {
+		t.Errorf("expected carol from directory history, got %+v", candidates)
+	}
+}
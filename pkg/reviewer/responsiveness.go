@@ -0,0 +1,60 @@
+package reviewer
+
+import (
+	"context"
+	"log/slog"
+	"time"
+)
+
+// Responsiveness model parameters.
+const (
+	responsivenessTarget = 24 * time.Hour // Median first-review time that earns neither bonus nor penalty
+	minResponseSamples   = 3              // Review requests needed before responsiveness counts
+)
+
+// applyResponsiveness adjusts the scores of candidates by how quickly they have historically
+// responded to review requests in the org. See responsivenessAdjustment.
+func (f *Finder) applyResponsiveness(ctx context.Context, org string, candidates []candidateWeight, w Weights) {
+	if w.Responsiveness == 0 || len(candidates) == 0 {
+		return
+	}
+
+	usernames := make([]string, len(candidates))
+	for i, c := range candidates {
+		usernames[i] = c.username
+	}
+	times, err := f.client.ReviewResponseTimes(ctx, org, usernames)
+	if err != nil {
+		slog.WarnContext(ctx, "Failed to fetch review response times, continuing without responsiveness", "error", err)
+		return
+	}
+
+	for i := range candidates {
+		c := &candidates[i]
+		rt := times[c.username]
+		if rt.Samples < minResponseSamples {
+			continue
+		}
+		c.responseTime = rt.Median
+		c.responsiveness = responsivenessAdjustment(rt.Median, c.weight, w)
+		c.finalScore += c.responsiveness
+		slog.InfoContext(ctx, "Applied responsiveness adjustment",
+			"username", c.username, "median_first_review", rt.Median.Round(time.Minute), "samples", rt.Samples,
+			"adjustment", c.responsiveness, "final_score", c.finalScore)
+	}
+}
+
+// responsivenessAdjustment returns the bonus (positive) or penalty (negative) for a reviewer
+// whose median time to first review is median: up to Responsiveness percent of their
+// expertise, scaling linearly from the full bonus for immediate reviews, through zero at
+// responsivenessTarget, to the full penalty at twice the target or slower.
+func responsivenessAdjustment(median time.Duration, expertise int, w Weights) int {
+	share := 1 - float64(median)/float64(responsivenessTarget)
+	share = max(-1, min(1, share))
+	return int(share * float64(expertise*w.Responsiveness) / 100)
+}
+
+// medianResponseHours converts a median response time for the score breakdown.
+func medianResponseHours(d time.Duration) float64 {
+	return float64(d.Round(6*time.Minute)) / float64(time.Hour)
+}
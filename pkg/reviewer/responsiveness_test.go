@@ -0,0 +1,92 @@
+package reviewer
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/internal/testutil"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+func TestResponsivenessAdjustment(t *testing.T) {
+	w := DefaultWeights()
+	tests := []struct {
+		name   string
+		median time.Duration
+		want   int
+	}{
+		{"immediate", 0, 20},
+		{"fast", 6 * time.Hour, 15},
+		{"at target", 24 * time.Hour, 0},
+		{"slow", 36 * time.Hour, -10},
+		{"twice the target", 48 * time.Hour, -20},
+		{"capped", 30 * 24 * time.Hour, -20},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := responsivenessAdjustment(tt.median, 100, w); got != tt.want {
+				t.Errorf("responsivenessAdjustment(%v, 100) = %d, want %d", tt.median, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinder_applyResponsiveness(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	client.SetReviewResponseTimes("org", map[string]types.ResponseTime{
+		"fast":     {Median: 2 * time.Hour, Samples: 10},
+		"slow":     {Median: 72 * time.Hour, Samples: 5},
+		"too-few":  {Median: time.Hour, Samples: minResponseSamples - 1},
+		"no-data":  {},
+		"unlisted": {Median: time.Hour, Samples: 10},
+	})
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	newCandidates := func() []candidateWeight {
+		var cs []candidateWeight
+		for _, name := range []string{"fast", "slow", "too-few", "no-data"} {
+			cs = append(cs, candidateWeight{username: name, weight: 100, finalScore: 100})
+		}
+		return cs
+	}
+	want := map[string]int{"fast": 118, "slow": 80, "too-few": 100, "no-data": 100}
+
+	t.Run("adjusts scores with enough samples", func(t *testing.T) {
+		candidates := newCandidates()
+		finder.applyResponsiveness(context.Background(), "org", candidates, DefaultWeights())
+		for _, c := range candidates {
+			if c.finalScore != want[c.username] {
+				t.Errorf("%s final score = %d, want %d", c.username, c.finalScore, want[c.username])
+			}
+		}
+		if candidates[0].responseTime != 2*time.Hour {
+			t.Errorf("expected response time recorded for breakdown, got %v", candidates[0].responseTime)
+		}
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		candidates := newCandidates()
+		w := DefaultWeights()
+		w.Responsiveness = 0
+		finder.applyResponsiveness(context.Background(), "org", candidates, w)
+		for _, c := range candidates {
+			if c.finalScore != 100 {
+				t.Errorf("%s final score = %d, want unchanged 100", c.username, c.finalScore)
+			}
+		}
+	})
+
+	t.Run("fetch errors leave scores alone", func(t *testing.T) {
+		client.SetError("ReviewResponseTimes:org", errors.New("rate limited"))
+		defer client.SetError("ReviewResponseTimes:org", nil)
+		candidates := newCandidates()
+		finder.applyResponsiveness(context.Background(), "org", candidates, DefaultWeights())
+		for _, c := range candidates {
+			if c.finalScore != 100 {
+				t.Errorf("%s final score = %d, want unchanged 100", c.username, c.finalScore)
+			}
+		}
+	})
+}
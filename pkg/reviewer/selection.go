@@ -0,0 +1,136 @@
+package reviewer
+
+import (
+	"path/filepath"
+	"slices"
+	"sort"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+// Assignment is a reviewer picked by SelectCovering and the changed files they were picked for.
+type Assignment struct {
+	Username string   `json:"username"`
+	Files    []string `json:"files,omitempty"` // Files no earlier pick had history in, sorted (empty for picks filling remaining slots)
+}
+
+// SelectCovering picks up to n reviewers from candidates so that together they know as much
+// of the change as possible: a greedy weighted set cover over each candidate's FileScores.
+// Each round picks the candidate whose history in not-yet-covered files scores highest,
+// scaled by their workload and availability penalties, so a cross-cutting PR gets one
+// expert per area rather than two people who know the same file. Ties go to the
+// earlier candidate, and once nobody adds coverage the remaining slots are filled in
+// candidate order. Candidates must be sorted best first.
+func SelectCovering(candidates []types.ReviewerCandidate, n int) []Assignment {
+	covered := make(map[string]bool)
+	picked := make([]bool, len(candidates))
+	var result []Assignment
+
+	for len(result) < min(n, len(candidates)) {
+		best, bestGain := -1, 0
+		for i, c := range candidates {
+			if picked[i] {
+				continue
+			}
+			if gain := coverageGain(c, covered); gain > bestGain {
+				best, bestGain = i, gain
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		picked[best] = true
+		var files []string
+		for file, score := range candidates[best].FileScores {
+			if score > 0 && !covered[file] {
+				covered[file] = true
+				files = append(files, file)
+			}
+		}
+		sort.Strings(files)
+		result = append(result, Assignment{Username: candidates[best].Username, Files: files})
+	}
+
+	for i, c := range candidates {
+		if len(result) >= n {
+			break
+		}
+		if !picked[i] {
+			result = append(result, Assignment{Username: c.Username})
+		}
+	}
+	return result
+}
+
+// coverageGain returns the candidate's score in files not yet covered, scaled by the share
+// of their expertise left after penalties.
+func coverageGain(c types.ReviewerCandidate, covered map[string]bool) int {
+	gain := 0
+	for file, score := range c.FileScores {
+		if !covered[file] {
+			gain += score
+		}
+	}
+	if c.Breakdown != nil && c.Breakdown.Expertise > 0 {
+		gain = gain * max(c.ContextScore, 0) / c.Breakdown.Expertise
+	}
+	return gain
+}
+
+// orderBySelection moves the selected reviewers to the front of candidates, in selection order.
+func orderBySelection(candidates []types.ReviewerCandidate, selection []Assignment) []types.ReviewerCandidate {
+	ordered := make([]types.ReviewerCandidate, 0, len(candidates))
+	for _, a := range selection {
+		i := slices.IndexFunc(candidates, func(c types.ReviewerCandidate) bool { return c.Username == a.Username })
+		if i >= 0 {
+			ordered = append(ordered, candidates[i])
+		}
+	}
+	for _, c := range candidates {
+		if !slices.ContainsFunc(selection, func(a Assignment) bool { return a.Username == c.Username }) {
+			ordered = append(ordered, c)
+		}
+	}
+	return ordered
+}
+
+// fileScores returns the score each of the changed files' history contributed to the
+// candidate. Directory history is credited to every changed file directly in the directory,
+// and ownership counts for every owned file, even beyond the scoring cap.
+func (c *candidateWeight) fileScores(files []string, w Weights) map[string]int {
+	changed := make(map[string]bool, len(files))
+	for _, file := range files {
+		changed[file] = true
+	}
+
+	scores := make(map[string]int)
+	for _, e := range c.evidence {
+		switch e.Source {
+		case "codeowner":
+			if changed[e.Path] {
+				scores[e.Path] += w.Codeowner
+			}
+		case "dir-author", "dir-reviewer", "dir-merger":
+			for _, file := range files {
+				if filepath.Dir(file) == e.Path {
+					scores[file] += e.Score
+				}
+			}
+		default:
+			if changed[e.Path] {
+				scores[e.Path] += e.Score
+			}
+		}
+	}
+
+	for file, score := range scores {
+		if score <= 0 {
+			delete(scores, file)
+		}
+	}
+	if len(scores) == 0 {
+		return nil
+	}
+	return scores
+}
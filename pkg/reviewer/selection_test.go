@@ -0,0 +1,114 @@
+package reviewer
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+func TestSelectCovering(t *testing.T) {
+	// alice and bob know the same file; carol is the only one who knows the API package
+	candidates := []types.ReviewerCandidate{
+		{Username: "alice", ContextScore: 60, FileScores: map[string]int{"db/store.go": 50, "db/query.go": 10}},
+		{Username: "bob", ContextScore: 55, FileScores: map[string]int{"db/store.go": 55}},
+		{Username: "carol", ContextScore: 30, FileScores: map[string]int{"api/handler.go": 30}},
+		{Username: "dave", ContextScore: 5},
+	}
+
+	tests := []struct {
+		name string
+		want []Assignment
+		n    int
+	}{
+		{
+			name: "one expert per area",
+			n:    2,
+			want: []Assignment{
+				{Username: "alice", Files: []string{"db/query.go", "db/store.go"}},
+				{Username: "carol", Files: []string{"api/handler.go"}},
+			},
+		},
+		{
+			name: "remaining slots filled in candidate order",
+			n:    4,
+			want: []Assignment{
+				{Username: "alice", Files: []string{"db/query.go", "db/store.go"}},
+				{Username: "carol", Files: []string{"api/handler.go"}},
+				{Username: "bob"},
+				{Username: "dave"},
+			},
+		},
+		{
+			name: "more slots than candidates",
+			n:    10,
+			want: []Assignment{
+				{Username: "alice", Files: []string{"db/query.go", "db/store.go"}},
+				{Username: "carol", Files: []string{"api/handler.go"}},
+				{Username: "bob"},
+				{Username: "dave"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SelectCovering(candidates, tt.n); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("SelectCovering() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectCovering_WorkloadPenalty(t *testing.T) {
+	// Both know the file equally, but alice's score is halved by her open PRs
+	candidates := []types.ReviewerCandidate{
+		{Username: "alice", ContextScore: 20, FileScores: map[string]int{"a.go": 40},
+			Breakdown: &types.ScoreBreakdown{Expertise: 40, WorkloadPenalty: 20}},
+		{Username: "bob", ContextScore: 35, FileScores: map[string]int{"a.go": 40},
+			Breakdown: &types.ScoreBreakdown{Expertise: 40, WorkloadPenalty: 5}},
+	}
+	got := SelectCovering(candidates, 1)
+	if len(got) != 1 || got[0].Username != "bob" {
+		t.Errorf("expected the less loaded bob to be picked, got %+v", got)
+	}
+}
+
+func TestOrderBySelection(t *testing.T) {
+	candidates := []types.ReviewerCandidate{{Username: "alice"}, {Username: "bob"}, {Username: "carol"}}
+	ordered := orderBySelection(candidates, []Assignment{{Username: "carol"}, {Username: "alice"}})
+
+	var got []string
+	for _, c := range ordered {
+		got = append(got, c.Username)
+	}
+	if want := []string{"carol", "alice", "bob"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("orderBySelection() = %v, want %v", got, want)
+	}
+}
+
+func TestCandidateWeight_fileScores(t *testing.T) {
+	w := DefaultWeights()
+	c := &candidateWeight{evidence: []types.Evidence{
+		{Source: "blame-author", Path: "pkg/a.go", Lines: 4, Score: 4},
+		{Source: "blame-merger", Path: "pkg/a.go", Lines: 2, Score: 3},
+		{Source: "file-reviewer", Path: "pkg/b.go", Score: 5},
+		{Source: "dir-merger", Path: "cmd", Score: 6},
+		{Source: "codeowner", Path: "pkg/b.go"}, // Beyond the scoring cap, still counts for coverage
+		{Source: "codeowner", Path: "vendor/ignored.go", Score: 10},
+	}}
+
+	got := c.fileScores([]string{"pkg/a.go", "pkg/b.go", "cmd/main.go", "cmd/sub/x.go"}, w)
+	want := map[string]int{
+		"pkg/a.go":    7,
+		"pkg/b.go":    5 + w.Codeowner,
+		"cmd/main.go": 6,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("fileScores() = %v, want %v", got, want)
+	}
+
+	if got := (&candidateWeight{}).fileScores([]string{"a.go"}, w); got != nil {
+		t.Errorf("expected nil file scores without evidence, got %v", got)
+	}
+}
@@ -0,0 +1,109 @@
+package reviewer
+
+import (
+	"fmt"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+// SkipReason returns why reviewers should not be requested for a pull request,
+// or "" if they may be. Drafts are not ready for review, and PRs that already
+// have reviewers or team review requests are left to their authors.
+func SkipReason(pr *types.PullRequest) string {
+	switch {
+	case pr.Draft:
+		return "draft"
+	case len(pr.Reviewers) > 0 || len(pr.ReviewerTeams) > 0:
+		return "already has reviewers"
+	default:
+		return ""
+	}
+}
+
+// RuleMatch is a repository rule that matched a pull request.
+type RuleMatch struct {
+	Reason string // The conditions that matched, e.g. `label "wip"`
+	Rule   config.Rule
+}
+
+// MatchRule returns the first of rules that matches pr, or nil if none does.
+func MatchRule(pr *types.PullRequest, rules []config.Rule) *RuleMatch {
+	for _, rule := range rules {
+		if reason, ok := matchRule(pr, &rule); ok {
+			return &RuleMatch{Rule: rule, Reason: reason}
+		}
+	}
+	return nil
+}
+
+// matchRule reports whether all of a rule's conditions match pr, describing what matched.
+func matchRule(pr *types.PullRequest, rule *config.Rule) (string, bool) {
+	var matched []string
+
+	if len(rule.Labels) > 0 {
+		i := slices.IndexFunc(pr.Labels, func(label string) bool {
+			return slices.ContainsFunc(rule.Labels, func(want string) bool { return strings.EqualFold(label, want) })
+		})
+		if i < 0 {
+			return "", false
+		}
+		matched = append(matched, fmt.Sprintf("label %q", pr.Labels[i]))
+	}
+
+	if len(rule.TitlePrefixes) > 0 {
+		title := strings.ToLower(strings.TrimSpace(pr.Title))
+		i := slices.IndexFunc(rule.TitlePrefixes, func(prefix string) bool {
+			return strings.HasPrefix(title, strings.ToLower(prefix))
+		})
+		if i < 0 {
+			return "", false
+		}
+		matched = append(matched, fmt.Sprintf("title prefix %q", rule.TitlePrefixes[i]))
+	}
+
+	if len(rule.Authors) > 0 {
+		if !slices.ContainsFunc(rule.Authors, func(author string) bool { return sameLogin(pr.Author, author) }) {
+			return "", false
+		}
+		matched = append(matched, fmt.Sprintf("author %q", pr.Author))
+	}
+
+	if len(rule.BaseBranches) > 0 {
+		if !slices.ContainsFunc(rule.BaseBranches, func(pattern string) bool {
+			ok, err := path.Match(pattern, pr.BaseRef)
+			return ok && err == nil
+		}) {
+			return "", false
+		}
+		matched = append(matched, fmt.Sprintf("base branch %q", pr.BaseRef))
+	}
+
+	return strings.Join(matched, " and "), len(matched) > 0
+}
+
+// sameLogin reports whether two logins name the same account, ignoring case, a
+// leading "@" and the "[bot]" suffix of GitHub App accounts.
+func sameLogin(a, b string) bool {
+	normalize := func(s string) string {
+		return strings.TrimSuffix(strings.ToLower(strings.TrimPrefix(strings.TrimSpace(s), "@")), "[bot]")
+	}
+	return normalize(a) == normalize(b)
+}
+
+// RoutedReviewers returns the users a route rule requests for pr, leaving out the
+// PR author and users excluded by cfg.
+func RoutedReviewers(pr *types.PullRequest, cfg *config.Config, rule *config.Rule) []string {
+	var reviewers []string
+	for _, u := range rule.Reviewers {
+		u = strings.TrimPrefix(u, "@")
+		if sameLogin(u, pr.Author) || cfg.IsExcluded(u) {
+			continue
+		}
+		reviewers = append(reviewers, u)
+	}
+	return reviewers
+}
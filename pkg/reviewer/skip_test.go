@@ -0,0 +1,85 @@
+package reviewer
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+func TestSkipReason(t *testing.T) {
+	tests := []struct {
+		name string
+		pr   types.PullRequest
+		want string
+	}{
+		{name: "ready", pr: types.PullRequest{}, want: ""},
+		{name: "draft", pr: types.PullRequest{Draft: true}, want: "draft"},
+		{name: "reviewers", pr: types.PullRequest{Reviewers: []string{"bob"}}, want: "already has reviewers"},
+		{name: "teams", pr: types.PullRequest{ReviewerTeams: []string{"core"}}, want: "already has reviewers"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SkipReason(&tt.pr); got != tt.want {
+				t.Errorf("SkipReason() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchRule(t *testing.T) {
+	rules := append(config.Default().Rules,
+		config.Rule{Action: config.RuleRoute, Authors: []string{"dependabot", "renovate"}, Teams: []string{"deps"}},
+		config.Rule{Action: config.RuleSkip, BaseBranches: []string{"gh-pages", "release-*"}},
+		config.Rule{Action: config.RuleSkip, Labels: []string{"docs"}, BaseBranches: []string{"main"}},
+	)
+
+	tests := []struct {
+		name       string
+		pr         types.PullRequest
+		wantAction string
+		wantReason string
+	}{
+		{name: "no match", pr: types.PullRequest{Title: "Fix bug", Author: "alice", BaseRef: "main"}},
+		{name: "label", pr: types.PullRequest{Labels: []string{"bug", "WIP"}}, wantAction: config.RuleSkip, wantReason: `label "WIP"`},
+		{name: "title prefix", pr: types.PullRequest{Title: "  wip: refactor"}, wantAction: config.RuleSkip, wantReason: `title prefix "WIP:"`},
+		{name: "do not merge", pr: types.PullRequest{Title: "[DNM] experiment"}, wantAction: config.RuleSkip, wantReason: `title prefix "[DNM]"`},
+		{name: "prefix elsewhere in title", pr: types.PullRequest{Title: "Remove WIP: markers"}},
+		{name: "bot author", pr: types.PullRequest{Author: "dependabot[bot]"}, wantAction: config.RuleRoute, wantReason: `author "dependabot[bot]"`},
+		{name: "base branch glob", pr: types.PullRequest{BaseRef: "release-1.2"}, wantAction: config.RuleSkip, wantReason: `base branch "release-1.2"`},
+		{name: "all conditions", pr: types.PullRequest{Labels: []string{"docs"}, BaseRef: "main"}, wantAction: config.RuleSkip, wantReason: `label "docs" and base branch "main"`},
+		{name: "some conditions", pr: types.PullRequest{Labels: []string{"docs"}, BaseRef: "dev"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			match := MatchRule(&tt.pr, rules)
+			if tt.wantAction == "" {
+				if match != nil {
+					t.Errorf("expected no match, got %+v", match)
+				}
+				return
+			}
+			if match == nil {
+				t.Fatal("expected a match")
+			}
+			if match.Rule.Action != tt.wantAction || match.Reason != tt.wantReason {
+				t.Errorf("MatchRule() = %s (%s), want %s (%s)", match.Rule.Action, match.Reason, tt.wantAction, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestRoutedReviewers(t *testing.T) {
+	cfg := config.Default()
+	cfg.ExcludeUsers = []string{"carol"}
+	rule := &config.Rule{Action: config.RuleRoute, Authors: []string{"alice"}, Reviewers: []string{"@bob", "Alice", "carol", "dave"}}
+	pr := &types.PullRequest{Author: "alice"}
+
+	got := RoutedReviewers(pr, cfg, rule)
+	if want := []string{"bob", "dave"}; !slices.Equal(got, want) {
+		t.Errorf("RoutedReviewers() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,88 @@
+package reviewer
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sort"
+	"strings"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+// FindTeams finds teams that should review a pull request, most relevant first.
+// Teams named in CODEOWNERS are ranked by how many changed files they own; teams listed
+// in the repository config follow. Returns team slugs within the PR's organization.
+func (f *Finder) FindTeams(ctx context.Context, pr *types.PullRequest) ([]string, error) {
+	if pr == nil {
+		return nil, errors.New("pr cannot be nil")
+	}
+
+	cfg, err := f.RepoConfig(ctx, pr.Owner, pr.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	counts := f.codeownerTeamFileCounts(ctx, pr)
+	teams := make([]string, 0, len(counts)+len(cfg.Teams))
+	for slug := range counts {
+		teams = append(teams, slug)
+	}
+	sort.Slice(teams, func(i, j int) bool {
+		if counts[teams[i]] != counts[teams[j]] {
+			return counts[teams[i]] > counts[teams[j]]
+		}
+		return teams[i] < teams[j]
+	})
+
+	for _, team := range cfg.Teams {
+		slug, ok := teamSlug(team, pr.Owner)
+		if !ok {
+			slog.WarnContext(ctx, "Ignoring configured team from another organization", "team", team, "config", cfg.Source)
+			continue
+		}
+		if _, exists := counts[slug]; !exists {
+			counts[slug] = 0
+			teams = append(teams, slug)
+		}
+	}
+
+	slog.InfoContext(ctx, "Team search complete", "pr", pr.Number, "teams", teams)
+	return teams, nil
+}
+
+// codeownerTeamFileCounts returns how many of the PR's changed files each CODEOWNERS team owns.
+// Only teams in the PR's organization are counted, since other teams cannot be requested.
+func (f *Finder) codeownerTeamFileCounts(ctx context.Context, pr *types.PullRequest) map[string]int {
+	counts := make(map[string]int)
+	co := f.repoCodeowners(ctx, pr.Owner, pr.Repository, pr.BaseRef)
+	if co == nil {
+		return counts
+	}
+
+	for _, file := range pr.ChangedFiles {
+		seen := make(map[string]bool)
+		for _, owner := range co.ownersFor(file.Filename) {
+			if !strings.HasPrefix(owner, "@") || !strings.Contains(owner, "/") {
+				continue // Not a team
+			}
+			slug, ok := teamSlug(owner, pr.Owner)
+			if ok && !seen[slug] {
+				seen[slug] = true
+				counts[slug]++
+			}
+		}
+	}
+	return counts
+}
+
+// teamSlug normalizes "@org/team", "org/team" or "team" to a team slug.
+// Returns false if the team belongs to an organization other than org.
+func teamSlug(team, org string) (string, bool) {
+	team = strings.TrimPrefix(strings.TrimSpace(team), "@")
+	teamOrg, slug, hasOrg := strings.Cut(team, "/")
+	if !hasOrg {
+		return team, true
+	}
+	return slug, strings.EqualFold(teamOrg, org)
+}
@@ -0,0 +1,70 @@
+package reviewer
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/internal/testutil"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+func TestFinder_FindTeams(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+	client.SetFileContent("acme", "repo", ".github/CODEOWNERS",
+		"/pkg/ @acme/backend @alice\n/pkg/api/ @acme/api @acme/backend\n/docs/ @other-org/writers\n")
+	client.SetFileContent("acme", "repo", ".github/best-reviewer.yml", "teams: [\"@acme/maintainers\", backend]\n")
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour})
+
+	pr := &types.PullRequest{
+		Owner:      "acme",
+		Repository: "repo",
+		ChangedFiles: []types.ChangedFile{
+			{Filename: "pkg/api/handler.go"},
+			{Filename: "pkg/store.go"},
+			{Filename: "docs/index.md"},
+		},
+	}
+
+	teams, err := finder.FindTeams(context.Background(), pr)
+	if err != nil {
+		t.Fatalf("FindTeams() error = %v", err)
+	}
+	want := []string{"backend", "api", "maintainers"}
+	if !reflect.DeepEqual(teams, want) {
+		t.Errorf("FindTeams() = %v, want %v", teams, want)
+	}
+}
+
+func TestFinder_FindTeams_None(t *testing.T) {
+	finder := newTestFinder(t, testutil.NewMockGitHubClient(), Config{PRCountCache: time.Hour})
+	pr := &types.PullRequest{Owner: "acme", Repository: "repo", ChangedFiles: []types.ChangedFile{{Filename: "a.go"}}}
+
+	teams, err := finder.FindTeams(context.Background(), pr)
+	if err != nil {
+		t.Fatalf("FindTeams() error = %v", err)
+	}
+	if len(teams) != 0 {
+		t.Errorf("expected no teams, got %v", teams)
+	}
+}
+
+func TestTeamSlug(t *testing.T) {
+	tests := []struct {
+		team   string
+		slug   string
+		sameOK bool
+	}{
+		{team: "@acme/core", slug: "core", sameOK: true},
+		{team: "ACME/core", slug: "core", sameOK: true},
+		{team: "core", slug: "core", sameOK: true},
+		{team: "@other/core", slug: "core", sameOK: false},
+	}
+	for _, tt := range tests {
+		slug, ok := teamSlug(tt.team, "acme")
+		if slug != tt.slug || ok != tt.sameOK {
+			t.Errorf("teamSlug(%q) = %q, %v; want %q, %v", tt.team, slug, ok, tt.slug, tt.sameOK)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package reviewer
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
+)
+
+// Weights holds the scoring weights used by the reviewer algorithm.
+// Field names in flags and config files use the snake_case form (e.g. "blame_line").
+type Weights struct {
+	Assignee              int // Flat score for PR assignees
+	Codeowner             int // Score per owned changed file
+	BlameLine             int // Score per blamed line
+	MergerMultiplier      int // Multiplier applied to merger scores
+	File                  int // Score per recent PR touching a changed file
+	Directory             int // Score per recent PR touching a changed directory
+	RecentActivityDivisor int // Divisor applied to project-wide activity counts
+	WorkloadPerPR         int // Penalty per open PR
+	WorkloadMaxPercent    int // Penalty cap as a percentage of expertise score
+	LimitedAvailability   int // Penalty for reviewers with limited availability, as a percentage of expertise score
+	HalfLifeDays          int // Age at which history counts half as much (0, the default, disables decay)
+	AuthorAffinity        int // Score per approval of the PR author's recent PRs
+	Responsiveness        int // Bonus or penalty for review response time, as a percentage of expertise score
+}
+
+// DefaultWeights returns the built-in scoring weights.
+func DefaultWeights() Weights {
+	return Weights{
+		Assignee:              200,
+		Codeowner:             10,
+		BlameLine:             1,
+		MergerMultiplier:      2,
+		File:                  5,
+		Directory:             3,
+		RecentActivityDivisor: 10,
+		WorkloadPerPR:         10,
+		WorkloadMaxPercent:    50,
+		LimitedAvailability:   50,
+		HalfLifeDays:          0,
+		AuthorAffinity:        3,
+		Responsiveness:        20,
+	}
+}
+
+// weightField pairs a weight's external name with its storage.
+type weightField struct {
+	name  string
+	value *int
+}
+
+// fields returns the weights in a stable order, keyed by their external names.
+func (w *Weights) fields() []weightField {
+	return []weightField{
+		{"assignee", &w.Assignee},
+		{"codeowner", &w.Codeowner},
+		{"blame_line", &w.BlameLine},
+		{"merger_multiplier", &w.MergerMultiplier},
+		{"file", &w.File},
+		{"directory", &w.Directory},
+		{"recent_activity_divisor", &w.RecentActivityDivisor},
+		{"workload_per_pr", &w.WorkloadPerPR},
+		{"workload_max_percent", &w.WorkloadMaxPercent},
+		{"limited_availability_percent", &w.LimitedAvailability},
+		{"half_life_days", &w.HalfLifeDays},
+		{"author_affinity", &w.AuthorAffinity},
+		{"responsiveness_percent", &w.Responsiveness},
+	}
+}
+
+// Validate checks that all weights are within acceptable ranges.
+func (w Weights) Validate() error {
+	var errs []error
+	for _, f := range w.fields() {
+		if err := config.ValidateWeight(f.name, *f.value); err != nil {
+			errs = append(errs, fmt.Errorf("weight %s %w", f.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// String formats the weights as a comma-separated list of name=value pairs.
+func (w *Weights) String() string {
+	if w == nil {
+		return ""
+	}
+	parts := make([]string, 0, len(w.fields()))
+	for _, f := range w.fields() {
+		parts = append(parts, fmt.Sprintf("%s=%d", f.name, *f.value))
+	}
+	return strings.Join(parts, ",")
+}
+
+// Set parses a comma-separated list of name=value overrides, e.g. "assignee=100,file=8".
+// Weights not mentioned keep their current value, so Weights can be used as a flag.Value.
+func (w *Weights) Set(spec string) error {
+	for _, pair := range strings.Split(spec, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		name, raw, ok := strings.Cut(pair, "=")
+		if !ok {
+			return fmt.Errorf("invalid weight %q (expected name=value)", pair)
+		}
+		n, err := strconv.Atoi(strings.TrimSpace(raw))
+		if err != nil {
+			return fmt.Errorf("invalid value for weight %s: %w", name, err)
+		}
+		if !w.setField(strings.TrimSpace(name), n) {
+			return fmt.Errorf("unknown weight %q", name)
+		}
+	}
+	return w.Validate()
+}
+
+// setField sets a weight by external name. Returns false if the name is unknown.
+func (w *Weights) setField(name string, value int) bool {
+	for _, f := range w.fields() {
+		if f.name == name {
+			*f.value = value
+			return true
+		}
+	}
+	return false
+}
+
+// withOverrides returns a copy of the weights with config file overrides applied.
+func (w Weights) withOverrides(cw config.Weights) Weights {
+	for _, o := range cw.Fields() {
+		if o.Value != nil {
+			w.setField(o.Name, *o.Value)
+		}
+	}
+	return w
+}
+
+// decayFactor returns how much history from t counts at now: 1 for fresh history, halving
+// every HalfLifeDays. With decay disabled everything counts fully. History without a date
+// counts as if one half-life old, so it never outranks fresh history.
+func (w Weights) decayFactor(t, now time.Time) float64 {
+	if w.HalfLifeDays <= 0 {
+		return 1
+	}
+	if t.IsZero() {
+		return 0.5
+	}
+	if !t.Before(now) {
+		return 1
+	}
+	halfLife := time.Duration(w.HalfLifeDays) * 24 * time.Hour
+	return math.Pow(0.5, float64(now.Sub(t))/float64(halfLife))
+}
+
+// decay scales score by the age of the history it came from. See decayFactor.
+func (w Weights) decay(score int, t, now time.Time) int {
+	return int(math.Round(float64(score) * w.decayFactor(t, now)))
+}
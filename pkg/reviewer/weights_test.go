@@ -0,0 +1,188 @@
+package reviewer
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/config"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/internal/testutil"
+	"github.com/codeGROOVE-dev/best-reviewer/pkg/types"
+)
+
+func TestDefaultWeights_Valid(t *testing.T) {
+	if err := DefaultWeights().Validate(); err != nil {
+		t.Errorf("default weights should be valid: %v", err)
+	}
+}
+
+func TestWeights_Set(t *testing.T) {
+	w := DefaultWeights()
+	if err := w.Set("assignee=100, file=8"); err != nil {
+		t.Fatalf("Set() error = %v", err)
+	}
+	if w.Assignee != 100 || w.File != 8 {
+		t.Errorf("expected assignee=100 file=8, got %+v", w)
+	}
+	if w.Directory != DefaultWeights().Directory {
+		t.Errorf("expected unmentioned weights to keep defaults, got directory=%d", w.Directory)
+	}
+
+	roundTrip := DefaultWeights()
+	if err := roundTrip.Set(w.String()); err != nil || roundTrip != w {
+		t.Errorf("String() should round-trip through Set(), got %+v (err %v)", roundTrip, err)
+	}
+}
+
+func TestWeights_Set_Invalid(t *testing.T) {
+	tests := []struct {
+		spec    string
+		wantErr string
+	}{
+		{spec: "assignee", wantErr: "expected name=value"},
+		{spec: "bogus=1", wantErr: "unknown weight"},
+		{spec: "file=abc", wantErr: "invalid value"},
+		{spec: "file=-2", wantErr: "cannot be negative"},
+		{spec: "recent_activity_divisor=0", wantErr: "must be positive"},
+		{spec: "workload_max_percent=150", wantErr: "cannot exceed 100"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.spec, func(t *testing.T) {
+			w := DefaultWeights()
+			err := w.Set(tt.spec)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Set(%q) error = %v, want containing %q", tt.spec, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestWeights_withOverrides(t *testing.T) {
+	base := DefaultWeights()
+	base.File = 7
+
+	dir := 9
+	got := base.withOverrides(config.Weights{Directory: &dir})
+	if got.Directory != 9 || got.File != 7 || got.Assignee != 200 {
+		t.Errorf("unexpected weights after overrides: %+v", got)
+	}
+	if base.Directory != DefaultWeights().Directory {
+		t.Error("withOverrides should not modify the receiver")
+	}
+}
+
+func TestNew_Weights(t *testing.T) {
+	client := testutil.NewMockGitHubClient()
+
+	if f := newTestFinder(t, client, Config{}); f.weights != DefaultWeights() {
+		t.Errorf("expected zero-value weights to use defaults, got %+v", f.weights)
+	}
+
+	invalid := DefaultWeights()
+	invalid.RecentActivityDivisor = 0
+	f, err := New(client, Config{Weights: invalid})
+	if err == nil || !strings.Contains(err.Error(), "recent_activity_divisor must be positive") {
+		t.Errorf("New() error = %v, want invalid weights", err)
+	}
+	if f != nil {
+		t.Errorf("New() = %v, want nil Finder for invalid weights", f)
+	}
+}
+
+func TestWeights_fieldsMatchConfig(t *testing.T) {
+	// Every config file override must name a weight, and every weight must be overridable
+	var cw config.Weights
+	overrides := cw.Fields()
+	w := DefaultWeights()
+	if len(overrides) != len(w.fields()) {
+		t.Fatalf("config.Weights has %d fields, Weights has %d", len(overrides), len(w.fields()))
+	}
+	for _, o := range overrides {
+		if !w.setField(o.Name, 1) {
+			t.Errorf("config weight %q is not a scoring weight", o.Name)
+		}
+	}
+}
+
+func TestFindReviewersOptimized_CustomWeights(t *testing.T) {
+	ctx := context.Background()
+	client := testutil.NewMockGitHubClient()
+	client.SetWriteAccess("owner", "repo", "bob", true)
+	client.SetBatchOpenPRCount("owner", map[string]int{"bob": 3})
+
+	w := DefaultWeights()
+	w.Assignee = 60
+	w.WorkloadPerPR = 5
+	finder := newTestFinder(t, client, Config{PRCountCache: time.Hour, Weights: w})
+
+	pr := &types.PullRequest{Owner: "owner", Repository: "repo", Author: "alice", Assignees: []string{"bob"}}
+	candidates, _ := finder.findReviewersOptimized(ctx, pr, config.Default())
+	if len(candidates) != 1 {
+		t.Fatalf("expected 1 candidate, got %+v", candidates)
+	}
+	// 60 assignee - 3 open PRs * 5
+	if candidates[0].ContextScore != 45 {
+		t.Errorf("expected score 45, got %d (%s)", candidates[0].ContextScore, candidates[0].SelectionMethod)
+	}
+}
+
+func TestWeights_decay(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	w := DefaultWeights()
+	w.HalfLifeDays = 100
+
+	tests := []struct {
+		at   time.Time
+		name string
+		want int
+	}{
+		{name: "fresh", at: now, want: 40},
+		{name: "one half-life", at: now.AddDate(0, 0, -100), want: 20},
+		{name: "two half-lives", at: now.AddDate(0, 0, -200), want: 10},
+		{name: "undated counts as one half-life old", want: 20},
+		{name: "future", at: now.Add(time.Hour), want: 40},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := w.decay(40, tt.at, now); got != tt.want {
+				t.Errorf("decay(40) = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	w.HalfLifeDays = 0
+	if got := w.decay(40, now.AddDate(-5, 0, 0), now); got != 40 {
+		t.Errorf("expected no decay with half_life_days=0, got %d", got)
+	}
+	if got := w.decay(40, time.Time{}, now); got != 40 {
+		t.Errorf("expected undated history to count fully with half_life_days=0, got %d", got)
+	}
+	if got := DefaultWeights().decay(40, now.AddDate(-5, 0, 0), now); got != 40 {
+		t.Errorf("expected decay to be off by default, got %d", got)
+	}
+}
+
+func TestFinder_scoreBlame_Decay(t *testing.T) {
+	now := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	finder := newTestFinder(t, testutil.NewMockGitHubClient(), Config{})
+	finder.now = func() time.Time { return now }
+
+	w := DefaultWeights()
+	w.HalfLifeDays = 180
+	candidates := make(map[string]*candidateWeight)
+	overlapping := []types.PRInfo{
+		{Number: 1, Author: "fresh", LineCount: 20, MergedAt: now.AddDate(0, 0, -7)},
+		{Number: 2, Author: "stale", LineCount: 20, MergedAt: now.AddDate(-2, 0, 0)},
+	}
+	finder.scoreBlame(context.Background(), candidates, "main.go", overlapping, nil, w)
+
+	fresh, stale := candidates["fresh"].weight, candidates["stale"].weight
+	if fresh != 19 || stale != 1 {
+		t.Errorf("expected week-old blame to score 19 and two-year-old blame 1, got %d and %d", fresh, stale)
+	}
+	if e := candidates["stale"].evidence[0]; e.Score != stale || !e.MergedAt.Equal(overlapping[1].MergedAt) {
+		t.Errorf("expected evidence to record the decayed score and merge date, got %+v", e)
+	}
+}
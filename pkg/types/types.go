@@ -7,45 +7,107 @@ import "time"
 
 // PullRequest represents a GitHub pull request.
 type PullRequest struct {
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
-	LastCommit   time.Time
-	LastReview   time.Time
-	Title        string
-	State        string
-	Author       string
-	Repository   string
-	Owner        string
-	TestState    string // "passing", "failing", "pending", "queued", "running", or ""
-	ChangedFiles []ChangedFile
-	Assignees    []string
-	Reviewers    []string
-	Number       int
-	Draft        bool
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+	LastCommit    time.Time
+	LastReview    time.Time
+	Title         string
+	State         string
+	Author        string
+	Repository    string
+	Owner         string
+	TestState     string // "passing", "failing", "pending", "queued", "running", or ""
+	BaseRef       string // Branch the PR merges into, e.g. "main" or "release-1.2"
+	HeadSHA       string // Latest commit on the PR branch
+	ChangedFiles  []ChangedFile
+	Assignees     []string
+	Reviewers     []string
+	ReviewerTeams []string // Slugs of teams with pending review requests
+	Labels        []string // Label names
+	Number        int
+	Draft         bool
 }
 
 // ChangedFile represents a file changed in a pull request.
 type ChangedFile struct {
-	Filename  string
-	Status    string // "added", "modified", "removed", "renamed"
-	Patch     string
-	Additions int
-	Deletions int
+	Filename         string
+	PreviousFilename string // Path before a rename ("" unless Status is "renamed")
+	Status           string // "added", "modified", "removed", "renamed"
+	Patch            string
+	Additions        int
+	Deletions        int
 }
 
 // ReviewerCandidate represents a potential reviewer with scoring metadata.
 type ReviewerCandidate struct {
-	LastActivity      time.Time
-	Username          string
-	SelectionMethod   string
-	AuthorAssociation string
-	ContextScore      int
-	ActivityScore     int
+	LastActivity      time.Time       `json:"last_activity,omitzero"`
+	Breakdown         *ScoreBreakdown `json:"breakdown,omitempty"`   // Structured explanation of ContextScore
+	FileScores        map[string]int  `json:"file_scores,omitempty"` // Score each changed file's history contributed
+	Username          string          `json:"username"`
+	SelectionMethod   string          `json:"selection_method"` // Human-readable summary, e.g. "blame-author:+12, workload:-5"
+	AuthorAssociation string          `json:"author_association,omitempty"`
+	ContextScore      int             `json:"context_score"`
+	ActivityScore     int             `json:"activity_score,omitempty"`
+}
+
+// ScoreBreakdown explains how a candidate's score was computed.
+type ScoreBreakdown struct {
+	Sources             map[string]int `json:"sources"`            // Score contributed by each source, e.g. "blame-author" -> 12
+	Evidence            []Evidence     `json:"evidence,omitempty"` // History that contributed to the source scores
+	Expertise           int            `json:"expertise"`          // Sum of source scores before workload penalty
+	WorkloadPenalty     int            `json:"workload_penalty"`
+	OpenPRs             int            `json:"open_prs"`                     // Open PRs assigned to or awaiting review from the candidate
+	RecentAssignments   int            `json:"recent_assignments,omitempty"` // Part of OpenPRs: requests made since counts were cached
+	Availability        string         `json:"availability,omitempty"`       // Why the candidate has limited availability, e.g. "GitHub status: busy"
+	AvailabilityPenalty int            `json:"availability_penalty,omitempty"`
+	Responsiveness      int            `json:"responsiveness,omitempty"`        // Bonus (positive) or penalty (negative) for how quickly the candidate reviews
+	MedianResponseHours float64        `json:"median_response_hours,omitempty"` // Median time from review request to first review
+}
+
+// Coverage describes how much of a PR's changes the history analysis covered within its API budget.
+type Coverage struct {
+	FilesAnalyzed       int `json:"files_analyzed"`       // Changed files whose lines were blamed
+	FilesSkipped        int `json:"files_skipped"`        // Changed files left unblamed to stay within the budget
+	DirectoriesAnalyzed int `json:"directories_analyzed"` // Directories whose recent history was checked
+	ChangedLines        int `json:"changed_lines"`        // Changed lines that existed before the PR, in analyzed and skipped files
+	BlamedLines         int `json:"blamed_lines"`         // Changed lines whose last author was found
+	APIRequests         int `json:"api_requests"`         // GraphQL requests spent on file and directory history
+	APIBudget           int `json:"api_budget"`
+}
+
+// Ratio returns the fraction of changed lines with blame evidence, or 1 if no pre-existing lines changed.
+func (c *Coverage) Ratio() float64 {
+	if c.ChangedLines == 0 {
+		return 1
+	}
+	return float64(c.BlamedLines) / float64(c.ChangedLines)
+}
+
+// Evidence is a single piece of history supporting a candidate.
+type Evidence struct {
+	MergedAt time.Time `json:"merged_at,omitzero"` // When the history landed, if known
+	Source   string    `json:"source"`             // Scoring source this evidence counted toward
+	Path     string    `json:"path,omitempty"`     // File or directory involved
+	Lines    int       `json:"lines,omitempty"`    // Changed lines the candidate last touched
+	PR       int       `json:"pr,omitempty"`       // Historical PR number (0 for direct commits)
+	Score    int       `json:"score"`              // Points contributed, after time decay
+}
+
+// OpenPRCount is a user's open PR workload and when GitHub was asked for it.
+type OpenPRCount struct {
+	FetchedAt time.Time // Zero if unknown; cached counts keep the time they were fetched
+	Count     int       // Open PRs the user is assigned to or asked to review
+}
+
+// ResponseTime summarizes how quickly a reviewer responds to review requests.
+type ResponseTime struct {
+	Median  time.Duration // Median time from review request to the reviewer's first review; ignored requests count as misses
+	Samples int           // Review requests the median is based on
 }
 
 // PRInfo holds basic PR information for historical analysis.
 type PRInfo struct {
-	MergedAt  time.Time
+	MergedAt  time.Time // Commit date for direct commits
 	Author    string
 	MergedBy  string
 	Reviewers []string